@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/arena"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/skill"
+)
+
+func main() {
+	first := flag.String("a", "heuristic", "first AI: a player of the config or an AI spec such as expectimax:depth=2, AIs: "+strings.Join(ai.Names(), ", "))
+	second := flag.String("b", "heuristic", "second AI: a player of the config or an AI spec")
+	configPath := flag.String("config", "", "JSON file naming AI players, e.g. {\"players\": {\"strong\": \"expectimax:depth=2\"}}")
+	games := flag.Int("games", 100, "number of games, or matches if -match-length is set, played in mirrored pairs")
+	matchLength := flag.Int("match-length", 0, "length of the matches, played with the Crawford rule, 0 to play single games")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed for the dice")
+	parallel := flag.Int("parallel", runtime.NumCPU(), "number of games played at the same time")
+	jsonOutput := flag.Bool("json", false, "write the report as JSON")
+	timeControl := flag.String("time", "", "time control of the games, e.g. fischer:1m+2s or bronstein:1m+2s,position; untimed by default")
+	playCube := flag.Bool("cube", false, "play single games with the doubling cube, the cube decisions are evaluated with the heuristic evaluator")
+	calibrate := flag.Bool("calibrate", false, "measure the error rate of every skill level over -games games instead of playing")
+	flag.Parse()
+
+	if *calibrate {
+		for _, level := range skill.LEVELS {
+			measurement, err := skill.Measure(level, ai.HeuristicEvaluator{}, *games, *seed)
+			if err != nil {
+				exit(err)
+			}
+			fmt.Printf("%-12s %6.1f ± %.1f mEMG over %d decisions, expected %.1f\n",
+				level.Name, measurement.ErrorRate, measurement.StandardError, measurement.Decisions, level.ErrorRate)
+		}
+		return
+	}
+
+	config, err := ai.LoadConfigOrDefault(*configPath)
+	if err != nil {
+		exit(err)
+	}
+
+	firstPlayer, err := player(config, *first)
+	if err != nil {
+		exit(err)
+	}
+	secondPlayer, err := player(config, *second)
+	if err != nil {
+		exit(err)
+	}
+
+	options := arena.Options{
+		Games:       *games,
+		MatchLength: *matchLength,
+		Seed:        *seed,
+		Parallel:    *parallel,
+	}
+	if *timeControl != "" {
+		control, err := game.ParseTimeControl(*timeControl)
+		if err != nil {
+			exit(err)
+		}
+		options.TimeControl = &control
+	}
+	if *playCube {
+		options.CubeEvaluator = ai.HeuristicEvaluator{}
+	}
+	report, err := arena.Run(firstPlayer, secondPlayer, options)
+	if err != nil {
+		exit(err)
+	}
+	if *jsonOutput {
+		err = report.WriteJSON(os.Stdout)
+	} else {
+		fmt.Printf("Seed %d\n", *seed)
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		exit(err)
+	}
+}
+
+func player(config ai.Config, nameOrSpec string) (arena.Player, error) {
+	playerAI, err := config.New(nameOrSpec)
+	if err != nil {
+		return arena.Player{}, err
+	}
+	return arena.Player{Name: nameOrSpec, AI: playerAI}, nil
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/rpc"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/rpc/enginepb"
+	// Registers the skill AI
+	_ "github.com/GeorgianBadita/backgammon-move-generator/pkg/skill"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	configPath := flag.String("config", "", "JSON file naming the AI players, every registered AI with its default options by default")
+	flag.Parse()
+
+	config, err := ai.LoadConfigOrDefault(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ais, err := config.AIs()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server := grpc.NewServer()
+	enginepb.RegisterEngineServer(server, rpc.NewService(rpc.Options{
+		AIs:       ais,
+		Evaluator: ai.HeuristicEvaluator{},
+	}))
+	log.Printf("Listening on %s", *addr)
+	log.Fatal(server.Serve(listener))
+}
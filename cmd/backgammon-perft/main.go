@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/perft"
+)
+
+func main() {
+	position := flag.String("position", board.NewBoard(board.COLOR_WHITE).SerializeBoard(), "serialized board to count from")
+	depth := flag.Int("depth", 2, "number of plies, a ply being a dice roll and a move roll")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of goroutines counting the 21 first rolls")
+	reference := flag.Bool("reference", false, "count with the reference generator instead of the board package")
+	divide := flag.Bool("divide", false, "print the count of every first roll")
+	check := flag.Bool("check", false, "cross-check the board package against the reference generator in every position to -depth instead of counting")
+	flag.Parse()
+
+	b, err := board.ParseBoard(*position)
+	if err != nil {
+		exit(err)
+	}
+
+	if *check {
+		start := time.Now()
+		mismatches := perft.CheckTree(b, *depth)
+		for _, mismatch := range mismatches {
+			fmt.Println(mismatch)
+		}
+		fmt.Printf("%d mismatch(es) in %v\n", len(mismatches), time.Since(start).Round(time.Millisecond))
+		if len(mismatches) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	generator := perft.BoardGenerator
+	if *reference {
+		generator = perft.ReferenceGenerator
+	}
+	generations := uint64(0)
+	counted := func(b board.Board, d board.DieRoll) []board.Board {
+		atomic.AddUint64(&generations, 1)
+		return generator(b, d)
+	}
+
+	start := time.Now()
+	counts := perft.Divide(b, *depth, counted, *workers)
+	elapsed := time.Since(start)
+
+	total := uint64(0)
+	for idx, roll := range perft.Rolls() {
+		total += counts[idx]
+		if *divide {
+			fmt.Printf("%d-%d %d\n", roll.Die1, roll.Die2, counts[idx])
+		}
+	}
+	if *depth <= 0 {
+		total = 1
+	}
+	seconds := elapsed.Seconds()
+	fmt.Printf("Depth %d: %d leaves in %v, %.0f leaves/s, %.0f generations/s\n",
+		*depth, total, elapsed.Round(time.Millisecond), float64(total)/seconds, float64(generations)/seconds)
+
+	if expected, ok := perft.ReferenceCount(b, *depth); ok {
+		if total != expected {
+			fmt.Printf("MISMATCH: the reference count is %d\n", expected)
+			os.Exit(1)
+		}
+		fmt.Println("Matches the reference count")
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/server"
+	// Registers the skill AI
+	_ "github.com/GeorgianBadita/backgammon-move-generator/pkg/skill"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	configPath := flag.String("config", "", "JSON file naming the AI players, every registered AI with its default options by default")
+	moveTimeout := flag.Duration("move-timeout", 2*time.Minute, "time a player of a room has to move, 0 for no limit")
+	reconnectTimeout := flag.Duration("reconnect-timeout", time.Minute, "time a disconnected player of a room has to reconnect, 0 for no limit")
+	maxSessions := flag.Int("max-sessions", server.DEFAULT_MAX_SESSIONS, "maximum number of game sessions")
+	sessionIdleTimeout := flag.Duration("session-idle-timeout", server.DEFAULT_SESSION_IDLE_TIMEOUT, "time after which an unused game session is deleted")
+	maxRooms := flag.Int("max-rooms", server.DEFAULT_MAX_ROOMS, "maximum number of multiplayer rooms")
+	roomIdleTimeout := flag.Duration("room-idle-timeout", server.DEFAULT_ROOM_IDLE_TIMEOUT, "time after which a room without connected clients is deleted")
+	flag.Parse()
+
+	config, err := ai.LoadConfigOrDefault(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ais, err := config.AIs()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	handler := server.New(server.Options{
+		AIs:                ais,
+		Evaluator:          ai.HeuristicEvaluator{},
+		MoveTimeout:        *moveTimeout,
+		ReconnectTimeout:   *reconnectTimeout,
+		MaxSessions:        *maxSessions,
+		SessionIdleTimeout: *sessionIdleTimeout,
+		MaxRooms:           *maxRooms,
+		RoomIdleTimeout:    *roomIdleTimeout,
+	})
+	log.Printf("Listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, handler))
+}
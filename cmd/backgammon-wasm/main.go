@@ -0,0 +1,188 @@
+//go:build js && wasm
+
+// Command backgammon-wasm exposes the move generator and the AI to
+// JavaScript. Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o backgammon.wasm ./cmd/backgammon-wasm
+//
+// and run it with the wasm_exec.js support file of the Go distribution.
+// Once started, it defines a global backgammon object whose functions take
+// serialized boards and JSON encoded moves and move rolls, e.g.
+// [{"from":23,"to":17,"type":"normal"}], and return strings. Invalid
+// arguments return an Error instead of a string
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"syscall/js"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+// AI choosing the moves of chooseMove
+var moveChooser = ai.EvaluatorAI{Evaluator: ai.HeuristicEvaluator{}}
+
+func main() {
+	js.Global().Set("backgammon", js.ValueOf(map[string]interface{}{
+		"getMoveRollsForSerializedBoard": export(getMoveRolls),
+		"getMovesForSerializedBoard":     export(getMoves),
+		"makeMoveOnSerializedBoard":      export(makeMove),
+		"makeMoveRollOnSerializedBoard":  export(makeMoveRoll),
+		"chooseMove":                     export(chooseMove),
+	}))
+	select {}
+}
+
+// getMoveRollsForSerializedBoard(board, die1, die2) returns the JSON encoded legal move rolls
+func getMoveRolls(args []js.Value) (interface{}, error) {
+	boardStr, err := boardArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	dieRoll, err := dieRollArgs(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	mvRolls := game.GetMoveRollsForSerializedBoard(boardStr, dieRoll)
+	if mvRolls == nil {
+		mvRolls = []board.MoveRoll{}
+	}
+	return marshal(mvRolls)
+}
+
+// getMovesForSerializedBoard(board, die) returns the JSON encoded legal moves for a single die
+func getMoves(args []js.Value) (interface{}, error) {
+	boardStr, err := boardArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	die, err := dieArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	moves := game.GetMovesForSerializedBoard(boardStr, die)
+	if moves == nil {
+		moves = []board.Move{}
+	}
+	return marshal(moves)
+}
+
+// makeMoveOnSerializedBoard(board, move, endOfTurn) returns the serialized board after the move
+func makeMove(args []js.Value) (interface{}, error) {
+	boardStr, err := boardArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	mv := board.Move{}
+	if err := unmarshalArg(args, 1, &mv); err != nil {
+		return nil, err
+	}
+	if len(args) < 3 || args[2].Type() != js.TypeBoolean {
+		return nil, fmt.Errorf("argument 3 must be a boolean")
+	}
+	return game.MakeMoveOnSerializedBoard(boardStr, mv, args[2].Bool()), nil
+}
+
+// makeMoveRollOnSerializedBoard(board, moveRoll) returns the serialized board after the
+// move roll, with the opponent to move
+func makeMoveRoll(args []js.Value) (interface{}, error) {
+	boardStr, err := boardArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	mvRoll := board.MoveRoll{}
+	if err := unmarshalArg(args, 1, &mvRoll); err != nil {
+		return nil, err
+	}
+	return game.MakeMoveRollOnSerializedBoard(boardStr, mvRoll), nil
+}
+
+// chooseMove(board, die1, die2) returns the JSON encoded move roll chosen by the AI
+func chooseMove(args []js.Value) (interface{}, error) {
+	boardStr, err := boardArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	dieRoll, err := dieRollArgs(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return marshal(moveChooser.ChooseMove(board.DeserializeBoard(boardStr), dieRoll))
+}
+
+// Function that wraps fn as a JavaScript function returning an Error for
+// invalid arguments, including the ones making the board package panic
+func export(fn func(args []js.Value) (interface{}, error)) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) (result interface{}) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = js.Global().Get("Error").New(fmt.Sprint(r))
+			}
+		}()
+		value, err := fn(args)
+		if err != nil {
+			return js.Global().Get("Error").New(err.Error())
+		}
+		return value
+	})
+}
+
+func stringArg(args []js.Value, idx int) (string, error) {
+	if len(args) <= idx || args[idx].Type() != js.TypeString {
+		return "", fmt.Errorf("argument %d must be a string", idx+1)
+	}
+	return args[idx].String(), nil
+}
+
+// Function that returns a serialized board argument, checking it is valid
+func boardArg(args []js.Value, idx int) (string, error) {
+	boardStr, err := stringArg(args, idx)
+	if err != nil {
+		return "", err
+	}
+	if _, err := board.ParseBoard(boardStr); err != nil {
+		return "", err
+	}
+	return boardStr, nil
+}
+
+func dieArg(args []js.Value, idx int) (int, error) {
+	if len(args) <= idx || args[idx].Type() != js.TypeNumber || args[idx].Int() < 1 || args[idx].Int() > 6 {
+		return 0, fmt.Errorf("argument %d must be a die value between 1 and 6", idx+1)
+	}
+	return args[idx].Int(), nil
+}
+
+func dieRollArgs(args []js.Value, idx int) (board.DieRoll, error) {
+	die1, err := dieArg(args, idx)
+	if err != nil {
+		return board.DieRoll{}, err
+	}
+	die2, err := dieArg(args, idx+1)
+	if err != nil {
+		return board.DieRoll{}, err
+	}
+	return board.DieRoll{Die1: die1, Die2: die2}, nil
+}
+
+func unmarshalArg(args []js.Value, idx int, value interface{}) error {
+	data, err := stringArg(args, idx)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(data), value); err != nil {
+		return fmt.Errorf("argument %d: %v", idx+1, err)
+	}
+	return nil
+}
+
+func marshal(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
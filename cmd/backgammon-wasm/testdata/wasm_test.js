@@ -0,0 +1,92 @@
+// Runs the WebAssembly build with node and checks the exported functions:
+//
+//	node wasm_test.js <wasm_exec.js> <backgammon.wasm>
+"use strict";
+
+const assert = require("assert");
+const fs = require("fs");
+
+globalThis.require = require;
+globalThis.fs = fs;
+globalThis.path = require("path");
+globalThis.TextEncoder = require("util").TextEncoder;
+globalThis.TextDecoder = require("util").TextDecoder;
+globalThis.performance ??= require("perf_hooks").performance;
+globalThis.crypto ??= require("crypto");
+
+require(process.argv[2]);
+
+const INITIAL_WHITE = "6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w";
+
+const tests = {
+  moveRolls(bg) {
+    const mvRolls = JSON.parse(bg.getMoveRollsForSerializedBoard(INITIAL_WHITE, 6, 5));
+    assert.ok(mvRolls.length > 0);
+    assert.ok(mvRolls.some((mvRoll) => JSON.stringify(mvRoll) ===
+      '[{"from":23,"to":17,"type":"normal"},{"from":17,"to":12,"type":"normal"}]'));
+  },
+
+  moves(bg) {
+    const moves = JSON.parse(bg.getMovesForSerializedBoard(INITIAL_WHITE, 6));
+    assert.deepStrictEqual(moves.map((mv) => mv.from).sort((a, b) => a - b), [7, 12, 23]);
+  },
+
+  noMoves(bg) {
+    // White is on the bar and black holds every entry point
+    const closed = "6-5:19-2/20-2/21-2/22-2/23-2/24-2 1 0 w";
+    assert.strictEqual(bg.getMoveRollsForSerializedBoard(closed, 6, 5), "[]");
+  },
+
+  makeMove(bg) {
+    const mv = '{"from":23,"to":17,"type":"normal"}';
+    assert.strictEqual(bg.makeMoveOnSerializedBoard(INITIAL_WHITE, mv, false),
+      "6-5/8-3/13-5/18-1/24-1:1-2/12-5/17-3/19-5 0 0 w");
+    assert.strictEqual(bg.makeMoveOnSerializedBoard(INITIAL_WHITE, mv, true),
+      "6-5/8-3/13-5/18-1/24-1:1-2/12-5/17-3/19-5 0 0 b");
+  },
+
+  makeMoveRoll(bg) {
+    const mvRoll = '[{"from":23,"to":17,"type":"normal"},{"from":17,"to":12,"type":"normal"}]';
+    assert.strictEqual(bg.makeMoveRollOnSerializedBoard(INITIAL_WHITE, mvRoll),
+      "6-5/8-3/13-6/24-1:1-2/12-5/17-3/19-5 0 0 b");
+  },
+
+  chooseMove(bg) {
+    const chosen = bg.chooseMove(INITIAL_WHITE, 3, 1);
+    const legal = JSON.parse(bg.getMoveRollsForSerializedBoard(INITIAL_WHITE, 3, 1));
+    assert.ok(legal.some((mvRoll) => JSON.stringify(mvRoll) === chosen));
+  },
+
+  invalidArguments(bg) {
+    assert.ok(bg.getMoveRollsForSerializedBoard("nonsense", 6, 5) instanceof Error);
+    assert.ok(bg.getMoveRollsForSerializedBoard(INITIAL_WHITE, 7, 5) instanceof Error);
+    assert.ok(bg.getMoveRollsForSerializedBoard(INITIAL_WHITE) instanceof Error);
+    assert.ok(bg.makeMoveRollOnSerializedBoard(INITIAL_WHITE, "[{") instanceof Error);
+    assert.ok(bg.makeMoveRollOnSerializedBoard(INITIAL_WHITE, '[{"from":23,"to":17,"type":"jump"}]') instanceof Error);
+    // Moving a checker of the opponent makes the board package panic
+    assert.ok(bg.makeMoveOnSerializedBoard(INITIAL_WHITE, '{"from":0,"to":6,"type":"normal"}', true) instanceof Error);
+  },
+};
+
+async function main() {
+  const go = new Go();
+  const { instance } = await WebAssembly.instantiate(fs.readFileSync(process.argv[3]), go.importObject);
+  go.run(instance);
+
+  let failed = 0;
+  for (const [name, test] of Object.entries(tests)) {
+    try {
+      test(globalThis.backgammon);
+      console.log("ok   " + name);
+    } catch (err) {
+      failed++;
+      console.log("FAIL " + name + ": " + err.message);
+    }
+  }
+  process.exit(failed === 0 ? 0 : 1);
+}
+
+main().catch((err) => {
+  console.error(err);
+  process.exit(1);
+});
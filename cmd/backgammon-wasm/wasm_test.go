@@ -0,0 +1,49 @@
+//go:build !js
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// Function that finds the JavaScript support file of the Go distribution,
+// moved from misc/wasm to lib/wasm in Go 1.24
+func wasmExecPath(t *testing.T) string {
+	for _, dir := range []string{"lib", "misc"} {
+		path := filepath.Join(runtime.GOROOT(), dir, "wasm", "wasm_exec.js")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	t.Skip("wasm_exec.js not found in GOROOT")
+	return ""
+}
+
+// Builds the WebAssembly entry point and runs testdata/wasm_test.js with node
+func TestWasm(t *testing.T) {
+	// ARRANGE
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found in PATH")
+	}
+	wasmExec := wasmExecPath(t)
+	wasm := filepath.Join(t.TempDir(), "backgammon.wasm")
+	build := exec.Command(filepath.Join(runtime.GOROOT(), "bin", "go"), "build", "-o", wasm, ".")
+	build.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Unexpected error %v building the wasm binary:\n%s", err, output)
+	}
+
+	// ACT
+	output, err := exec.Command(node, filepath.Join("testdata", "wasm_test.js"), wasmExec, wasm).CombinedOutput()
+
+	// ASSERT
+	t.Logf("\n%s", output)
+	if err != nil {
+		t.Errorf("Unexpected error %v running the node tests", err)
+	}
+}
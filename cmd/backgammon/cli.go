@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/render"
+)
+
+// Number of moves shown by the hint command without an argument
+const DEFAULT_HINT_COUNT = 5
+
+const helpText = `Commands:
+  roll (or an empty line)  roll the dice
+  moves                    list the legal moves for the rolled dice
+  hint [n]                 show the n best moves for the rolled dice, 5 by default
+  <number>                 play the legal move with the given number
+  <notation>               play a move written in standard notation, e.g. 13/7 8/7
+  undo                     take back your last move
+  resign [1|2|3]           resign a single game, a gammon or a backgammon
+  save <file>              save the current position to a file
+  load <file>              load a position from a file, the game restarts from it
+  board                    show the board
+  help                     show this help
+  quit                     leave the game`
+
+// An interactive game between the players, human players are
+// asked for their decisions on in and everything is printed to out
+type session struct {
+	game    *game.Game
+	players map[board.Color]game.IPlayer
+	dice    game.Dice
+	in      *bufio.Scanner
+	out     io.Writer
+	// Options the board is drawn with, the last move is updated after every move
+	view render.Options
+	// Options the hints are computed with
+	hint ai.HintOptions
+}
+
+func newSession(g *game.Game, players map[board.Color]game.IPlayer, dice game.Dice, in io.Reader, out io.Writer, view render.Options, hint ai.HintOptions) *session {
+	return &session{g, players, dice, bufio.NewScanner(in), out, view, hint}
+}
+
+func (s *session) run() {
+	s.printBoard()
+	for !s.game.IsOver() {
+		player := s.players[s.game.Board.ColorToMove]
+		if aiPlayer, ok := player.(game.IAIPlayer); ok {
+			s.playAI(aiPlayer)
+			continue
+		}
+		if !s.playHuman() {
+			return
+		}
+	}
+	result := s.game.Result
+	fmt.Fprintf(s.out, "%s wins %d point(s)", colorName(result.Winner), result.Points)
+	if result.Resigned {
+		fmt.Fprint(s.out, " by resignation")
+	}
+	if result.TimedOut {
+		fmt.Fprintf(s.out, ", %s ran out of time", colorName(board.Color(1-result.Winner)))
+	}
+	fmt.Fprintln(s.out)
+}
+
+func (s *session) playAI(player game.IAIPlayer) {
+	if !s.game.Rolled {
+		s.game.Roll(s.dice)
+	}
+	before := s.game.Board
+	dice := s.game.Dice
+	mvRoll, err := s.game.PlayAI(context.Background(), player)
+	if err == game.ErrTimeout {
+		return
+	}
+	if err != nil {
+		// The AI tried an illegal move roll or failed to choose one, it forfeits the game
+		fmt.Fprintf(s.out, "%s could not play: %v\n", colorName(before.ColorToMove), err)
+		s.game.Resign(before.ColorToMove, 1)
+		return
+	}
+	fmt.Fprintf(s.out, "%s rolls %d-%d and plays %s\n", colorName(before.ColorToMove), dice.Die1, dice.Die2, moveRollText(mvRoll, before))
+	s.view.LastMove = mvRoll
+	s.printBoard()
+}
+
+// Function that reads and executes one command of the human player to
+// move, it returns false if the player wants to quit
+func (s *session) playHuman() bool {
+	color := s.game.Board.ColorToMove
+	fmt.Fprintf(s.out, "%s> ", colorName(color))
+	if !s.in.Scan() {
+		return false
+	}
+	line := strings.TrimSpace(s.in.Text())
+	fields := strings.Fields(line)
+	command := ""
+	if len(fields) > 0 {
+		command = strings.ToLower(fields[0])
+	}
+
+	switch command {
+	case "", "roll":
+		s.roll()
+	case "moves":
+		s.printMoves()
+	case "hint":
+		s.printHint(fields)
+	case "undo":
+		s.undo()
+	case "resign":
+		points := 1
+		if len(fields) > 1 {
+			points, _ = strconv.Atoi(fields[1])
+		}
+		if err := s.game.Resign(color, points); err != nil {
+			fmt.Fprintln(s.out, err)
+		}
+	case "save":
+		s.save(fields)
+	case "load":
+		s.load(fields)
+	case "board":
+		s.printBoard()
+	case "help":
+		fmt.Fprintln(s.out, helpText)
+	case "quit", "exit":
+		return false
+	default:
+		s.playMove(line)
+	}
+	return true
+}
+
+func (s *session) roll() {
+	if s.game.Rolled {
+		fmt.Fprintln(s.out, game.ErrDiceAlreadyRolled)
+		return
+	}
+	dice, _ := s.game.Roll(s.dice)
+	fmt.Fprintf(s.out, "You rolled %d-%d\n", dice.Die1, dice.Die2)
+	results, _ := s.game.ValidMoves()
+	if len(results) == 0 {
+		fmt.Fprintln(s.out, "No legal moves, the turn passes")
+		s.game.Play(board.MoveRoll{})
+		s.view.LastMove = nil
+		s.printBoard()
+		return
+	}
+	s.printMoves()
+}
+
+func (s *session) printMoves() {
+	results, err := s.game.ValidMoves()
+	if err != nil {
+		fmt.Fprintln(s.out, err)
+		return
+	}
+	for idx, result := range results {
+		fmt.Fprintf(s.out, "%3d. %s\n", idx+1, result.Notation)
+	}
+}
+
+func (s *session) printHint(fields []string) {
+	n := DEFAULT_HINT_COUNT
+	if len(fields) > 1 {
+		var err error
+		if n, err = strconv.Atoi(fields[1]); err != nil || n < 1 {
+			fmt.Fprintln(s.out, "Usage: hint [n]")
+			return
+		}
+	}
+	if !s.game.Rolled {
+		fmt.Fprintln(s.out, game.ErrDiceNotRolled)
+		return
+	}
+	candidates := ai.Hint(s.game.Board, s.game.Dice, n, s.hint)
+	if len(candidates) == 0 {
+		fmt.Fprintln(s.out, "No legal moves")
+		return
+	}
+	for idx, candidate := range candidates {
+		evaluation := candidate.Evaluation
+		fmt.Fprintf(s.out, "%3d. %-24s equity %+.3f (%+.3f)  win %.1f%%  gammon %.1f%%  lose gammon %.1f%%\n",
+			idx+1, candidate.Notation, candidate.Equity, candidate.Difference,
+			100*evaluation.Win, 100*evaluation.WinGammon, 100*evaluation.LoseGammon)
+	}
+}
+
+func (s *session) playMove(input string) {
+	var mvRoll board.MoveRoll
+	if number, err := strconv.Atoi(input); err == nil {
+		results, err := s.game.ValidMoves()
+		if err != nil {
+			fmt.Fprintln(s.out, err)
+			return
+		}
+		if number < 1 || number > len(results) {
+			fmt.Fprintf(s.out, "Choose a move between 1 and %d\n", len(results))
+			return
+		}
+		mvRoll = results[number-1].MoveRoll
+	} else {
+		mvRoll, err = board.ParseMoveRollNotation(input, s.game.Board.ColorToMove)
+		if err != nil {
+			fmt.Fprintf(s.out, "Unknown command or move: %v, type help for the list of commands\n", err)
+			return
+		}
+	}
+
+	if err := s.game.Play(mvRoll); err != nil {
+		fmt.Fprintln(s.out, err)
+		return
+	}
+	s.view.LastMove = mvRoll
+	s.printBoard()
+}
+
+// Function that takes back moves until the last decision of a human player
+func (s *session) undo() {
+	if s.game.Clock != nil {
+		fmt.Fprintln(s.out, game.ErrTimedUndo)
+		return
+	}
+	if !s.game.CanUndo() {
+		fmt.Fprintln(s.out, game.ErrNothingToUndo)
+		return
+	}
+	s.game.Undo()
+	for s.game.CanUndo() {
+		if _, isAI := s.players[s.game.Board.ColorToMove].(game.IAIPlayer); !isAI {
+			break
+		}
+		s.game.Undo()
+	}
+	s.view.LastMove = nil
+	s.printBoard()
+	s.printMoves()
+}
+
+func (s *session) save(fields []string) {
+	if len(fields) != 2 {
+		fmt.Fprintln(s.out, "Usage: save <file>")
+		return
+	}
+	if err := os.WriteFile(fields[1], []byte(s.game.Board.SerializeBoard()+"\n"), 0644); err != nil {
+		fmt.Fprintln(s.out, err)
+		return
+	}
+	fmt.Fprintf(s.out, "Position saved to %s\n", fields[1])
+}
+
+func (s *session) load(fields []string) {
+	if len(fields) != 2 {
+		fmt.Fprintln(s.out, "Usage: load <file>")
+		return
+	}
+	content, err := os.ReadFile(fields[1])
+	if err != nil {
+		fmt.Fprintln(s.out, err)
+		return
+	}
+	b, err := board.ParseBoard(strings.TrimSpace(string(content)))
+	if err != nil {
+		fmt.Fprintln(s.out, err)
+		return
+	}
+	clock := s.game.Clock
+	*s.game = *game.NewGame(b)
+	if clock != nil {
+		s.game.SetTimeControl(clock.Control, clock.Clock)
+	}
+	s.view.LastMove = nil
+	s.printBoard()
+}
+
+func (s *session) printBoard() {
+	s.view.Dice = nil
+	if s.game.Rolled {
+		s.view.Dice = &s.game.Dice
+	}
+	fmt.Fprint(s.out, render.Render(s.game.Board, s.view))
+	if clock := s.game.Clock; clock != nil {
+		fmt.Fprintf(s.out, "Time left: White %s, Black %s\n", clockText(clock, board.COLOR_WHITE), clockText(clock, board.COLOR_BLACK))
+	}
+}
+
+// Function that returns the time left of a player as minutes:seconds
+func clockText(clock *game.GameClock, color board.Color) string {
+	left := clock.Remaining[color]
+	if running, ok := clock.Running(); ok && running == color {
+		left = clock.TimeLeft()
+	}
+	seconds := int(left.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+func moveRollText(mvRoll board.MoveRoll, b board.Board) string {
+	if len(mvRoll) == 0 {
+		return "nothing"
+	}
+	return mvRoll.Notation(b)
+}
+
+func colorName(color board.Color) string {
+	if color == board.COLOR_WHITE {
+		return "White"
+	}
+	return "Black"
+}
@@ -1,34 +1,122 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"log"
 	"os"
-	"runtime/pprof"
+	"runtime"
+	"strings"
+	"time"
 
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
 	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/render"
+	// Registers the skill AI
+	_ "github.com/GeorgianBadita/backgammon-move-generator/pkg/skill"
 )
 
 func main() {
-	f, err := os.Create("backgammon.prof")
+	side := flag.String("side", "white", "side played by the user: white or black")
+	opponent := flag.String("opponent", "heuristic", "opponent: human, a player of the config or an AI spec such as expectimax:depth=2, AIs: "+strings.Join(ai.Names(), ", "))
+	white := flag.String("white", "", "player of white: human, a player of the config or an AI spec, overrides -side and -opponent")
+	black := flag.String("black", "", "player of black: human, a player of the config or an AI spec, overrides -side and -opponent")
+	configPath := flag.String("config", "", "JSON file naming AI players, e.g. {\"players\": {\"strong\": \"expectimax:depth=2\"}}")
+	position := flag.String("position", "", "serialized board to start from instead of the initial position")
+	plain := flag.Bool("plain", !render.ColorSupported(os.Stdout), "draw the board without colors")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed for the dice")
+	hintDepth := flag.Int("hint-depth", 0, "number of rolls the hint command looks ahead")
+	hintWorkers := flag.Int("hint-workers", runtime.NumCPU(), "number of goroutines evaluating the hints")
+	timeControl := flag.String("time", "", "time control, e.g. fischer:5m+10s or bronstein:5m+12s, append ,position to lose the gammon or backgammon of the position on timeout; untimed by default")
+	thinking := flag.Bool("thinking", false, "print the best move roll of the AIs while they are thinking")
+	flag.Parse()
+
+	config, err := ai.LoadConfigOrDefault(*configPath)
 	if err != nil {
-		log.Fatal(err)
+		exit(err)
+	}
+
+	userColor := board.COLOR_WHITE
+	switch *side {
+	case "white":
+	case "black":
+		userColor = board.COLOR_BLACK
+	default:
+		exit(fmt.Errorf("unknown side %q", *side))
+	}
+	specs := map[board.Color]string{userColor: "human", board.Color(1 - userColor): *opponent}
+	if *white != "" || *black != "" {
+		specs = map[board.Color]string{board.COLOR_WHITE: *white, board.COLOR_BLACK: *black}
+	}
+
+	var progress ai.ProgressFunc
+	if *thinking {
+		progress = printProgress
+	}
+	players := map[board.Color]game.IPlayer{}
+	for _, color := range []board.Color{board.COLOR_WHITE, board.COLOR_BLACK} {
+		player, err := newPlayer(config, color, specs[color], progress)
+		if err != nil {
+			exit(err)
+		}
+		players[color] = player
+	}
+	// The board is drawn from the perspective of a human player if there is one
+	if _, ok := players[userColor].(game.HumanPlayer); !ok {
+		if _, ok := players[board.Color(1-userColor)].(game.HumanPlayer); ok {
+			userColor = board.Color(1 - userColor)
+		}
 	}
-	pprof.StartCPUProfile(f)
-	defer pprof.StopCPUProfile()
-	backgammonBoard := board.NewBoard(board.COLOR_WHITE)
-	fmt.Println(backgammonBoard)
-	moveRolls := backgammonBoard.GetValidMovesForDieRoll(board.DieRoll{Die1: 6, Die2: 6})
 
-	newBoard := moveRolls[0].MakeMoveRoll(backgammonBoard)
-	newBoard.ColorToMove = board.COLOR_BLACK
-	newMoves := newBoard.GetValidMovesForDieRoll(board.DieRoll{Die1: 6, Die2: 1})
+	dice := game.NewRandomDice(*seed)
+	var g *game.Game
+	if *position != "" {
+		b, err := board.ParseBoard(*position)
+		if err != nil {
+			exit(err)
+		}
+		g = game.NewGame(b)
+	} else {
+		color, openingRoll := game.OpeningRoll(dice)
+		g = game.NewGame(board.NewBoard(color))
+		g.SetDice(openingRoll)
+		fmt.Printf("Opening roll: white %d, black %d, %s starts\n", openingRoll.Die1, openingRoll.Die2, colorName(color))
+	}
 
-	for idx := 0; idx < len(newMoves); idx++ {
-		moveRoll := newMoves[idx]
-		fmt.Println("BOARD BEFORE MOVE ROLL: ")
-		fmt.Println(newBoard)
-		fmt.Println("BOARD AFTER MOVE ROLL: ")
-		fmt.Println(moveRoll.MakeMoveRoll(newBoard))
+	if *timeControl != "" {
+		control, err := game.ParseTimeControl(*timeControl)
+		if err != nil {
+			exit(err)
+		}
+		g.SetTimeControl(control, game.SystemClock{})
 	}
+
+	fmt.Println("Type help for the list of commands")
+	view := render.Options{Perspective: userColor, Plain: *plain}
+	hint := ai.HintOptions{Evaluator: ai.HeuristicEvaluator{}, Depth: *hintDepth, Workers: *hintWorkers}
+	newSession(g, players, dice, os.Stdin, os.Stdout, view, hint).run()
+}
+
+// Function that creates the player of the given color from a spec, an
+// empty spec or human is a human player. The progress function, if any,
+// receives the progress of an AI player
+func newPlayer(config ai.Config, color board.Color, spec string, progress ai.ProgressFunc) (game.IPlayer, error) {
+	if spec == "" || spec == "human" {
+		return game.HumanPlayer{Color: color, Name: colorName(color)}, nil
+	}
+	playerAI, err := config.New(spec)
+	if err != nil {
+		return nil, err
+	}
+	return game.AIPlayer{Color: color, AI: playerAI, Progress: progress}, nil
+}
+
+// Function that prints the best move roll an AI found so far
+func printProgress(progress ai.Progress) {
+	fmt.Printf("  depth %d, %d/%d move rolls: %s (%+.3f)\n", progress.Depth, progress.Evaluated, progress.Total, progress.Notation, progress.Equity)
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
 }
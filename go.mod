@@ -3,3 +3,16 @@ module github.com/GeorgianBadita/backgammon-move-generator
 go 1.19
 
 require github.com/mitchellh/hashstructure/v2 v2.0.2
+
+require (
+	github.com/gorilla/websocket v1.5.0
+	google.golang.org/grpc v1.64.1
+	google.golang.org/protobuf v1.34.1
+)
+
+require (
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
+)
@@ -1,7 +1,196 @@
 package ai
 
-import "github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
 
 type AI interface {
 	ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll
 }
+
+// Best move roll found so far by a search, reported while a ContextAI is thinking
+type Progress struct {
+	MoveRoll board.MoveRoll
+	Notation string
+	// Cubeless equity of the move roll, its match winning chances in a
+	// game of a match, see WithMatch
+	Equity float64
+	// Number of rolls looked ahead by the search that found the move roll
+	Depth int
+	// Number of move rolls evaluated at the depth, out of Total
+	Evaluated int
+	Total     int
+}
+
+// Function receiving the progress of a search, called from the goroutine
+// of the search
+type ProgressFunc func(progress Progress)
+
+// AI that can be cancelled and reports the progress of its search: it
+// stops thinking when the context is done, e.g. at the deadline of a timed
+// move. Search based AIs return the best move roll found so far, the error
+// is only set when the AI has no move roll to play. The progress function
+// is optional
+type ContextAI interface {
+	ChooseMoveContext(ctx context.Context, b board.Board, d board.DieRoll, progress ProgressFunc) (board.MoveRoll, error)
+}
+
+// Function that returns the AI as a ContextAI: a ContextAI is returned as
+// is, any other AI is adapted so the wait for its move roll can be
+// cancelled, but it does not report any progress
+func WithContext(a AI) ContextAI {
+	if contextAI, ok := a.(ContextAI); ok {
+		return contextAI
+	}
+	return simpleAI{a}
+}
+
+// Function that chooses the move roll of an AI with a context, see WithContext
+func Choose(ctx context.Context, a AI, b board.Board, d board.DieRoll, progress ProgressFunc) (board.MoveRoll, error) {
+	return WithContext(a).ChooseMoveContext(ctx, b, d, progress)
+}
+
+// Adapter of an AI that cannot stop thinking
+type simpleAI struct {
+	AI
+}
+
+// Function that waits for the move roll of the AI until the context is
+// done, the AI is left to finish in the background if it is too slow
+func (a simpleAI) ChooseMoveContext(ctx context.Context, b board.Board, d board.DieRoll, progress ProgressFunc) (board.MoveRoll, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	chosen := make(chan board.MoveRoll, 1)
+	go func() {
+		chosen <- a.ChooseMove(b, d)
+	}()
+	select {
+	case mvRoll := <-chosen:
+		return mvRoll, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// AI that plays the move roll leading to the position its evaluator
+// likes the most, without looking further ahead
+type EvaluatorAI struct {
+	Evaluator Evaluator
+	// Time budget of a move: the search stops after Time, or at the
+	// deadline of the context if it comes first. No budget if 0
+	Time time.Duration
+}
+
+func (a EvaluatorAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	mvRoll, _ := a.ChooseMoveContext(context.Background(), b, d, nil)
+	return mvRoll
+}
+
+// Function that evaluates the move rolls until the context is done, or
+// until the deadline is too close to evaluate one more move roll. An
+// ExpectimaxEvaluator is deepened one roll at a time, every depth
+// evaluating the best move rolls of the previous depth first, so a search
+// cut short plays the best move roll of the deepest depth reached. The
+// evaluation running when the context is done is dropped, and a depth is
+// not started if its first evaluation, estimated to take
+// EXPECTIMAX_BRANCHING times the last one, would not end before the
+// deadline. The error of the context is returned if it is done before any
+// evaluation, while the first move roll is played if only the time budget
+// of the AI ran out.
+// In a game of a match, see WithMatch, the move roll with the best match
+// winning chances is played
+func (a EvaluatorAI) ChooseMoveContext(ctx context.Context, b board.Board, d board.DieRoll, progress ProgressFunc) (board.MoveRoll, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	parent := ctx
+	if a.Time > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.Time)
+		defer cancel()
+	}
+	results := b.GetValidMoveResultsForDieRoll(d)
+	if len(results) == 0 {
+		return board.MoveRoll{}, nil
+	}
+	evaluators := []Evaluator{a.Evaluator}
+	if expectimax, ok := a.Evaluator.(ExpectimaxEvaluator); ok && expectimax.Depth > 0 {
+		evaluators = make([]Evaluator, expectimax.Depth+1)
+		for depth := range evaluators {
+			evaluators[depth] = ExpectimaxEvaluator{Evaluator: expectimax.Evaluator, Depth: depth, Workers: expectimax.Workers}
+		}
+	}
+
+	score := scoreFunc(ctx, b.ColorToMove)
+	order := make([]int, len(results))
+	for idx := range order {
+		order[idx] = idx
+	}
+	equities := make([]float64, len(results))
+	best := 0
+	deadline, hasDeadline := ctx.Deadline()
+	lastDuration := time.Duration(0)
+	for depth, evaluator := range evaluators {
+		depthBest := -1
+		for evaluated, idx := range order {
+			estimate := lastDuration
+			if evaluated == 0 {
+				estimate *= EXPECTIMAX_BRANCHING
+			}
+			if (depth > 0 || evaluated > 0) && (ctx.Err() != nil || hasDeadline && time.Until(deadline) < estimate) {
+				return results[best].MoveRoll, nil
+			}
+			start := time.Now()
+			evaluation, err := EvaluateMoveRollContext(ctx, evaluator, results[idx])
+			if err != nil {
+				if depth == 0 && evaluated == 0 && parent.Err() != nil {
+					return nil, parent.Err()
+				}
+				return results[best].MoveRoll, nil
+			}
+			equities[idx] = score(evaluation)
+			lastDuration = time.Since(start)
+			// Ties are broken by the order of generation, whatever the depth
+			if depthBest < 0 || equities[idx] > equities[depthBest] || equities[idx] == equities[depthBest] && idx < depthBest {
+				depthBest = idx
+			}
+			if depthBest == idx || evaluated == len(order)-1 {
+				best = depthBest
+				if progress != nil {
+					progress(Progress{
+						MoveRoll:  results[best].MoveRoll,
+						Notation:  results[best].Notation,
+						Equity:    equities[best],
+						Depth:     depth,
+						Evaluated: evaluated + 1,
+						Total:     len(order),
+					})
+				}
+			}
+		}
+		sort.SliceStable(order, func(i, j int) bool { return equities[order[i]] > equities[order[j]] })
+	}
+	return results[best].MoveRoll, nil
+}
+
+// Function that evaluates the position a move roll leads to, from the
+// perspective of the player who played the move roll
+func EvaluateMoveRoll(e Evaluator, result board.MoveRollResult) Evaluation {
+	next := result.Board
+	next.ColorToMove = board.Color(1 - result.Board.ColorToMove)
+	return e.Evaluate(next).Invert()
+}
+
+// Function that evaluates the position a move roll leads to like
+// EvaluateMoveRoll, stopping when the context is done, see EvaluateContext
+func EvaluateMoveRollContext(ctx context.Context, e Evaluator, result board.MoveRollResult) (Evaluation, error) {
+	next := result.Board
+	next.ColorToMove = board.Color(1 - result.Board.ColorToMove)
+	evaluation, err := EvaluateContext(ctx, e, next)
+	return evaluation.Invert(), err
+}
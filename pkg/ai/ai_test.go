@@ -0,0 +1,201 @@
+package ai
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+// AI thinking for a while before playing the first valid move roll
+type slowAI struct {
+	delay time.Duration
+}
+
+func (a slowAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	time.Sleep(a.delay)
+	return b.GetValidMovesForDieRoll(d)[0]
+}
+
+func TestEvaluatorAIProgress(t *testing.T) {
+	// ARRANGE
+	b := board.DeserializeBoard("1-3/2-4/3-3/5-3:19-4/20-4/22-3 0 0 w")
+	dice := board.DieRoll{Die1: 4, Die2: 2}
+	expectimax := EvaluatorAI{Evaluator: ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 1}}
+	reports := []Progress{}
+
+	// ACT
+	mvRoll, err := expectimax.ChooseMoveContext(context.Background(), b, dice, func(progress Progress) {
+		reports = append(reports, progress)
+	})
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(reports) == 0 || reports[0].Depth != 0 {
+		t.Fatalf("Output %v not equal to expected progress from depth %v", reports, 0)
+	}
+	last := reports[len(reports)-1]
+	if last.Depth != 1 || last.Evaluated != last.Total || !reflect.DeepEqual(last.MoveRoll, mvRoll) {
+		t.Errorf("Output %+v not equal to expected %v at depth %v", last, mvRoll, 1)
+	}
+	expected := Hint(b, dice, 1, HintOptions{Depth: 1})[0]
+	if last.Equity != expected.Equity {
+		t.Errorf("Output %v not equal to expected %v", last.Equity, expected.Equity)
+	}
+}
+
+func TestEvaluatorAICancelled(t *testing.T) {
+	// ARRANGE
+	b := board.NewBoard(board.COLOR_WHITE)
+	dice := board.DieRoll{Die1: 3, Die2: 1}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// ACT
+	mvRoll, err := EvaluatorAI{Evaluator: ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 2}}.ChooseMoveContext(ctx, b, dice, nil)
+
+	// ASSERT
+	// No move roll was evaluated before the context was done
+	if err != context.Canceled || mvRoll != nil {
+		t.Errorf("Output %v %v not equal to expected %v", mvRoll, err, context.Canceled)
+	}
+}
+
+func TestEvaluatorAIDeadline(t *testing.T) {
+	// ARRANGE
+	b := board.NewBoard(board.COLOR_WHITE)
+	dice := board.DieRoll{Die1: 3, Die2: 1}
+	deadline := 300 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	// ACT
+	// The full search at depth 2 takes several seconds
+	start := time.Now()
+	mvRoll, err := EvaluatorAI{Evaluator: ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 2}}.ChooseMoveContext(ctx, b, dice, nil)
+	elapsed := time.Since(start)
+
+	// ASSERT
+	if err != nil || len(mvRoll) == 0 {
+		t.Errorf("Output %v %v not equal to expected a move roll", mvRoll, err)
+	}
+	if elapsed > deadline+100*time.Millisecond {
+		t.Errorf("Output %v not equal to expected %v", elapsed, deadline)
+	}
+}
+
+// AI playing the first valid move roll until the context is done, the
+// last valid move roll afterwards
+type contextFirstAI struct{}
+
+func (a contextFirstAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	mvRoll, _ := a.ChooseMoveContext(context.Background(), b, d, nil)
+	return mvRoll
+}
+
+func (contextFirstAI) ChooseMoveContext(ctx context.Context, b board.Board, d board.DieRoll, progress ProgressFunc) (board.MoveRoll, error) {
+	mvRolls := b.GetValidMovesForDieRoll(d)
+	if ctx.Err() != nil {
+		return mvRolls[len(mvRolls)-1], nil
+	}
+	return mvRolls[0], nil
+}
+
+func TestWithContext(t *testing.T) {
+	// ARRANGE
+	b := board.NewBoard(board.COLOR_WHITE)
+	dice := board.DieRoll{Die1: 6, Die2: 5}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// ACT
+	mvRoll, err := Choose(context.Background(), NewRunAI(1), b, dice, nil)
+	_, slowErr := Choose(ctx, slowAI{time.Second}, b, dice, nil)
+	<-ctx.Done()
+	contextMoveRoll, contextErr := Choose(ctx, contextFirstAI{}, b, dice, nil)
+	evaluatorAI := WithContext(EvaluatorAI{Evaluator: HeuristicEvaluator{}})
+
+	// ASSERT
+	expected := NewRunAI(1).ChooseMove(b, dice)
+	if err != nil || !reflect.DeepEqual(mvRoll, expected) {
+		t.Errorf("Output %v not equal to expected %v", mvRoll, expected)
+	}
+	if slowErr != context.DeadlineExceeded {
+		t.Errorf("Output %v not equal to expected %v", slowErr, context.DeadlineExceeded)
+	}
+	// The ContextAI gets the context and plays its move roll
+	mvRolls := b.GetValidMovesForDieRoll(dice)
+	if contextErr != nil || !reflect.DeepEqual(contextMoveRoll, mvRolls[len(mvRolls)-1]) {
+		t.Errorf("Output %v %v not equal to expected %v", contextMoveRoll, contextErr, mvRolls[len(mvRolls)-1])
+	}
+	if _, ok := evaluatorAI.(EvaluatorAI); !ok {
+		t.Errorf("Output %T not equal to expected %T", evaluatorAI, EvaluatorAI{})
+	}
+}
+
+// Match in which the players want to lose, to tell its move rolls apart
+type losingMatch struct{}
+
+func (losingMatch) EvaluationMWC(evaluation Evaluation, color board.Color, cubeValue int) float64 {
+	return -evaluation.Equity()
+}
+
+func TestEvaluatorAIMatch(t *testing.T) {
+	// ARRANGE
+	b := board.NewBoard(board.COLOR_WHITE)
+	dice := board.DieRoll{Die1: 6, Die2: 5}
+	evaluatorAI := EvaluatorAI{Evaluator: HeuristicEvaluator{}}
+	ctx := WithMatch(context.Background(), losingMatch{}, 2)
+
+	// ACT
+	mvRoll, err := evaluatorAI.ChooseMoveContext(ctx, b, dice, nil)
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	hints := Hint(b, dice, 0, HintOptions{})
+	expected := hints[len(hints)-1].MoveRoll
+	if !reflect.DeepEqual(mvRoll, expected) {
+		t.Errorf("Output %v not equal to expected %v", mvRoll, expected)
+	}
+	if _, cubeValue, ok := MatchFromContext(ctx); !ok || cubeValue != 2 {
+		t.Errorf("Output %v not equal to expected %v", cubeValue, 2)
+	}
+}
+
+// Evaluator taking a while to evaluate every position
+type slowEvaluator struct {
+	delay time.Duration
+}
+
+func (e slowEvaluator) Evaluate(b board.Board) Evaluation {
+	time.Sleep(e.delay)
+	return HeuristicEvaluator{}.Evaluate(b)
+}
+
+func TestEvaluatorAITime(t *testing.T) {
+	// ARRANGE
+	b := board.NewBoard(board.COLOR_WHITE)
+	dice := board.DieRoll{Die1: 6, Die2: 5}
+	budget := 30 * time.Millisecond
+	evaluatorAI := EvaluatorAI{Evaluator: slowEvaluator{10 * time.Millisecond}, Time: budget}
+
+	// ACT
+	start := time.Now()
+	mvRoll := evaluatorAI.ChooseMove(b, dice)
+	elapsed := time.Since(start)
+
+	// ASSERT
+	// Evaluating all the move rolls of 6-5 takes more than 100ms
+	if elapsed > budget+20*time.Millisecond {
+		t.Errorf("Output %v not equal to expected %v", elapsed, budget)
+	}
+	if len(mvRoll) == 0 {
+		t.Errorf("A move roll should be played when the time budget runs out")
+	}
+}
@@ -0,0 +1,149 @@
+package ai
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+// Simple reference AI: it plays the move roll with the highest score for
+// its strategy, ties are broken at random with a generator seeded with the
+// seed, the position and the dice, so two baseline AIs created with the
+// same seed play the same games, whatever the games played before or at
+// the same time. It is safe for concurrent use
+type BaselineAI struct {
+	Name  string
+	score func(b board.Board, result board.MoveRollResult) float64
+	seed  int64
+}
+
+func newBaselineAI(name string, seed int64, score func(b board.Board, result board.MoveRollResult) float64) *BaselineAI {
+	return &BaselineAI{Name: name, score: score, seed: seed}
+}
+
+// Function that returns a random generator seeded with the seed, the
+// position and the decision made in it, e.g. the dice of a move roll, so
+// the random choices of an AI only depend on the position it plays
+func PositionRand(seed int64, b board.Board, decision string) *rand.Rand {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%d %s %s", seed, b.SerializeBoard(), decision)
+	return rand.New(rand.NewSource(int64(hash.Sum64())))
+}
+
+// AI that plays one of the valid move rolls uniformly at random
+func NewRandomAI(seed int64) *BaselineAI {
+	return newBaselineAI("random", seed, func(b board.Board, result board.MoveRollResult) float64 {
+		return 0
+	})
+}
+
+// AI that hits as many checkers as possible, preferring the hits
+// costing the opponent the most pips
+func NewHitAI(seed int64) *BaselineAI {
+	return newBaselineAI("hit", seed, func(b board.Board, result board.MoveRollResult) float64 {
+		opponent := board.Color(1 - b.ColorToMove)
+		return 1000*float64(result.Hits) + float64(result.Board.PipCount(opponent))
+	})
+}
+
+// AI that runs its checkers furthest from home first
+func NewRunAI(seed int64) *BaselineAI {
+	return newBaselineAI("run", seed, func(b board.Board, result board.MoveRollResult) float64 {
+		score := 0.0
+		forEachChecker(result.Board, b.ColorToMove, func(number int, count int) {
+			score -= float64(count * number * number)
+		})
+		return score
+	})
+}
+
+// AI that leaves as few blots as possible, then makes as many points as possible
+func NewSafeAI(seed int64) *BaselineAI {
+	return newBaselineAI("safe", seed, func(b board.Board, result board.MoveRollResult) float64 {
+		blots, points := 0, 0
+		forEachChecker(result.Board, b.ColorToMove, func(number int, count int) {
+			if count == 1 && number <= board.NUM_PLAYABLE_POINTS {
+				blots += 1
+			} else if count > 1 && number <= board.NUM_PLAYABLE_POINTS {
+				points += 1
+			}
+		})
+		return -100*float64(blots) + float64(points)
+	})
+}
+
+// AI that plays a pure race: it bears off as many checkers as possible,
+// then brings the checkers outside of its home board in and lowers its
+// pip count, ignoring the opponent
+func NewRaceAI(seed int64) *BaselineAI {
+	return newBaselineAI("race", seed, func(b board.Board, result board.MoveRollResult) float64 {
+		outside := 0
+		forEachChecker(result.Board, b.ColorToMove, func(number int, count int) {
+			if number > 6 {
+				outside += count
+			}
+		})
+		return 1000*float64(result.BorneOff) - 50*float64(outside) - float64(result.Board.PipCount(b.ColorToMove))
+	})
+}
+
+func (a *BaselineAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	results := b.GetValidMoveResultsForDieRoll(d)
+	if len(results) == 0 {
+		return board.MoveRoll{}
+	}
+
+	best := []int{}
+	bestScore := 0.0
+	for idx, result := range results {
+		score := a.score(b, result)
+		switch {
+		case idx == 0 || score > bestScore:
+			best, bestScore = []int{idx}, score
+		case score == bestScore:
+			best = append(best, idx)
+		}
+	}
+
+	rng := PositionRand(a.seed, b, fmt.Sprintf("move %d-%d", d.Die1, d.Die2))
+	return results[best[rng.Intn(len(best))]].MoveRoll
+}
+
+// Function that calls fn with the point number, from the player's
+// perspective, and the number of checkers of every point holding
+// checkers of the player, the bar is point 25
+func forEachChecker(b board.Board, color board.Color, fn func(number int, count int)) {
+	for number := 1; number <= board.NUM_PLAYABLE_POINTS+1; number++ {
+		point := b.Points[board.PointIndexFromNumber(number, color)]
+		if point.CheckerCount > 0 && (point.Checker.Color == color || number == board.NUM_PLAYABLE_POINTS+1) {
+			fn(number, point.CheckerCount)
+		}
+	}
+}
+
+func init() {
+	baselines := []struct {
+		name  string
+		usage string
+		new   func(seed int64) *BaselineAI
+	}{
+		{"random", "plays a random valid move roll", NewRandomAI},
+		{"hit", "hits as many checkers as possible", NewHitAI},
+		{"run", "runs its back checkers first", NewRunAI},
+		{"safe", "leaves as few blots as possible", NewSafeAI},
+		{"race", "plays a pure race, bearing off as fast as possible", NewRaceAI},
+	}
+	for _, baseline := range baselines {
+		newBaseline := baseline.new
+		Register(Factory{
+			Name:    baseline.name,
+			Usage:   baseline.usage,
+			Options: []Option{{Name: "seed", Type: OPTION_INT, Default: "1", Usage: "seed breaking the ties"}},
+			New: func(options Options) (AI, error) {
+				return newBaseline(int64(options.Int("seed"))), nil
+			},
+		})
+	}
+}
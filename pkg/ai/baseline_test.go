@@ -0,0 +1,110 @@
+package ai
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+type baselineTest struct {
+	ai    *BaselineAI
+	board board.Board
+	dice  board.DieRoll
+	check func(result board.MoveRollResult) bool
+}
+
+func makeBaselineTests() []baselineTest {
+	blot := board.DeserializeBoard("6-5/8-3/13-7:1-2/10-1/12-4/17-3/19-5 0 0 w")
+	race := board.DeserializeBoard("1-3/2-4/3-3/5-2/9-3:19-4/20-4/22-7 0 0 w")
+	return []baselineTest{
+		// test 1 - the hit AI hits the blot on the 10 point
+		{NewHitAI(1), blot, board.DieRoll{Die1: 3, Die2: 1}, func(result board.MoveRollResult) bool { return result.Hits == 1 }},
+		// test 2 - the safe AI makes the 5 point with the opening 3-1
+		{NewSafeAI(1), board.NewBoard(board.COLOR_WHITE), board.DieRoll{Die1: 3, Die2: 1}, func(result board.MoveRollResult) bool {
+			return result.Notation == "8/5 6/5"
+		}},
+		// test 3 - the run AI runs a back checker to the mid point with the opening 6-5
+		{NewRunAI(1), board.NewBoard(board.COLOR_WHITE), board.DieRoll{Die1: 6, Die2: 5}, func(result board.MoveRollResult) bool {
+			return result.Board.Points[23].CheckerCount == 1 && result.Board.Points[12].CheckerCount == 6
+		}},
+		// test 4 - the race AI brings its checkers home
+		{NewRaceAI(1), race, board.DieRoll{Die1: 4, Die2: 4}, func(result board.MoveRollResult) bool {
+			return result.Board.Points[8].CheckerCount == 0
+		}},
+	}
+}
+
+func TestBaselineAIs(t *testing.T) {
+	for idx, test := range makeBaselineTests() {
+		// ACT
+		mvRoll := test.ai.ChooseMove(test.board, test.dice)
+
+		// ASSERT
+		found := false
+		for _, result := range test.board.GetValidMoveResultsForDieRoll(test.dice) {
+			if reflect.DeepEqual(result.MoveRoll, mvRoll) {
+				found = true
+				if !test.check(result) {
+					t.Errorf("Test %d: unexpected move roll %v for %s", idx+1, result.Notation, test.ai.Name)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Test %d: output %v is not a valid move roll", idx+1, mvRoll)
+		}
+	}
+}
+
+// Function that plays a game between two AIs from the initial position,
+// the dice are rolled from a fixed sequence, and returns the move rolls
+func playBaselineGame(t *testing.T, white AI, black AI) []board.MoveRoll {
+	b := board.NewBoard(board.COLOR_WHITE)
+	mvRolls := []board.MoveRoll{}
+	for turn := 0; ; turn++ {
+		dice := board.DieRoll{Die1: turn%6 + 1, Die2: (turn*5+2)%6 + 1}
+		player := white
+		if b.ColorToMove == board.COLOR_BLACK {
+			player = black
+		}
+		mvRoll := player.ChooseMove(b, dice)
+		valid := len(mvRoll) == 0 && len(b.GetValidMovesForDieRoll(dice)) == 0
+		for _, legal := range b.GetValidMovesForDieRoll(dice) {
+			valid = valid || reflect.DeepEqual(legal, mvRoll)
+		}
+		if !valid {
+			t.Fatalf("Illegal move roll %v on %s", mvRoll, b.SerializeBoard())
+		}
+		mover := b.ColorToMove
+		b = mvRoll.MakeMoveRoll(b)
+		b.ColorToMove = board.Color(1 - mover)
+		mvRolls = append(mvRolls, mvRoll)
+		if b.CheckersOff(mover) == board.INIT_NUM_CHECKERS {
+			return mvRolls
+		}
+	}
+}
+
+func TestRandomAIDeterministic(t *testing.T) {
+	// ACT
+	first := playBaselineGame(t, NewRandomAI(4), NewRandomAI(5))
+	second := playBaselineGame(t, NewRandomAI(4), NewRandomAI(5))
+
+	// ASSERT
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Output %v not equal to expected %v", second, first)
+	}
+}
+
+func TestRegisteredBaselines(t *testing.T) {
+	for _, name := range []string{"random", "hit", "run", "safe", "race"} {
+		// ACT
+		output, err := New(name + ":seed=7")
+
+		// ASSERT
+		baseline, ok := output.(*BaselineAI)
+		if err != nil || !ok || baseline.Name != name {
+			t.Errorf("Output %v %v not equal to expected %v", output, err, name)
+		}
+	}
+}
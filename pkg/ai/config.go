@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Named AI players read from a config file, e.g.
+//
+//	{"players": {"strong": "expectimax:depth=2", "fast": "heuristic"}}
+//
+// The names can be used instead of specs wherever an AI is chosen
+type Config struct {
+	Players map[string]string `json:"players"`
+}
+
+// Config used without a config file: every registered AI with its default options
+func DefaultConfig() Config {
+	config := Config{Players: map[string]string{}}
+	for _, name := range Names() {
+		config.Players[name] = name
+	}
+	return config
+}
+
+// Function that reads a config and checks all its players can be built
+func LoadConfig(r io.Reader) (Config, error) {
+	config := Config{}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, fmt.Errorf("invalid AI config: %w", err)
+	}
+	if _, err := config.AIs(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+func LoadConfigFromFile(path string) (Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Config{}, err
+	}
+	defer f.Close()
+	return LoadConfig(f)
+}
+
+// Function that reads the config file at the given path, or returns the
+// default config if the path is empty
+func LoadConfigOrDefault(path string) (Config, error) {
+	if path == "" {
+		return DefaultConfig(), nil
+	}
+	return LoadConfigFromFile(path)
+}
+
+// Function that builds the AI of a player of the config, or from a spec
+// if no player has the given name
+func (c Config) New(nameOrSpec string) (AI, error) {
+	if spec, ok := c.Players[nameOrSpec]; ok {
+		return New(spec)
+	}
+	return New(nameOrSpec)
+}
+
+// Function that builds the AIs of all the players of the config, by name
+func (c Config) AIs() (map[string]AI, error) {
+	ais := map[string]AI{}
+	for name, spec := range c.Players {
+		player, err := New(spec)
+		if err != nil {
+			return nil, fmt.Errorf("player %s: %w", name, err)
+		}
+		ais[name] = player
+	}
+	return ais, nil
+}
+
+// Function that returns the names of the players of the config, sorted
+func (c Config) Names() []string {
+	names := []string{}
+	for name := range c.Players {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
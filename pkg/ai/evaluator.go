@@ -0,0 +1,197 @@
+package ai
+
+import (
+	"context"
+	"math"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+// Probabilities of the outcomes of a game, from the perspective of the
+// player to move. Gammon and backgammon probabilities are cumulative,
+// i.e. WinGammon already includes WinBackgammon and Win includes WinGammon
+type Evaluation struct {
+	Win            float64
+	WinGammon      float64
+	WinBackgammon  float64
+	LoseGammon     float64
+	LoseBackgammon float64
+}
+
+// Function that computes the cubeless money equity of an evaluation
+func (e Evaluation) Equity() float64 {
+	return 2*e.Win - 1 + e.WinGammon - e.LoseGammon + e.WinBackgammon - e.LoseBackgammon
+}
+
+// Function that returns the evaluation from the opponent's perspective
+func (e Evaluation) Invert() Evaluation {
+	return Evaluation{
+		Win:            1 - e.Win,
+		WinGammon:      e.LoseGammon,
+		WinBackgammon:  e.LoseBackgammon,
+		LoseGammon:     e.WinGammon,
+		LoseBackgammon: e.WinBackgammon,
+	}
+}
+
+// Evaluators estimate the outcome probabilities of a position
+// from the perspective of the player to move
+type Evaluator interface {
+	Evaluate(b board.Board) Evaluation
+}
+
+// Evaluator whose evaluation can be cancelled, e.g. a search looking ahead.
+// The evaluation stops when the context is done and returns its error
+type ContextEvaluator interface {
+	Evaluator
+	EvaluateContext(ctx context.Context, b board.Board) (Evaluation, error)
+}
+
+// Function that evaluates the position with the evaluator, stopping when
+// the context is done if the evaluator is a ContextEvaluator
+func EvaluateContext(ctx context.Context, e Evaluator, b board.Board) (Evaluation, error) {
+	if contextEvaluator, ok := e.(ContextEvaluator); ok {
+		return contextEvaluator.EvaluateContext(ctx, b)
+	}
+	return e.Evaluate(b), nil
+}
+
+// Function that returns the evaluation of a game won by the player to move
+// with the given value (1 - single game, 2 - gammon, 3 - backgammon)
+func wonEvaluation(value int) Evaluation {
+	evaluation := Evaluation{Win: 1}
+	if value >= 2 {
+		evaluation.WinGammon = 1
+	}
+	if value >= 3 {
+		evaluation.WinBackgammon = 1
+	}
+	return evaluation
+}
+
+// Function that returns the exact evaluation of a finished game
+func terminalEvaluation(b board.Board) (Evaluation, bool) {
+	color := b.ColorToMove
+	if b.CheckersOff(color) == board.INIT_NUM_CHECKERS {
+		return wonEvaluation(b.WinValue(color)), true
+	}
+	if b.CheckersOff(board.Color(1-color)) == board.INIT_NUM_CHECKERS {
+		return wonEvaluation(b.WinValue(board.Color(1 - color))).Invert(), true
+	}
+	return Evaluation{}, false
+}
+
+// Hand written evaluator based on the race, the home board strength,
+// the checkers on the bar and the blots left in direct contact
+type HeuristicEvaluator struct{}
+
+func (HeuristicEvaluator) Evaluate(b board.Board) Evaluation {
+	if evaluation, ok := terminalEvaluation(b); ok {
+		return evaluation
+	}
+
+	color := b.ColorToMove
+	opponent := board.Color(1 - color)
+	pips := float64(b.PipCount(color))
+	opponentPips := float64(b.PipCount(opponent))
+
+	// The score is expressed in pips, being on roll is worth about 4 pips
+	score := opponentPips - pips + 4
+	if hasContact(b) {
+		score += positionalScore(b, color) - positionalScore(b, opponent)
+	}
+	win := logistic(score / math.Sqrt((pips+opponentPips)/2))
+
+	evaluation := Evaluation{Win: win}
+	evaluation.WinGammon, evaluation.WinBackgammon = gammonChances(b, color, win)
+	evaluation.LoseGammon, evaluation.LoseBackgammon = gammonChances(b, opponent, 1-win)
+	return evaluation
+}
+
+// Function that scores the structure of a player's position, in pips
+func positionalScore(b board.Board, color board.Color) float64 {
+	opponent := board.Color(1 - color)
+	score := 0.0
+
+	// Home board points make entering harder for the opponent
+	homePoints := 0
+	for number := 1; number <= 6; number++ {
+		point := b.Points[board.PointIndexFromNumber(number, color)]
+		if point.Checker.Color == color && point.CheckerCount > 1 {
+			homePoints += 1
+		}
+	}
+	score += 3 * float64(homePoints)
+	score += float64(b.Points[board.PointIndexFromNumber(board.NUM_PLAYABLE_POINTS+1, opponent)].CheckerCount) * 2 * float64(homePoints+1)
+
+	// Consecutive made points block the opponent's back checkers
+	prime := 0
+	for number := 1; number <= board.NUM_PLAYABLE_POINTS; number++ {
+		point := b.Points[board.PointIndexFromNumber(number, color)]
+		if point.Checker.Color == color && point.CheckerCount > 1 {
+			prime += 1
+			if prime > 2 {
+				score += 2 * float64(prime-2)
+			}
+		} else {
+			prime = 0
+		}
+	}
+
+	// Blots that can be hit by an opponent's checker are a liability
+	opponentBack := backmostPointNumber(b, opponent)
+	for number := 1; number <= board.NUM_PLAYABLE_POINTS; number++ {
+		point := b.Points[board.PointIndexFromNumber(number, color)]
+		// Point number seen by the opponent is 25 - number
+		if point.Checker.Color == color && point.CheckerCount == 1 && board.NUM_PLAYABLE_POINTS+1-number < opponentBack {
+			score -= 4 + float64(board.NUM_PLAYABLE_POINTS-number)/4
+		}
+	}
+	return score
+}
+
+// Function that returns the number, from the player's perspective,
+// of the point holding the player's checker furthest from home
+func backmostPointNumber(b board.Board, color board.Color) int {
+	if b.Points[board.PointIndexFromNumber(board.NUM_PLAYABLE_POINTS+1, color)].CheckerCount > 0 {
+		return board.NUM_PLAYABLE_POINTS + 1
+	}
+	for number := board.NUM_PLAYABLE_POINTS; number >= 1; number-- {
+		point := b.Points[board.PointIndexFromNumber(number, color)]
+		if point.Checker.Color == color && point.CheckerCount > 0 {
+			return number
+		}
+	}
+	return 0
+}
+
+// Function that checks whether any checkers can still hit each other
+func hasContact(b board.Board) bool {
+	return backmostPointNumber(b, board.COLOR_WHITE)+backmostPointNumber(b, board.COLOR_BLACK) > board.NUM_PLAYABLE_POINTS+1
+}
+
+// Function that estimates the chances of the player with the given color to
+// win a gammon and a backgammon, knowing his chances of winning the game
+func gammonChances(b board.Board, color board.Color, win float64) (float64, float64) {
+	opponent := board.Color(1 - color)
+	if b.CheckersOff(opponent) > 0 {
+		return 0, 0
+	}
+	pipsDifference := float64(b.PipCount(opponent) - b.PipCount(color))
+	gammon := win * logistic((pipsDifference-60)/15)
+
+	// Opponent's checkers on the bar or in the player's home board
+	behind := b.Points[board.PointIndexFromNumber(board.NUM_PLAYABLE_POINTS+1, opponent)].CheckerCount
+	for number := 19; number <= board.NUM_PLAYABLE_POINTS; number++ {
+		point := b.Points[board.PointIndexFromNumber(number, opponent)]
+		if point.Checker.Color == opponent {
+			behind += point.CheckerCount
+		}
+	}
+	backgammon := gammon * math.Min(1, float64(behind)/float64(board.INIT_NUM_CHECKERS)) / 2
+	return gammon, backgammon
+}
+
+func logistic(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"math"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+type evaluationTest struct {
+	board          board.Board
+	expectedEquity float64
+}
+
+func TestHeuristicEvaluatorTerminal(t *testing.T) {
+	for _, test := range makeTerminalEvaluationTests() {
+		if output := (HeuristicEvaluator{}).Evaluate(test.board).Equity(); output != test.expectedEquity {
+			t.Errorf("Output %f not equal to expected %f for %s", output, test.expectedEquity, test.board.SerializeBoard())
+		}
+	}
+}
+
+func TestHeuristicEvaluatorIsConsistent(t *testing.T) {
+	boards := []board.Board{
+		board.NewBoard(board.COLOR_WHITE),
+		board.DeserializeBoard("6-5/8-3/13-5/18-1/23-1:1-2/12-5/17-3/19-5 0 0 b"),
+		board.DeserializeBoard("1-3/2-4/3-3/5-3:19-4/20-4/22-3 0 0 w"),
+	}
+	for _, b := range boards {
+		evaluation := HeuristicEvaluator{}.Evaluate(b)
+		if evaluation.Win < 0 || evaluation.Win > 1 ||
+			evaluation.WinGammon > evaluation.Win || evaluation.WinBackgammon > evaluation.WinGammon ||
+			evaluation.LoseGammon > 1-evaluation.Win || evaluation.LoseBackgammon > evaluation.LoseGammon {
+			t.Errorf("Inconsistent evaluation %+v for %s", evaluation, b.SerializeBoard())
+		}
+		if inverted := evaluation.Invert().Invert(); math.Abs(inverted.Equity()-evaluation.Equity()) > 1e-9 {
+			t.Errorf("Output %f not equal to expected %f", inverted.Equity(), evaluation.Equity())
+		}
+	}
+}
+
+func makeTerminalEvaluationTests() []evaluationTest {
+	// test 1 - white to move has borne off everything, black has borne off one checker
+	board1 := board.DeserializeBoard(":19-14 0 0 w")
+
+	// test 2 - black to move, white has borne off everything, black has none off
+	board2 := board.DeserializeBoard(":12-5/19-10 0 0 b")
+
+	// test 3 - white to move, black has borne off everything, white has a checker on the bar
+	board3 := board.DeserializeBoard("1-14: 1 0 w")
+
+	return []evaluationTest{
+		{board1, 1},
+		{board2, -2},
+		{board3, -3},
+	}
+}
@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"context"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/parallel"
+)
+
+// Largest depth accepted by the registered expectimax AI
+const MAX_EXPECTIMAX_DEPTH = 2
+
+// Rough factor by which every roll looked ahead multiplies the work of an
+// evaluation: 21 rolls with about 20 move rolls each
+const EXPECTIMAX_BRANCHING = 400
+
+// Evaluator that looks ahead the given number of rolls: the evaluation of
+// a position is the average over the 21 rolls of the player to move of the
+// evaluation after the best move roll for each roll, with one roll less.
+// At depth 0 it returns the evaluation of the underlying evaluator
+// NOTE: every roll multiplies the work by EXPECTIMAX_BRANCHING, depths
+// above 2 are impractical
+type ExpectimaxEvaluator struct {
+	Evaluator Evaluator
+	Depth     int
+	// Number of goroutines evaluating the 21 rolls of the first roll looked
+	// ahead, the deeper rolls are evaluated on the same goroutine. The
+	// evaluation does not depend on it, at most 1 evaluates sequentially.
+	// The evaluator must be safe for concurrent use if it is above 1
+	Workers int
+}
+
+func (e ExpectimaxEvaluator) Evaluate(b board.Board) Evaluation {
+	evaluation, _ := e.EvaluateContext(context.Background(), b)
+	return evaluation
+}
+
+// Function that evaluates the position like Evaluate until the context is
+// done: the rolls left are not evaluated and the error of the context is
+// returned, a search stopped at a deadline does not run past it
+func (e ExpectimaxEvaluator) EvaluateContext(ctx context.Context, b board.Board) (Evaluation, error) {
+	if evaluation, ok := terminalEvaluation(b); ok {
+		return evaluation, nil
+	}
+	if e.Depth <= 0 {
+		return EvaluateContext(ctx, e.Evaluator, b)
+	}
+
+	next := ExpectimaxEvaluator{Evaluator: e.Evaluator, Depth: e.Depth - 1}
+	rolls := []board.DieRoll{}
+	for d1 := 1; d1 <= 6; d1++ {
+		for d2 := d1; d2 <= 6; d2++ {
+			rolls = append(rolls, board.DieRoll{Die1: d1, Die2: d2})
+		}
+	}
+	evaluations := make([]Evaluation, len(rolls))
+	errs := make([]error, len(rolls))
+	parallel.For(len(rolls), e.Workers, func(idx int) {
+		if errs[idx] = ctx.Err(); errs[idx] == nil {
+			evaluations[idx], errs[idx] = next.bestMoveRoll(ctx, b, rolls[idx])
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return Evaluation{}, err
+		}
+	}
+
+	// Summed in the order of the rolls, so the average is the same whatever the workers
+	average := Evaluation{}
+	for idx, evaluation := range evaluations {
+		// Non doubles are rolled in two ways out of 36
+		weight := 2.0 / 36
+		if rolls[idx].Die1 == rolls[idx].Die2 {
+			weight = 1.0 / 36
+		}
+		average.Win += weight * evaluation.Win
+		average.WinGammon += weight * evaluation.WinGammon
+		average.WinBackgammon += weight * evaluation.WinBackgammon
+		average.LoseGammon += weight * evaluation.LoseGammon
+		average.LoseBackgammon += weight * evaluation.LoseBackgammon
+	}
+	return average, nil
+}
+
+// Function that returns the evaluation after the best move roll for the
+// dice, from the perspective of the player to move
+func (e ExpectimaxEvaluator) bestMoveRoll(ctx context.Context, b board.Board, d board.DieRoll) (Evaluation, error) {
+	results := b.GetValidMoveResultsForDieRoll(d)
+	if len(results) == 0 {
+		next := b
+		next.ColorToMove = board.Color(1 - b.ColorToMove)
+		evaluation, err := e.EvaluateContext(ctx, next)
+		return evaluation.Invert(), err
+	}
+	best := Evaluation{}
+	for idx, result := range results {
+		evaluation, err := EvaluateMoveRollContext(ctx, e, result)
+		if err != nil {
+			return Evaluation{}, err
+		}
+		if idx == 0 || evaluation.Equity() > best.Equity() {
+			best = evaluation
+		}
+	}
+	return best, nil
+}
@@ -0,0 +1,73 @@
+package ai
+
+import (
+	"sort"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/parallel"
+)
+
+type HintOptions struct {
+	// Evaluator of the positions after the move rolls, HeuristicEvaluator if nil
+	Evaluator Evaluator
+	// Number of rolls looked ahead with an ExpectimaxEvaluator, 0 evaluates
+	// the positions after the move rolls directly
+	Depth int
+	// Number of goroutines generating and evaluating the move rolls, at
+	// most 1 evaluates them sequentially. The candidates do not depend on
+	// it, but the evaluator must be safe for concurrent use if it is above 1
+	Workers int
+}
+
+// A move roll suggested by Hint, evaluated from the perspective
+// of the player who plays it
+type Candidate struct {
+	MoveRoll   board.MoveRoll
+	Notation   string
+	Evaluation Evaluation
+	Equity     float64
+	// Equity of the candidate minus the equity of the best candidate,
+	// 0 for the best candidate and negative for the others
+	Difference float64
+}
+
+// Function that returns the n best move rolls for the dice ranked by
+// equity, all of them if n is not positive. Move rolls leading to the
+// same position are suggested once
+func Hint(b board.Board, d board.DieRoll, n int, options HintOptions) []Candidate {
+	evaluator := options.Evaluator
+	if evaluator == nil {
+		evaluator = HeuristicEvaluator{}
+	}
+	if options.Depth > 0 {
+		evaluator = ExpectimaxEvaluator{Evaluator: evaluator, Depth: options.Depth}
+	}
+
+	candidates := []Candidate{}
+	results := []board.MoveRollResult{}
+	positions := map[string]bool{}
+	for _, result := range b.GetValidMoveResultsParallel(d, options.Workers) {
+		position := result.Board.SerializeBoard()
+		if positions[position] {
+			continue
+		}
+		positions[position] = true
+		candidates = append(candidates, Candidate{MoveRoll: result.MoveRoll, Notation: result.Notation})
+		results = append(results, result)
+	}
+	parallel.For(len(candidates), options.Workers, func(idx int) {
+		evaluation := EvaluateMoveRoll(evaluator, results[idx])
+		candidates[idx].Evaluation, candidates[idx].Equity = evaluation, evaluation.Equity()
+	})
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Equity > candidates[j].Equity
+	})
+	if n > 0 && len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	for idx := range candidates {
+		candidates[idx].Difference = candidates[idx].Equity - candidates[0].Equity
+	}
+	return candidates
+}
@@ -0,0 +1,105 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+func TestHintRanksCandidates(t *testing.T) {
+	// ARRANGE
+	b := board.NewBoard(board.COLOR_WHITE)
+	dice := board.DieRoll{Die1: 3, Die2: 1}
+
+	// ACT
+	all := Hint(b, dice, 0, HintOptions{})
+	top := Hint(b, dice, 3, HintOptions{})
+
+	// ASSERT
+	if len(top) != 3 || len(all) < len(top) {
+		t.Fatalf("Output %v not equal to expected %v candidates", len(top), 3)
+	}
+	expected := EvaluatorAI{Evaluator: HeuristicEvaluator{}}.ChooseMove(b, dice).Notation(b)
+	if top[0].Notation != expected || top[0].Difference != 0 {
+		t.Errorf("Output %v not equal to expected %v", top[0].Notation, expected)
+	}
+	positions := map[string]bool{}
+	for idx, candidate := range all {
+		if idx > 0 && candidate.Equity > all[idx-1].Equity {
+			t.Errorf("Candidate %v ranked after a worse candidate", candidate.Notation)
+		}
+		if math.Abs(candidate.Difference-(candidate.Equity-all[0].Equity)) > 1e-9 || candidate.Equity != candidate.Evaluation.Equity() {
+			t.Errorf("Unexpected candidate %+v", candidate)
+		}
+		next := b.CopyBoard()
+		for _, mv := range candidate.MoveRoll {
+			next = mv.MakeMove(next)
+		}
+		if positions[next.SerializeBoard()] {
+			t.Errorf("Candidate %v repeats a position", candidate.Notation)
+		}
+		positions[next.SerializeBoard()] = true
+	}
+}
+
+func TestExpectimaxEvaluator(t *testing.T) {
+	// ARRANGE
+	// White bears off the last checker with any roll and wins a gammon
+	b := board.DeserializeBoard("1-1:24-15 0 0 w")
+	middle := board.DeserializeBoard("6-5/8-3/13-5/18-1/23-1:1-2/12-5/17-3/19-5 0 0 b")
+
+	// ACT
+	lookahead := ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 1}.Evaluate(b)
+	static := ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 0}.Evaluate(middle)
+
+	// ASSERT
+	expected := Evaluation{Win: 1, WinGammon: 1}
+	if math.Abs(lookahead.Win-1) > 1e-9 || math.Abs(lookahead.WinGammon-1) > 1e-9 || lookahead.LoseGammon != 0 {
+		t.Errorf("Output %+v not equal to expected %+v", lookahead, expected)
+	}
+	if static != (HeuristicEvaluator{}).Evaluate(middle) {
+		t.Errorf("Output %+v not equal to expected %+v", static, HeuristicEvaluator{}.Evaluate(middle))
+	}
+}
+
+func TestExpectimaxEvaluatorDeadline(t *testing.T) {
+	// ARRANGE
+	b := board.DeserializeBoard("6-5/8-3/13-5/18-1/23-1:1-2/12-5/17-3/19-5 0 0 b")
+	deadline := 20 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	// ACT
+	start := time.Now()
+	_, err := ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 2}.EvaluateContext(ctx, b)
+	elapsed := time.Since(start)
+
+	// ASSERT
+	if err != context.DeadlineExceeded || elapsed > deadline+50*time.Millisecond {
+		t.Errorf("Output %v %v not equal to expected %v %v", err, elapsed, context.DeadlineExceeded, deadline)
+	}
+}
+
+func TestHintWorkers(t *testing.T) {
+	// ARRANGE
+	b := board.DeserializeBoard("1-3/2-4/3-3/5-3:19-4/20-4/22-3 0 0 w")
+	dice := board.DieRoll{Die1: 2, Die2: 2}
+	expected := Hint(b, dice, 0, HintOptions{Depth: 1})
+	expectedEvaluation := ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 1}.Evaluate(b)
+
+	// ACT
+	output := Hint(b, dice, 0, HintOptions{Depth: 1, Workers: 4})
+	evaluation := ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 1, Workers: 4}.Evaluate(b)
+
+	// ASSERT
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %v not equal to expected %v", output, expected)
+	}
+	if evaluation != expectedEvaluation {
+		t.Errorf("Output %+v not equal to expected %+v", evaluation, expectedEvaluation)
+	}
+}
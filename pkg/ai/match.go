@@ -0,0 +1,49 @@
+package ai
+
+import (
+	"context"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+// Match winning chances of the players of a match, e.g. a met.Match. In a
+// game of a match the AIs play for the match winning chances instead of
+// the cubeless equity, gammons being worth more or less than in money games
+type MatchEquity interface {
+	// Function that converts the cubeless evaluation of the player with the
+	// given color to match winning chances, for a game played for the
+	// given cube value
+	EvaluationMWC(evaluation Evaluation, color board.Color, cubeValue int) float64
+}
+
+type matchContextKey struct{}
+
+type matchContext struct {
+	match     MatchEquity
+	cubeValue int
+}
+
+// Function that returns a context telling the AIs choosing a move roll
+// with it that the game is played for cubeValue points in the match
+func WithMatch(ctx context.Context, match MatchEquity, cubeValue int) context.Context {
+	return context.WithValue(ctx, matchContextKey{}, matchContext{match, cubeValue})
+}
+
+// Function that returns the match and the cube value set by WithMatch, if any
+func MatchFromContext(ctx context.Context) (MatchEquity, int, bool) {
+	value, ok := ctx.Value(matchContextKey{}).(matchContext)
+	return value.match, value.cubeValue, ok
+}
+
+// Function that returns the function scoring the evaluations of the player
+// with the given color: the match winning chances in a game of a match,
+// the cubeless equity otherwise
+func scoreFunc(ctx context.Context, color board.Color) func(Evaluation) float64 {
+	match, cubeValue, ok := MatchFromContext(ctx)
+	if !ok {
+		return Evaluation.Equity
+	}
+	return func(evaluation Evaluation) float64 {
+		return match.EvaluationMWC(evaluation, color, cubeValue)
+	}
+}
@@ -0,0 +1,243 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownAI        = errors.New("unknown AI")
+	ErrUnknownEvaluator = errors.New("unknown evaluator")
+	ErrInvalidOption    = errors.New("invalid AI option")
+)
+
+type OptionType string
+
+const (
+	OPTION_INT      OptionType = "int"
+	OPTION_FLOAT    OptionType = "float"
+	OPTION_STRING   OptionType = "string"
+	OPTION_DURATION OptionType = "duration"
+	// Name of a registered evaluator
+	OPTION_EVALUATOR OptionType = "evaluator"
+)
+
+// Option accepted by a registered AI, written name=value in AI specs
+type Option struct {
+	Name    string
+	Type    OptionType
+	Default string
+	Usage   string
+}
+
+// AI implementation registered under a name, New builds an AI from
+// options parsed and typed according to the declared options
+type Factory struct {
+	Name    string
+	Usage   string
+	Options []Option
+	New     func(options Options) (AI, error)
+}
+
+// Typed values of the options of an AI, every declared option has
+// a value, its default if it was not given
+type Options struct {
+	values map[string]interface{}
+}
+
+func (o Options) Int(name string) int {
+	value, _ := o.values[name].(int)
+	return value
+}
+
+func (o Options) Float(name string) float64 {
+	value, _ := o.values[name].(float64)
+	return value
+}
+
+func (o Options) String(name string) string {
+	value, _ := o.values[name].(string)
+	return value
+}
+
+func (o Options) Duration(name string) time.Duration {
+	value, _ := o.values[name].(time.Duration)
+	return value
+}
+
+func (o Options) Evaluator(name string) Evaluator {
+	value, _ := o.values[name].(Evaluator)
+	return value
+}
+
+var (
+	registryMutex sync.RWMutex
+	factories     = map[string]Factory{}
+	evaluators    = map[string]Evaluator{}
+)
+
+// Function that registers an AI implementation, it panics if the name is
+// already taken, like registering the same AI twice in an init function
+func Register(factory Factory) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if _, ok := factories[factory.Name]; ok {
+		panic("ai: Register called twice for " + factory.Name)
+	}
+	factories[factory.Name] = factory
+}
+
+// Function that registers an evaluator usable in evaluator options,
+// it panics if the name is already taken
+func RegisterEvaluator(name string, evaluator Evaluator) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if _, ok := evaluators[name]; ok {
+		panic("ai: RegisterEvaluator called twice for " + name)
+	}
+	evaluators[name] = evaluator
+}
+
+// Function that returns the registered AI implementations sorted by name
+func Factories() []Factory {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	result := []Factory{}
+	for _, factory := range factories {
+		result = append(result, factory)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Function that returns the names of the registered AI implementations
+func Names() []string {
+	names := []string{}
+	for _, factory := range Factories() {
+		names = append(names, factory.Name)
+	}
+	return names
+}
+
+// Function that builds an AI from a spec: the name of a registered AI
+// followed by options, e.g. expectimax:depth=2,evaluator=heuristic
+func New(spec string) (AI, error) {
+	name, values, err := parseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	registryMutex.RLock()
+	factory, ok := factories[name]
+	registryMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q, available AIs: %s", ErrUnknownAI, name, strings.Join(Names(), ", "))
+	}
+
+	options := Options{map[string]interface{}{}}
+	for _, option := range factory.Options {
+		text, ok := values[option.Name]
+		if !ok {
+			text = option.Default
+		}
+		delete(values, option.Name)
+		value, err := parseOption(option, text)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s=%s for %s: %v", ErrInvalidOption, option.Name, text, name, err)
+		}
+		options.values[option.Name] = value
+	}
+	if len(values) > 0 {
+		unknown := []string{}
+		for key := range values {
+			unknown = append(unknown, key)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%w %s for %s", ErrInvalidOption, strings.Join(unknown, ", "), name)
+	}
+	return factory.New(options)
+}
+
+// Function that splits a spec in the name of the AI and the text of its options
+func parseSpec(spec string) (string, map[string]string, error) {
+	name, optionsText, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	values := map[string]string{}
+	if optionsText == "" {
+		return name, values, nil
+	}
+	for _, pair := range strings.Split(optionsText, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return "", nil, fmt.Errorf("%w %q, expected name=value", ErrInvalidOption, pair)
+		}
+		values[key] = strings.TrimSpace(value)
+	}
+	return name, values, nil
+}
+
+func parseOption(option Option, text string) (interface{}, error) {
+	switch option.Type {
+	case OPTION_INT:
+		return strconv.Atoi(text)
+	case OPTION_FLOAT:
+		return strconv.ParseFloat(text, 64)
+	case OPTION_DURATION:
+		return time.ParseDuration(text)
+	case OPTION_EVALUATOR:
+		registryMutex.RLock()
+		defer registryMutex.RUnlock()
+		evaluator, ok := evaluators[text]
+		if !ok {
+			return nil, ErrUnknownEvaluator
+		}
+		return evaluator, nil
+	default:
+		return text, nil
+	}
+}
+
+func init() {
+	RegisterEvaluator("heuristic", HeuristicEvaluator{})
+
+	Register(Factory{
+		Name:  "heuristic",
+		Usage: "plays the move roll its evaluator likes the most, without looking ahead",
+		Options: []Option{
+			{Name: "evaluator", Type: OPTION_EVALUATOR, Default: "heuristic", Usage: "evaluator of the positions"},
+			{Name: "time", Type: OPTION_DURATION, Default: "0s", Usage: "time budget of a move, e.g. 500ms, 0s for no budget"},
+		},
+		New: func(options Options) (AI, error) {
+			return newTimedAI(options.Evaluator("evaluator"), options.Duration("time"))
+		},
+	})
+	Register(Factory{
+		Name:  "expectimax",
+		Usage: "plays the move roll with the best average evaluation a number of rolls ahead",
+		Options: []Option{
+			{Name: "depth", Type: OPTION_INT, Default: "1", Usage: "number of rolls looked ahead, between 0 and 2"},
+			{Name: "evaluator", Type: OPTION_EVALUATOR, Default: "heuristic", Usage: "evaluator of the positions"},
+			{Name: "workers", Type: OPTION_INT, Default: "1", Usage: "number of goroutines looking ahead, the move rolls played do not depend on it"},
+			{Name: "time", Type: OPTION_DURATION, Default: "0s", Usage: "time budget of a move, the search plays the best move roll of the deepest depth reached, 0s for no budget"},
+		},
+		New: func(options Options) (AI, error) {
+			depth := options.Int("depth")
+			if depth < 0 || depth > MAX_EXPECTIMAX_DEPTH {
+				return nil, fmt.Errorf("%w: the depth must be between 0 and %d", ErrInvalidOption, MAX_EXPECTIMAX_DEPTH)
+			}
+			return newTimedAI(ExpectimaxEvaluator{Evaluator: options.Evaluator("evaluator"), Depth: depth, Workers: options.Int("workers")}, options.Duration("time"))
+		},
+	})
+}
+
+// Function that builds an EvaluatorAI with the time budget of a time option
+func newTimedAI(evaluator Evaluator, budget time.Duration) (AI, error) {
+	if budget < 0 {
+		return nil, fmt.Errorf("%w: the time budget can not be negative", ErrInvalidOption)
+	}
+	return EvaluatorAI{Evaluator: evaluator, Time: budget}, nil
+}
@@ -0,0 +1,109 @@
+package ai
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type specTest struct {
+	spec          string
+	expected      AI
+	expectedError error
+}
+
+func makeSpecTests() []specTest {
+	return []specTest{
+		// test 1 - default options
+		{"heuristic", EvaluatorAI{Evaluator: HeuristicEvaluator{}}, nil},
+		// test 2 - typed options
+		{"expectimax:depth=2", EvaluatorAI{Evaluator: ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 2, Workers: 1}}, nil},
+		// test 3 - spaces around the options
+		{" expectimax: evaluator = heuristic , depth=0", EvaluatorAI{Evaluator: ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 0, Workers: 1}}, nil},
+		// test 4 - an unknown AI
+		{"gnubg", nil, ErrUnknownAI},
+		// test 5 - an option of the wrong type
+		{"expectimax:depth=two", nil, ErrInvalidOption},
+		// test 6 - an unknown option
+		{"heuristic:depth=1", nil, ErrInvalidOption},
+		// test 7 - an option without a value
+		{"expectimax:depth", nil, ErrInvalidOption},
+		// test 8 - an unknown evaluator
+		{"expectimax:evaluator=neural", nil, ErrInvalidOption},
+		// test 9 - a depth out of range
+		{"expectimax:depth=3", nil, ErrInvalidOption},
+		// test 10 - a time budget
+		{"expectimax:depth=2,time=1.5s", EvaluatorAI{Evaluator: ExpectimaxEvaluator{Evaluator: HeuristicEvaluator{}, Depth: 2, Workers: 1}, Time: 1500 * time.Millisecond}, nil},
+		// test 11 - a negative time budget
+		{"heuristic:time=-1s", nil, ErrInvalidOption},
+	}
+}
+
+func TestNew(t *testing.T) {
+	for idx, test := range makeSpecTests() {
+		// ACT
+		output, err := New(test.spec)
+
+		// ASSERT
+		if !errors.Is(err, test.expectedError) || !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Test %d: output %v %v not equal to expected %v %v", idx+1, output, err, test.expected, test.expectedError)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	// ARRANGE
+	Register(Factory{
+		Name:    "test-timed",
+		Options: []Option{{Name: "time", Type: OPTION_DURATION, Default: "1s"}, {Name: "noise", Type: OPTION_FLOAT, Default: "0"}},
+		New: func(options Options) (AI, error) {
+			if options.Duration("time") != 2*time.Second || options.Float("noise") != 0 {
+				return nil, ErrInvalidOption
+			}
+			return EvaluatorAI{}, nil
+		},
+	})
+
+	// ACT
+	_, err := New("test-timed:time=2s")
+
+	// ASSERT
+	if err != nil {
+		t.Errorf("Output %v not equal to expected %v", err, nil)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Registering a name twice should panic")
+		}
+	}()
+	Register(Factory{Name: "test-timed"})
+}
+
+func TestConfig(t *testing.T) {
+	// ARRANGE
+	config, err := LoadConfig(strings.NewReader(`{"players": {"strong": "expectimax:depth=2"}}`))
+	if err != nil {
+		t.Fatalf("Output %v not equal to expected %v", err, nil)
+	}
+
+	// ACT
+	named, namedErr := config.New("strong")
+	spec, specErr := config.New("expectimax:depth=0")
+	_, invalidErr := LoadConfig(strings.NewReader(`{"players": {"weak": "expectimax:depth=-1"}}`))
+
+	// ASSERT
+	if namedErr != nil || named.(EvaluatorAI).Evaluator.(ExpectimaxEvaluator).Depth != 2 {
+		t.Errorf("Output %v %v not equal to expected %v", named, namedErr, "expectimax at depth 2")
+	}
+	if specErr != nil || spec.(EvaluatorAI).Evaluator.(ExpectimaxEvaluator).Depth != 0 {
+		t.Errorf("Output %v %v not equal to expected %v", spec, specErr, "expectimax at depth 0")
+	}
+	if !errors.Is(invalidErr, ErrInvalidOption) || !strings.Contains(invalidErr.Error(), "weak") {
+		t.Errorf("Output %v not equal to expected %v", invalidErr, ErrInvalidOption)
+	}
+	if !reflect.DeepEqual(config.Names(), []string{"strong"}) || len(DefaultConfig().Players) < 2 {
+		t.Errorf("Output %v not equal to expected %v", config.Names(), []string{"strong"})
+	}
+}
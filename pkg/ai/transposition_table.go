@@ -0,0 +1,256 @@
+package ai
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+type ReplacementPolicy int
+
+const (
+	// A new entry always replaces the entry stored in its slot
+	REPLACE_ALWAYS ReplacementPolicy = 0
+	// A new entry replaces the entry stored in its slot only if it was
+	// searched at least as deep, entries for the same position are always updated
+	REPLACE_DEPTH_PREFERRED ReplacementPolicy = 1
+)
+
+const tableLockStripes = 64
+const tableFileVersion = 1
+
+var ErrInvalidTableFile = errors.New("invalid transposition table file")
+
+type TableEntry struct {
+	Key        uint64
+	Depth      int
+	Evaluation Evaluation
+}
+
+type TableStats struct {
+	Hits         uint64
+	Misses       uint64
+	Stores       uint64
+	Replacements uint64
+}
+
+// Function that computes the ratio of lookups that found an entry
+func (s TableStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Fixed size cache of evaluations keyed by the board hash, safe for
+// concurrent use. Every key maps to a single slot, so the memory used
+// by the table never grows past the size it was created with
+type TranspositionTable struct {
+	policy  ReplacementPolicy
+	entries []TableEntry
+	used    []bool
+	locks   [tableLockStripes]sync.Mutex
+
+	hits         uint64
+	misses       uint64
+	stores       uint64
+	replacements uint64
+}
+
+func NewTranspositionTable(size int, policy ReplacementPolicy) *TranspositionTable {
+	if size <= 0 {
+		panic("Transposition table size must be positive")
+	}
+	return &TranspositionTable{
+		policy:  policy,
+		entries: make([]TableEntry, size),
+		used:    make([]bool, size),
+	}
+}
+
+// Function that looks up the evaluation of a position searched
+// at least minDepth plies deep
+func (tt *TranspositionTable) Get(key uint64, minDepth int) (Evaluation, bool) {
+	slot := tt.slot(key)
+	lock := &tt.locks[slot%tableLockStripes]
+
+	lock.Lock()
+	entry, used := tt.entries[slot], tt.used[slot]
+	lock.Unlock()
+
+	if used && entry.Key == key && entry.Depth >= minDepth {
+		atomic.AddUint64(&tt.hits, 1)
+		return entry.Evaluation, true
+	}
+	atomic.AddUint64(&tt.misses, 1)
+	return Evaluation{}, false
+}
+
+// Function that stores the evaluation of a position searched depth plies
+// deep, according to the replacement policy of the table
+func (tt *TranspositionTable) Put(key uint64, depth int, evaluation Evaluation) {
+	slot := tt.slot(key)
+	lock := &tt.locks[slot%tableLockStripes]
+
+	lock.Lock()
+	defer lock.Unlock()
+	if tt.used[slot] && tt.entries[slot].Key != key {
+		if tt.policy == REPLACE_DEPTH_PREFERRED && tt.entries[slot].Depth > depth {
+			return
+		}
+		atomic.AddUint64(&tt.replacements, 1)
+	}
+	tt.entries[slot] = TableEntry{key, depth, evaluation}
+	tt.used[slot] = true
+	atomic.AddUint64(&tt.stores, 1)
+}
+
+// Function that returns the number of entries stored in the table
+func (tt *TranspositionTable) Len() int {
+	count := 0
+	tt.forEachSlot(func(slot int) {
+		if tt.used[slot] {
+			count += 1
+		}
+	})
+	return count
+}
+
+// Function that returns the number of slots in the table
+func (tt *TranspositionTable) Size() int {
+	return len(tt.entries)
+}
+
+func (tt *TranspositionTable) Stats() TableStats {
+	return TableStats{
+		Hits:         atomic.LoadUint64(&tt.hits),
+		Misses:       atomic.LoadUint64(&tt.misses),
+		Stores:       atomic.LoadUint64(&tt.stores),
+		Replacements: atomic.LoadUint64(&tt.replacements),
+	}
+}
+
+// Function that removes all the entries and resets the statistics
+func (tt *TranspositionTable) Clear() {
+	tt.forEachSlot(func(slot int) {
+		tt.used[slot] = false
+		tt.entries[slot] = TableEntry{}
+	})
+	atomic.StoreUint64(&tt.hits, 0)
+	atomic.StoreUint64(&tt.misses, 0)
+	atomic.StoreUint64(&tt.stores, 0)
+	atomic.StoreUint64(&tt.replacements, 0)
+}
+
+type tableFileHeader struct {
+	Version    int
+	NumEntries int
+}
+
+// Function that writes all the entries of the table to w
+func (tt *TranspositionTable) Save(w io.Writer) error {
+	entries := []TableEntry{}
+	tt.forEachSlot(func(slot int) {
+		if tt.used[slot] {
+			entries = append(entries, tt.entries[slot])
+		}
+	})
+
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(tableFileHeader{tableFileVersion, len(entries)}); err != nil {
+		return err
+	}
+	return encoder.Encode(entries)
+}
+
+// Function that reads entries written by Save and stores them in the table.
+// The table does not need to have the same size as the saved one
+func (tt *TranspositionTable) Load(r io.Reader) error {
+	decoder := gob.NewDecoder(r)
+	header := tableFileHeader{}
+	if err := decoder.Decode(&header); err != nil {
+		return err
+	}
+	if header.Version != tableFileVersion {
+		return ErrInvalidTableFile
+	}
+
+	entries := []TableEntry{}
+	if err := decoder.Decode(&entries); err != nil {
+		return err
+	}
+	if len(entries) != header.NumEntries {
+		return ErrInvalidTableFile
+	}
+	for _, entry := range entries {
+		tt.Put(entry.Key, entry.Depth, entry.Evaluation)
+	}
+	return nil
+}
+
+func (tt *TranspositionTable) SaveToFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := tt.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (tt *TranspositionTable) LoadFromFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return tt.Load(f)
+}
+
+func (tt *TranspositionTable) slot(key uint64) int {
+	return int(key % uint64(len(tt.entries)))
+}
+
+// Function that calls fn for every slot while holding the slot's lock
+func (tt *TranspositionTable) forEachSlot(fn func(slot int)) {
+	for stripe := 0; stripe < tableLockStripes; stripe++ {
+		tt.locks[stripe].Lock()
+		for slot := stripe; slot < len(tt.entries); slot += tableLockStripes {
+			fn(slot)
+		}
+		tt.locks[stripe].Unlock()
+	}
+}
+
+// Evaluator that caches the evaluations of another evaluator in a
+// transposition table. A table must only be shared by evaluators
+// that return the same evaluations, e.g. the same evaluator at the same depth.
+// Positions are keyed by their normalized board, so a position and its
+// mirror with the other player to move share the same entry
+type CachedEvaluator struct {
+	Evaluator Evaluator
+	Table     *TranspositionTable
+	// Depth the evaluations are stored with
+	Depth int
+}
+
+func NewCachedEvaluator(evaluator Evaluator, table *TranspositionTable) CachedEvaluator {
+	return CachedEvaluator{evaluator, table, 0}
+}
+
+func (c CachedEvaluator) Evaluate(b board.Board) Evaluation {
+	key := b.Normalized().Hash()
+	if evaluation, ok := c.Table.Get(key, c.Depth); ok {
+		return evaluation
+	}
+	evaluation := c.Evaluator.Evaluate(b)
+	c.Table.Put(key, c.Depth, evaluation)
+	return evaluation
+}
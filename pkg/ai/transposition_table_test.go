@@ -0,0 +1,164 @@
+package ai
+
+import (
+	"bytes"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+type countingEvaluator struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *countingEvaluator) Evaluate(b board.Board) Evaluation {
+	c.mu.Lock()
+	c.calls += 1
+	c.mu.Unlock()
+	return HeuristicEvaluator{}.Evaluate(b)
+}
+
+type replacementTest struct {
+	policy           ReplacementPolicy
+	firstDepth       int
+	secondDepth      int
+	expectedFirstHit bool
+}
+
+func TestTranspositionTableGetPut(t *testing.T) {
+	// ARRANGE
+	table := NewTranspositionTable(16, REPLACE_ALWAYS)
+	evaluation := Evaluation{Win: 0.6, WinGammon: 0.1}
+
+	// ACT
+	_, foundBefore := table.Get(42, 0)
+	table.Put(42, 2, evaluation)
+	output, foundAfter := table.Get(42, 2)
+	_, foundDeeper := table.Get(42, 3)
+
+	// ASSERT
+	if foundBefore || !foundAfter || foundDeeper {
+		t.Errorf("Unexpected lookups %t %t %t", foundBefore, foundAfter, foundDeeper)
+	}
+	if output != evaluation {
+		t.Errorf("Output %v not equal to expected %v", output, evaluation)
+	}
+	if stats := table.Stats(); stats.Hits != 1 || stats.Misses != 2 || stats.Stores != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if output := table.Stats().HitRate(); output != 1.0/3 {
+		t.Errorf("Output %f not equal to expected %f", output, 1.0/3)
+	}
+}
+
+func TestTranspositionTableReplacementPolicy(t *testing.T) {
+	for _, test := range makeReplacementTests() {
+		// Keys 1 and 5 collide in a table with 4 slots
+		table := NewTranspositionTable(4, test.policy)
+		table.Put(1, test.firstDepth, Evaluation{Win: 0.1})
+		table.Put(5, test.secondDepth, Evaluation{Win: 0.2})
+
+		if _, output := table.Get(1, 0); output != test.expectedFirstHit {
+			t.Errorf("Output %t not equal to expected %t for %+v", output, test.expectedFirstHit, test)
+		}
+		if output := table.Len(); output != 1 {
+			t.Errorf("Output %d not equal to expected %d", output, 1)
+		}
+	}
+}
+
+func TestTranspositionTablePersistence(t *testing.T) {
+	// ARRANGE
+	table := NewTranspositionTable(128, REPLACE_ALWAYS)
+	for key := uint64(0); key < 100; key++ {
+		table.Put(key, int(key%3), Evaluation{Win: float64(key) / 100})
+	}
+	path := filepath.Join(t.TempDir(), "table.gob")
+
+	// ACT
+	err := table.SaveToFile(path)
+	loadedTable := NewTranspositionTable(128, REPLACE_ALWAYS)
+	loadErr := loadedTable.LoadFromFile(path)
+
+	// ASSERT
+	if err != nil || loadErr != nil {
+		t.Fatalf("Unexpected errors %v %v", err, loadErr)
+	}
+	if output := loadedTable.Len(); output != 100 {
+		t.Errorf("Output %d not equal to expected %d", output, 100)
+	}
+	if output, ok := loadedTable.Get(37, 1); !ok || output.Win != 0.37 {
+		t.Errorf("Output %v not equal to expected %v", output, Evaluation{Win: 0.37})
+	}
+	if err := loadedTable.Load(bytes.NewBufferString("not a table")); err == nil {
+		t.Errorf("Expected an error when loading an invalid table")
+	}
+}
+
+func TestCachedEvaluatorConcurrent(t *testing.T) {
+	// ARRANGE
+	counter := &countingEvaluator{}
+	evaluator := NewCachedEvaluator(counter, NewTranspositionTable(1024, REPLACE_DEPTH_PREFERRED))
+	b := board.NewBoard(board.COLOR_WHITE)
+	boards := []board.Board{}
+	for _, result := range b.GetValidMoveResultsForDieRoll(board.DieRoll{Die1: 3, Die2: 1}) {
+		boards = append(boards, result.Board)
+	}
+	expected := HeuristicEvaluator{}.Evaluate(boards[0])
+
+	// ACT
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, b := range boards {
+				evaluator.Evaluate(b)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// ASSERT
+	if output := evaluator.Evaluate(boards[0]); output != expected {
+		t.Errorf("Output %v not equal to expected %v", output, expected)
+	}
+	if counter.calls >= 8*len(boards) {
+		t.Errorf("Expected cached evaluations, evaluator was called %d times", counter.calls)
+	}
+}
+
+func makeReplacementTests() []replacementTest {
+	return []replacementTest{
+		// test 1 - always replace evicts the first entry
+		{REPLACE_ALWAYS, 3, 1, false},
+		// test 2 - depth preferred keeps the deeper entry
+		{REPLACE_DEPTH_PREFERRED, 3, 1, true},
+		// test 3 - depth preferred replaces with an entry as deep
+		{REPLACE_DEPTH_PREFERRED, 1, 1, false},
+	}
+}
+
+func TestCachedEvaluatorSharesMirroredPositions(t *testing.T) {
+	// ARRANGE
+	counter := &countingEvaluator{}
+	evaluator := NewCachedEvaluator(counter, NewTranspositionTable(64, REPLACE_ALWAYS))
+	b := board.NewBoard(board.COLOR_WHITE)
+	b.Points[5].CheckerCount -= 1
+	b.Points[4].CheckerCount = 1
+
+	// ACT
+	evaluation := evaluator.Evaluate(b)
+	mirroredEvaluation := evaluator.Evaluate(b.Flip())
+
+	// ASSERT
+	if counter.calls != 1 {
+		t.Errorf("Output %d not equal to expected %d", counter.calls, 1)
+	}
+	if evaluation != mirroredEvaluation {
+		t.Errorf("Output %v not equal to expected %v", mirroredEvaluation, evaluation)
+	}
+}
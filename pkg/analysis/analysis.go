@@ -0,0 +1,332 @@
+// Package analysis reviews recorded games: every checker play is compared
+// with the best move roll according to an evaluator, every recorded cube
+// action with the proper money cube action, and the rolls are compared
+// with the average roll to measure the luck of the players
+package analysis
+
+import (
+	"math"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/cube"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+// Minimum equity losses of the flagged decisions
+const (
+	INACCURACY_THRESHOLD = 0.02
+	ERROR_THRESHOLD      = 0.04
+	BLUNDER_THRESHOLD    = 0.08
+)
+
+// The Performance Rating is the mean equity loss of the decisions
+// of a player multiplied by this factor, lower is better
+const PR_FACTOR = 500
+
+type Severity string
+
+const (
+	SEVERITY_NONE       Severity = ""
+	SEVERITY_INACCURACY Severity = "inaccuracy"
+	SEVERITY_ERROR      Severity = "error"
+	SEVERITY_BLUNDER    Severity = "blunder"
+)
+
+// Function that classifies a decision by its equity loss
+func Classify(loss float64) Severity {
+	switch {
+	case loss >= BLUNDER_THRESHOLD:
+		return SEVERITY_BLUNDER
+	case loss >= ERROR_THRESHOLD:
+		return SEVERITY_ERROR
+	case loss >= INACCURACY_THRESHOLD:
+		return SEVERITY_INACCURACY
+	default:
+		return SEVERITY_NONE
+	}
+}
+
+// Checker play of a turn. Equities are cubeless, from the perspective
+// of the player who played the move roll
+type Decision struct {
+	// Number of the turn in the record, starting from 1
+	Turn           int            `json:"turn"`
+	Color          board.Color    `json:"color"`
+	Dice           board.DieRoll  `json:"dice"`
+	Played         board.MoveRoll `json:"played"`
+	PlayedNotation string         `json:"playedNotation"`
+	Best           board.MoveRoll `json:"best"`
+	BestNotation   string         `json:"bestNotation"`
+	PlayedEquity   float64        `json:"playedEquity"`
+	BestEquity     float64        `json:"bestEquity"`
+	EquityLoss     float64        `json:"equityLoss"`
+	Severity       Severity       `json:"severity,omitempty"`
+	// Number of move rolls reaching different positions,
+	// a decision with less than two candidates is forced
+	Candidates int `json:"candidates"`
+	// Equity of the roll minus the equity of the average roll
+	Luck float64 `json:"luck"`
+}
+
+func (d Decision) Forced() bool {
+	return d.Candidates < 2
+}
+
+// Recorded cube action: a double, or the take or drop answering it.
+// Equities are money equities normalized to a cube of value 1, from the
+// perspective of the player who chose the action
+type CubeDecision struct {
+	// Number of the turn in the record, starting from 1
+	Turn         int             `json:"turn"`
+	Color        board.Color     `json:"color"`
+	Played       game.CubeAction `json:"played"`
+	Best         game.CubeAction `json:"best"`
+	Proper       cube.Action     `json:"proper"`
+	PlayedEquity float64         `json:"playedEquity"`
+	BestEquity   float64         `json:"bestEquity"`
+	EquityLoss   float64         `json:"equityLoss"`
+	Severity     Severity        `json:"severity,omitempty"`
+}
+
+// Best action of a player who doubled when the double was wrong
+const CUBE_ACTION_NO_DOUBLE game.CubeAction = "no double"
+
+type PlayerStats struct {
+	// Number of decisions that were not forced
+	Decisions    int     `json:"decisions"`
+	Inaccuracies int     `json:"inaccuracies"`
+	Errors       int     `json:"errors"`
+	Blunders     int     `json:"blunders"`
+	EquityLoss   float64 `json:"equityLoss"`
+	// Mean equity loss of the decisions in millipoints
+	ErrorRate float64 `json:"errorRate"`
+	// Performance Rating: the mean equity loss multiplied by PR_FACTOR
+	PR float64 `json:"pr"`
+	// Number of rolls and the sum of their luck
+	Rolls int     `json:"rolls"`
+	Luck  float64 `json:"luck"`
+	// Recorded cube actions, the ones flagged with a severity and their
+	// equity loss. They are not part of the error rate and the PR
+	CubeDecisions  int     `json:"cubeDecisions"`
+	CubeErrors     int     `json:"cubeErrors"`
+	CubeEquityLoss float64 `json:"cubeEquityLoss"`
+}
+
+type Report struct {
+	Players       game.Players   `json:"players"`
+	Decisions     []Decision     `json:"decisions"`
+	CubeDecisions []CubeDecision `json:"cubeDecisions"`
+	White         PlayerStats    `json:"white"`
+	Black         PlayerStats    `json:"black"`
+}
+
+// Function that returns the statistics of the player with the given color
+func (r *Report) Stats(color board.Color) *PlayerStats {
+	if color == board.COLOR_WHITE {
+		return &r.White
+	}
+	return &r.Black
+}
+
+// Analyzes the checker plays and the cube actions of recorded games with
+// an evaluator. Cube actions are analyzed as in a money game, with the
+// Janowski model and DEFAULT_CUBE_EFFICIENCY, and only the recorded ones:
+// a player who did not double is not checked for a missed double
+type Analyzer struct {
+	Evaluator ai.Evaluator
+}
+
+// Function that analyzes every turn of a record, the record is
+// replayed so every move roll is checked to be legal
+func (a Analyzer) Analyze(record game.Record) (Report, error) {
+	replayer, err := game.NewReplayer(record)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Players: record.Players, Decisions: []Decision{}, CubeDecisions: []CubeDecision{}}
+	for replayer.Index() < replayer.Len() {
+		before := replayer.Position()
+		replayer.Forward()
+		turn := replayer.Position().Turn
+		cubeDecisions, err := a.analyzeCubeActions(before.Board, before.Cube, turn.CubeActions)
+		if err != nil {
+			return Report{}, err
+		}
+		for _, decision := range cubeDecisions {
+			decision.Turn = replayer.Index()
+			report.CubeDecisions = append(report.CubeDecisions, decision)
+			report.Stats(decision.Color).addCube(decision)
+		}
+		if turn.Dice == nil {
+			continue
+		}
+		decision := a.analyzeTurn(before.Board, *turn.Dice, replayer.Position().Board)
+		decision.Turn = replayer.Index()
+		report.Decisions = append(report.Decisions, decision)
+		report.Stats(decision.Color).add(decision)
+	}
+	report.White.finish()
+	report.Black.finish()
+	return report, nil
+}
+
+// Function that compares the move roll played on the given board, leading
+// to the position after, with the best move roll for the dice
+func (a Analyzer) analyzeTurn(b board.Board, dice board.DieRoll, after board.Board) Decision {
+	decision := Decision{
+		Color:  b.ColorToMove,
+		Dice:   dice,
+		Played: board.MoveRoll{},
+		Best:   board.MoveRoll{},
+		Luck:   a.Luck(b, dice),
+	}
+
+	results := b.GetValidMoveResultsForDieRoll(dice)
+	if len(results) == 0 {
+		decision.PlayedEquity = a.passEquity(b)
+		decision.BestEquity = decision.PlayedEquity
+		return decision
+	}
+
+	// The results keep the player to move
+	after.ColorToMove = b.ColorToMove
+	position := after.SerializeBoard()
+	positions := map[string]bool{}
+	for idx, result := range results {
+		serialized := result.Board.SerializeBoard()
+		if positions[serialized] {
+			continue
+		}
+		positions[serialized] = true
+		equity := ai.EvaluateMoveRoll(a.Evaluator, result).Equity()
+		if idx == 0 || equity > decision.BestEquity {
+			decision.Best, decision.BestEquity = result.MoveRoll, equity
+		}
+		if serialized == position {
+			decision.Played, decision.PlayedEquity = result.MoveRoll, equity
+		}
+	}
+	decision.Candidates = len(positions)
+	decision.PlayedNotation = decision.Played.Notation(b)
+	decision.BestNotation = decision.Best.Notation(b)
+	decision.EquityLoss = decision.BestEquity - decision.PlayedEquity
+	decision.Severity = Classify(decision.EquityLoss)
+	return decision
+}
+
+// Function that compares the cube actions played before the roll on the
+// given board with the proper cube actions: the double of the player to
+// move and the take or the drop of the opponent
+func (a Analyzer) analyzeCubeActions(b board.Board, c game.Cube, actions []game.CubeAction) ([]CubeDecision, error) {
+	if len(actions) == 0 {
+		return nil, nil
+	}
+	proper, err := cube.MoneyDecision(a.Evaluator.Evaluate(b), c, b.ColorToMove, cube.DEFAULT_CUBE_EFFICIENCY)
+	if err != nil {
+		return nil, err
+	}
+
+	// The doubler gets the equity of the best answer of the opponent
+	double := math.Min(proper.DoubleTake, proper.DoublePass)
+	doubler := CubeDecision{Color: b.ColorToMove, Played: game.CUBE_ACTION_DOUBLE, Proper: proper.Action, PlayedEquity: double}
+	if proper.NoDouble > double {
+		doubler.Best, doubler.BestEquity = CUBE_ACTION_NO_DOUBLE, proper.NoDouble
+	} else {
+		doubler.Best, doubler.BestEquity = game.CUBE_ACTION_DOUBLE, double
+	}
+	decisions := []CubeDecision{doubler}
+
+	if len(actions) > 1 {
+		taker := CubeDecision{Color: board.Color(1 - b.ColorToMove), Played: actions[1], Proper: proper.Action}
+		take, drop := -proper.DoubleTake, -proper.DoublePass
+		taker.PlayedEquity = drop
+		if actions[1] == game.CUBE_ACTION_TAKE {
+			taker.PlayedEquity = take
+		}
+		if proper.Take {
+			taker.Best, taker.BestEquity = game.CUBE_ACTION_TAKE, take
+		} else {
+			taker.Best, taker.BestEquity = game.CUBE_ACTION_DROP, drop
+		}
+		decisions = append(decisions, taker)
+	}
+	for idx := range decisions {
+		decisions[idx].EquityLoss = decisions[idx].BestEquity - decisions[idx].PlayedEquity
+		decisions[idx].Severity = Classify(decisions[idx].EquityLoss)
+	}
+	return decisions, nil
+}
+
+// Function that measures the luck of a roll: the equity after the best
+// move roll for the dice minus the mean of the same equity over all rolls
+func (a Analyzer) Luck(b board.Board, dice board.DieRoll) float64 {
+	average := 0.0
+	for d1 := 1; d1 <= 6; d1++ {
+		// The order of the dice does not matter, d1-d2 is as likely as d2-d1
+		average += a.rollEquity(b, board.DieRoll{Die1: d1, Die2: d1}) / 36
+		for d2 := d1 + 1; d2 <= 6; d2++ {
+			average += 2 * a.rollEquity(b, board.DieRoll{Die1: d1, Die2: d2}) / 36
+		}
+	}
+	return a.rollEquity(b, dice) - average
+}
+
+// Function that returns the equity of the best move roll for the dice
+func (a Analyzer) rollEquity(b board.Board, dice board.DieRoll) float64 {
+	results := b.GetValidMoveResultsForDieRoll(dice)
+	if len(results) == 0 {
+		return a.passEquity(b)
+	}
+	best := 0.0
+	for idx, result := range results {
+		equity := ai.EvaluateMoveRoll(a.Evaluator, result).Equity()
+		if idx == 0 || equity > best {
+			best = equity
+		}
+	}
+	return best
+}
+
+// Function that returns the equity of a player who cannot move
+func (a Analyzer) passEquity(b board.Board) float64 {
+	next := b
+	next.ColorToMove = board.Color(1 - b.ColorToMove)
+	return -a.Evaluator.Evaluate(next).Equity()
+}
+
+func (s *PlayerStats) add(decision Decision) {
+	s.Rolls += 1
+	s.Luck += decision.Luck
+	if decision.Forced() {
+		return
+	}
+	s.Decisions += 1
+	s.EquityLoss += decision.EquityLoss
+	switch decision.Severity {
+	case SEVERITY_INACCURACY:
+		s.Inaccuracies += 1
+	case SEVERITY_ERROR:
+		s.Errors += 1
+	case SEVERITY_BLUNDER:
+		s.Blunders += 1
+	}
+}
+
+func (s *PlayerStats) addCube(decision CubeDecision) {
+	s.CubeDecisions += 1
+	s.CubeEquityLoss += decision.EquityLoss
+	if decision.Severity != SEVERITY_NONE {
+		s.CubeErrors += 1
+	}
+}
+
+func (s *PlayerStats) finish() {
+	if s.Decisions == 0 {
+		return
+	}
+	mean := s.EquityLoss / float64(s.Decisions)
+	s.ErrorRate = 1000 * mean
+	s.PR = PR_FACTOR * mean
+}
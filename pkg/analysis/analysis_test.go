@@ -0,0 +1,188 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+// Equity lost for every blot left by a player
+const BLOT_COST = 0.03
+
+// Evaluator of the tests: the player to move gains BLOT_COST
+// for every blot of their opponent
+type blotEvaluator struct{}
+
+func (blotEvaluator) Evaluate(b board.Board) ai.Evaluation {
+	blots := 0
+	for idx := 0; idx < board.NUM_PLAYABLE_POINTS; idx++ {
+		point := b.Points[idx]
+		if point.CheckerCount == 1 && point.Checker.Color != b.ColorToMove {
+			blots += 1
+		}
+	}
+	return ai.Evaluation{Win: 0.5 + BLOT_COST*float64(blots)/2}
+}
+
+func makeClassifyTests() map[float64]Severity {
+	return map[float64]Severity{
+		0:     SEVERITY_NONE,
+		0.019: SEVERITY_NONE,
+		0.02:  SEVERITY_INACCURACY,
+		0.04:  SEVERITY_ERROR,
+		0.079: SEVERITY_ERROR,
+		0.08:  SEVERITY_BLUNDER,
+		0.5:   SEVERITY_BLUNDER,
+	}
+}
+
+func TestClassify(t *testing.T) {
+	for loss, expected := range makeClassifyTests() {
+		if output := Classify(loss); output != expected {
+			t.Errorf("Output %v not equal to expected %v for %v", output, expected, loss)
+		}
+	}
+}
+
+// Function that records the opening 3-1 of white, played without leaving
+// blots, and the 3-1 of black, played leaving two blots
+func makeAnalyzedRecord(t *testing.T) game.Record {
+	g := game.NewGame(board.NewBoard(board.COLOR_WHITE))
+	g.SetDice(board.DieRoll{Die1: 3, Die2: 1})
+	if err := g.Play(board.MoveRoll{{From: 7, To: 4, Type: board.NORMAL_MOVE}, {From: 5, To: 4, Type: board.NORMAL_MOVE}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	g.SetDice(board.DieRoll{Die1: 3, Die2: 1})
+	if err := g.Play(board.MoveRoll{{From: 0, To: 1, Type: board.NORMAL_MOVE}, {From: 0, To: 3, Type: board.NORMAL_MOVE}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return g.Record(game.Players{White: "alice", Black: "bob"})
+}
+
+func TestAnalyze(t *testing.T) {
+	// ARRANGE
+	analyzer := Analyzer{Evaluator: blotEvaluator{}}
+
+	// ACT
+	report, err := analyzer.Analyze(makeAnalyzedRecord(t))
+
+	// ASSERT
+	if err != nil || len(report.Decisions) != 2 {
+		t.Fatalf("Unexpected report %v %v", report, err)
+	}
+	white, black := report.Decisions[0], report.Decisions[1]
+	if white.EquityLoss != 0 || white.Severity != SEVERITY_NONE || white.PlayedNotation != "8/5 6/5" {
+		t.Errorf("Unexpected decision %v", white)
+	}
+	if math.Abs(black.EquityLoss-2*BLOT_COST) > 1e-9 || black.Severity != SEVERITY_ERROR || black.PlayedNotation != "24/23 24/21" {
+		t.Errorf("Unexpected decision %v", black)
+	}
+	if report.Black.Errors != 1 || math.Abs(report.Black.PR-PR_FACTOR*2*BLOT_COST) > 1e-9 || report.White.PR != 0 {
+		t.Errorf("Unexpected stats %v %v", report.White, report.Black)
+	}
+}
+
+func TestLuckOfAverageRollIsZero(t *testing.T) {
+	// ARRANGE
+	analyzer := Analyzer{Evaluator: blotEvaluator{}}
+	b := board.DeserializeBoard("2-2/5-3/9-1/14-2:11-1/19-4/21-3/24-2 0 0 w")
+
+	// ACT
+	total := 0.0
+	for d1 := 1; d1 <= 6; d1++ {
+		for d2 := 1; d2 <= 6; d2++ {
+			total += analyzer.Luck(b, board.DieRoll{Die1: d1, Die2: d2})
+		}
+	}
+
+	// ASSERT
+	if math.Abs(total) > 1e-9 {
+		t.Errorf("Output %v not equal to expected %v", total, 0)
+	}
+}
+
+func TestReport(t *testing.T) {
+	// ARRANGE
+	report, _ := Analyzer{Evaluator: blotEvaluator{}}.Analyze(makeAnalyzedRecord(t))
+	text, data := bytes.Buffer{}, bytes.Buffer{}
+
+	// ACT
+	textErr := report.WriteText(&text)
+	jsonErr := report.WriteJSON(&data)
+	decoded := Report{}
+	decodeErr := json.Unmarshal(data.Bytes(), &decoded)
+
+	// ASSERT
+	if textErr != nil || jsonErr != nil || decodeErr != nil {
+		t.Fatalf("Unexpected errors %v %v %v", textErr, jsonErr, decodeErr)
+	}
+	if !strings.Contains(text.String(), "24/21") || !strings.Contains(text.String(), "0.060 error") || !strings.Contains(text.String(), "Cube decisions") {
+		t.Errorf("Unexpected text report %v", text.String())
+	}
+	if decoded.Black != report.Black || decoded.Decisions[1].Severity != SEVERITY_ERROR {
+		t.Errorf("Output %v not equal to expected %v", decoded.Black, report.Black)
+	}
+}
+
+type cubeAnalysisTest struct {
+	answer            game.CubeAction
+	expectedTakerBest game.CubeAction
+	expectedSeverity  Severity
+}
+
+func makeCubeAnalysisTests() []cubeAnalysisTest {
+	return []cubeAnalysisTest{
+		// test 1 - a take of a premature double
+		{game.CUBE_ACTION_TAKE, game.CUBE_ACTION_TAKE, SEVERITY_NONE},
+		// test 2 - a drop of a premature double
+		{game.CUBE_ACTION_DROP, game.CUBE_ACTION_TAKE, SEVERITY_BLUNDER},
+	}
+}
+
+func TestAnalyzeCubeActions(t *testing.T) {
+	for idx, test := range makeCubeAnalysisTests() {
+		// ARRANGE
+		// White doubles in the starting position, where the chances are even
+		g := game.NewGame(board.NewBoard(board.COLOR_WHITE))
+		if err := g.Double(); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		answer := g.Take
+		if test.answer == game.CUBE_ACTION_DROP {
+			answer = g.Drop
+		}
+		if err := answer(); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if !g.IsOver() {
+			g.SetDice(board.DieRoll{Die1: 3, Die2: 1})
+			if err := g.Play(board.MoveRoll{{From: 7, To: 4, Type: board.NORMAL_MOVE}, {From: 5, To: 4, Type: board.NORMAL_MOVE}}); err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+		}
+
+		// ACT
+		report, err := Analyzer{Evaluator: blotEvaluator{}}.Analyze(g.Record(game.Players{}))
+
+		// ASSERT
+		if err != nil || len(report.CubeDecisions) != 2 {
+			t.Fatalf("Test %d: unexpected report %v %v", idx+1, report, err)
+		}
+		doubler, taker := report.CubeDecisions[0], report.CubeDecisions[1]
+		if doubler.Color != board.COLOR_WHITE || doubler.Best != CUBE_ACTION_NO_DOUBLE || doubler.EquityLoss <= 0 {
+			t.Errorf("Test %d: unexpected decision %v", idx+1, doubler)
+		}
+		if taker.Color != board.COLOR_BLACK || taker.Played != test.answer || taker.Best != test.expectedTakerBest || taker.Severity != test.expectedSeverity {
+			t.Errorf("Test %d: output %v not equal to expected %v %v", idx+1, taker, test.expectedTakerBest, test.expectedSeverity)
+		}
+		if report.Black.CubeDecisions != 1 || report.Black.CubeEquityLoss != taker.EquityLoss || report.White.CubeErrors != 1 {
+			t.Errorf("Test %d: unexpected stats %v %v", idx+1, report.White, report.Black)
+		}
+	}
+}
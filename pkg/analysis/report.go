@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+// Function that writes the report as indented JSON
+func (r Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// Function that writes the report as text: the flagged checker plays and
+// cube actions followed by the statistics of both players
+func (r Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s (white) vs %s (black)\n\n", playerName(r.Players.White, "white"), playerName(r.Players.Black, "black"))
+
+	fmt.Fprintln(tw, "Turn\tPlayer\tDice\tPlayed\tBest\tLoss\tLuck\t")
+	for _, decision := range r.Decisions {
+		if decision.Severity == SEVERITY_NONE {
+			continue
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d%d\t%s\t%s\t%.3f %s\t%+.3f\t\n",
+			decision.Turn, colorName(decision.Color), decision.Dice.Die1, decision.Dice.Die2,
+			decision.PlayedNotation, decision.BestNotation, decision.EquityLoss, decision.Severity, decision.Luck)
+	}
+
+	flagged := false
+	for _, decision := range r.CubeDecisions {
+		if decision.Severity == SEVERITY_NONE {
+			continue
+		}
+		if !flagged {
+			fmt.Fprintln(tw, "\nTurn\tPlayer\tCube action\tBest\tLoss\t")
+			flagged = true
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%.3f %s\t\n",
+			decision.Turn, colorName(decision.Color), decision.Played, decision.Best, decision.EquityLoss, decision.Severity)
+	}
+
+	fmt.Fprintf(tw, "\n\tWhite\tBlack\t\n")
+	rows := []struct {
+		name   string
+		format string
+		value  func(s PlayerStats) interface{}
+	}{
+		{"Decisions", "%d", func(s PlayerStats) interface{} { return s.Decisions }},
+		{"Inaccuracies", "%d", func(s PlayerStats) interface{} { return s.Inaccuracies }},
+		{"Errors", "%d", func(s PlayerStats) interface{} { return s.Errors }},
+		{"Blunders", "%d", func(s PlayerStats) interface{} { return s.Blunders }},
+		{"Equity loss", "%.3f", func(s PlayerStats) interface{} { return s.EquityLoss }},
+		{"Error rate (mEMG)", "%.1f", func(s PlayerStats) interface{} { return s.ErrorRate }},
+		{"PR", "%.1f", func(s PlayerStats) interface{} { return s.PR }},
+		{"Luck", "%+.3f", func(s PlayerStats) interface{} { return s.Luck }},
+		{"Cube decisions", "%d", func(s PlayerStats) interface{} { return s.CubeDecisions }},
+		{"Cube errors", "%d", func(s PlayerStats) interface{} { return s.CubeErrors }},
+		{"Cube equity loss", "%.3f", func(s PlayerStats) interface{} { return s.CubeEquityLoss }},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s\t"+row.format+"\t"+row.format+"\t\n", row.name, row.value(r.White), row.value(r.Black))
+	}
+	return tw.Flush()
+}
+
+func playerName(name string, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+func colorName(color board.Color) string {
+	name, _ := color.MarshalText()
+	return string(name)
+}
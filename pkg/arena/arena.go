@@ -0,0 +1,235 @@
+// Package arena plays games and matches between two AIs to compare their
+// strength. Games are played in pairs with mirrored dice: both games of a
+// pair roll the same dice sequence with the sides swapped, so the luck of
+// the dice mostly cancels out
+package arena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/cube"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/met"
+)
+
+var (
+	ErrNoGames     = errors.New("the number of games must be positive")
+	ErrCubeInMatch = errors.New("the cube is only played in single games")
+)
+
+type Player struct {
+	Name string
+	// AI of the player, used by several goroutines at once if the
+	// games are played in parallel
+	AI ai.AI
+}
+
+type Options struct {
+	// Number of games, or matches if MatchLength is positive. An odd
+	// number is rounded up, games are played in mirrored pairs
+	Games int
+	// Length of the matches, 0 to play single games. The matches are
+	// played with the Crawford rule and the AIs play for the match winning
+	// chances of the built-in match equity table, see met.Builtin
+	MatchLength int
+	// Seed of the dice, the pair of games i rolls the dice seeded with Seed + i
+	Seed int64
+	// Number of games played at the same time, at least 1
+	Parallel int
+	// Time control of the games, nil for untimed games. An AI that runs
+	// out of time loses by the timeout rule of the time control
+	TimeControl *game.TimeControl
+	// Evaluator of the cube decisions of money games, the games are played
+	// without the cube if nil. Before rolling, the players double and take
+	// as the money cube decision of the evaluator says, AIs implementing
+	// cube.Player decide from it
+	CubeEvaluator ai.Evaluator
+}
+
+// Result of a game from the perspective of the first player
+type GameResult struct {
+	Won bool
+	// 1 for a single game, 2 for a gammon and 3 for a backgammon
+	Value int
+	// Value of the cube at the end of the game, 1 without the cube
+	Cube int
+	// Number of move rolls played by both players
+	Turns int
+	// The loser ran out of time
+	TimedOut bool
+}
+
+// Function that returns the points won by the first player, negative if
+// the game was lost
+func (r GameResult) Points() int {
+	if r.Won {
+		return r.Value * r.Cube
+	}
+	return -r.Value * r.Cube
+}
+
+// Games of a single game or of a match, from the perspective of the first player
+type unit struct {
+	games []GameResult
+	// The first player won the match, or the single game
+	won bool
+}
+
+// Function that plays the games or matches between the two players and
+// returns the statistics from the perspective of the first player. The
+// results do not depend on the number of games played in parallel
+func Run(first Player, second Player, options Options) (Report, error) {
+	if options.Games < 1 {
+		return Report{}, ErrNoGames
+	}
+	if options.CubeEvaluator != nil && options.MatchLength > 0 {
+		return Report{}, ErrCubeInMatch
+	}
+	if options.MatchLength > 0 {
+		if _, err := met.NewMatch(options.MatchLength, nil); err != nil {
+			return Report{}, err
+		}
+	}
+	parallel := options.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	pairs := (options.Games + 1) / 2
+	units := make([][2]unit, pairs)
+	errs := make([]error, pairs)
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < parallel; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pair := range jobs {
+				seed := options.Seed + int64(pair)
+				for swapped := 0; swapped < 2 && errs[pair] == nil; swapped++ {
+					units[pair][swapped], errs[pair] = playUnit(first, second, swapped == 1, seed, options)
+				}
+			}
+		}()
+	}
+	for pair := 0; pair < pairs; pair++ {
+		jobs <- pair
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return Report{}, err
+		}
+	}
+	return newReport(first, second, options, units), nil
+}
+
+// Function that plays a single game, or a match, with the dice seeded with
+// the given seed. The first player plays white unless the sides are swapped
+func playUnit(first Player, second Player, swapped bool, seed int64, options Options) (unit, error) {
+	players := [2]Player{first, second}
+	if swapped {
+		players = [2]Player{second, first}
+	}
+	dice := game.NewRandomDice(seed)
+	var match *met.Match
+	if options.MatchLength > 0 {
+		// The length was checked by Run
+		match, _ = met.NewMatch(options.MatchLength, nil)
+	}
+
+	result := unit{}
+	for {
+		winner, gameResult, err := playGame(players, dice, match, options)
+		if err != nil {
+			return unit{}, err
+		}
+		// The first player plays the color of index 0 unless the sides are swapped
+		gameResult.Won = (winner == board.COLOR_WHITE) != swapped
+		result.games = append(result.games, gameResult)
+		if match != nil {
+			match.AddResult(winner, gameResult.Value*gameResult.Cube)
+		}
+		if match == nil || match.IsOver() {
+			result.won = gameResult.Won
+			return result, nil
+		}
+	}
+}
+
+// Function that plays a game from the initial position between the white
+// and the black player, for the match if it is not nil. An illegal move
+// roll of an AI is an error
+func playGame(players [2]Player, dice game.Dice, match *met.Match, options Options) (board.Color, GameResult, error) {
+	color, openingRoll := game.OpeningRoll(dice)
+	g := game.NewGame(board.NewBoard(color))
+	if match != nil {
+		g = match.NewGame(board.NewBoard(color))
+	}
+	g.SetDice(openingRoll)
+	if options.TimeControl != nil {
+		g.SetTimeControl(*options.TimeControl, game.SystemClock{})
+	}
+
+	turns := 0
+	for !g.IsOver() {
+		if !g.Rolled {
+			if options.CubeEvaluator != nil {
+				if err := playCube(g, players, options.CubeEvaluator); err != nil {
+					return 0, GameResult{}, err
+				}
+				if g.IsOver() {
+					break
+				}
+			}
+			g.Roll(dice)
+		}
+		color := g.Board.ColorToMove
+		player := players[color]
+		_, err := g.PlayAI(context.Background(), game.AIPlayer{Color: color, AI: player.AI})
+		if err != nil && err != game.ErrTimeout {
+			return 0, GameResult{}, fmt.Errorf("%s: %w", player.Name, err)
+		}
+		turns += 1
+	}
+	return g.Result.Winner, GameResult{Value: g.Result.Points, Cube: g.Cube.Value, Turns: turns, TimedOut: g.Result.TimedOut}, nil
+}
+
+// Function that lets the player to move double before rolling if the cube
+// decision of the evaluator says so, and the opponent take or drop
+func playCube(g *game.Game, players [2]Player, evaluator ai.Evaluator) error {
+	color := g.Board.ColorToMove
+	decision, err := cube.MoneyDecision(evaluator.Evaluate(g.Board), g.Cube, color, cube.DEFAULT_CUBE_EFFICIENCY)
+	if err == game.ErrCannotDouble {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	double := decision.Double()
+	if cubePlayer, ok := players[color].AI.(cube.Player); ok {
+		double = cubePlayer.ShouldDouble(g.Board, decision)
+	}
+	if !double {
+		return nil
+	}
+	if err := g.Double(); err != nil {
+		return err
+	}
+	take := decision.Take
+	if cubePlayer, ok := players[1-color].AI.(cube.Player); ok {
+		take = cubePlayer.ShouldTake(g.Board, decision)
+	}
+	if take {
+		return g.Take()
+	}
+	return g.Drop()
+}
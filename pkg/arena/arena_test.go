@@ -0,0 +1,253 @@
+package arena
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/met"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/skill"
+)
+
+// AI that plays the first valid move roll
+type firstMoveAI struct{}
+
+func (firstMoveAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	results := b.GetValidMoveResultsForDieRoll(d)
+	if len(results) == 0 {
+		return board.MoveRoll{}
+	}
+	return results[0].MoveRoll
+}
+
+// AI that never moves, its move rolls are illegal
+type passAI struct{}
+
+func (passAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	return board.MoveRoll{}
+}
+
+// AI that plays the first valid move roll and records the scores of the
+// matches of its games
+type matchRecorderAI struct {
+	mutex  *sync.Mutex
+	scores *[]met.Match
+}
+
+func (a matchRecorderAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	return firstMoveAI{}.ChooseMove(b, d)
+}
+
+func (a matchRecorderAI) ChooseMoveContext(ctx context.Context, b board.Board, d board.DieRoll, progress ai.ProgressFunc) (board.MoveRoll, error) {
+	if match, _, ok := ai.MatchFromContext(ctx); ok {
+		a.mutex.Lock()
+		*a.scores = append(*a.scores, *match.(*met.Match))
+		a.mutex.Unlock()
+	}
+	return a.ChooseMove(b, d), nil
+}
+
+var heuristic = Player{Name: "heuristic", AI: ai.EvaluatorAI{Evaluator: ai.HeuristicEvaluator{}}}
+
+// The skill AI misses every cube action
+var missingCube = Player{Name: "missing", AI: skill.Wrap(heuristic.AI.(ai.EvaluatorAI), skill.Level{Name: "missing", CubeMissRate: 1}, 1)}
+
+func TestMirroredGames(t *testing.T) {
+	// ACT
+	report, err := Run(heuristic, heuristic, Options{Games: 3, Seed: 7, Parallel: 2})
+
+	// ASSERT
+	if err != nil || report.Games != 4 || report.Wins != [2]int{2, 2} {
+		t.Errorf("Output %v %v not equal to expected %v %v", report.Games, report.Wins, 4, [2]int{2, 2})
+	}
+	expected := Interval{}
+	if report.PointsPerGame != expected || report.WinRate != (Interval{0.5, 0.5, 0.5}) {
+		t.Errorf("Output %v not equal to expected %v", report.PointsPerGame, expected)
+	}
+}
+
+func TestRunDeterministic(t *testing.T) {
+	// ARRANGE
+	first := Player{Name: "first", AI: firstMoveAI{}}
+
+	// ACT
+	sequential, err := Run(first, heuristic, Options{Games: 4, MatchLength: 2, Seed: 3, Parallel: 1})
+	parallel, parallelErr := Run(first, heuristic, Options{Games: 4, MatchLength: 2, Seed: 3, Parallel: 4})
+
+	// ASSERT
+	if err != nil || parallelErr != nil || !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("Output %v not equal to expected %v", parallel, sequential)
+	}
+	if sequential.Matches != 4 || sequential.MatchWins[0]+sequential.MatchWins[1] != 4 || sequential.Games < 4 {
+		t.Errorf("Output %v %v not equal to expected %v", sequential.Matches, sequential.MatchWins, 4)
+	}
+}
+
+func TestRunMatchesCrawford(t *testing.T) {
+	// ARRANGE
+	scores := []met.Match{}
+	recorder := Player{Name: "recorder", AI: matchRecorderAI{&sync.Mutex{}, &scores}}
+
+	// ACT
+	report, err := Run(recorder, heuristic, Options{Games: 4, MatchLength: 3, Seed: 3, Parallel: 1})
+	_, tooLongErr := Run(recorder, heuristic, Options{Games: 2, MatchLength: met.BUILTIN_LENGTH + 1})
+
+	// ASSERT
+	if err != nil || report.Matches != 4 || len(scores) == 0 {
+		t.Fatalf("Unexpected report %v %v", report, err)
+	}
+	// The game after a player reaches 1-away is the Crawford game
+	crawford := false
+	for _, score := range scores {
+		oneAway := score.Away(board.COLOR_WHITE) == 1 || score.Away(board.COLOR_BLACK) == 1
+		if score.Length != 3 || score.Crawford && !oneAway || oneAway && !score.Crawford && !score.PostCrawford {
+			t.Errorf("Unexpected score %+v", score)
+		}
+		crawford = crawford || score.Crawford
+	}
+	if !crawford {
+		t.Errorf("Output %v not equal to expected %v", crawford, true)
+	}
+	if tooLongErr != met.ErrMatchTooLong {
+		t.Errorf("Output %v not equal to expected %v", tooLongErr, met.ErrMatchTooLong)
+	}
+}
+
+func TestRunRandomDeterministic(t *testing.T) {
+	// ARRANGE
+	random := Player{Name: "random", AI: ai.NewRandomAI(1)}
+
+	// ACT
+	sequential, err := Run(random, heuristic, Options{Games: 6, Seed: 9, Parallel: 1})
+	parallel, parallelErr := Run(random, heuristic, Options{Games: 6, Seed: 9, Parallel: 4})
+
+	// ASSERT
+	if err != nil || parallelErr != nil || !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("Output %v not equal to expected %v", parallel, sequential)
+	}
+}
+
+func TestRunCube(t *testing.T) {
+	// ARRANGE
+	options := Options{Games: 4, Seed: 5, Parallel: 1, CubeEvaluator: ai.HeuristicEvaluator{}}
+
+	// ACT
+	sequential, err := Run(missingCube, heuristic, options)
+	options.Parallel = 4
+	parallel, parallelErr := Run(missingCube, heuristic, options)
+
+	// ASSERT
+	if err != nil || parallelErr != nil || !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("Output %v not equal to expected %v", parallel, sequential)
+	}
+	if _, err := Run(heuristic, heuristic, Options{Games: 2, MatchLength: 3, CubeEvaluator: ai.HeuristicEvaluator{}}); err != ErrCubeInMatch {
+		t.Errorf("Output %v not equal to expected %v", err, ErrCubeInMatch)
+	}
+}
+
+type playCubeTest struct {
+	players        [2]Player
+	expectedResult *game.Result
+	expectedCube   int
+}
+
+func makePlayCubeTests() []playCubeTest {
+	return []playCubeTest{
+		// test 1 - proper cube actions
+		{[2]Player{heuristic, heuristic}, &game.Result{Winner: board.COLOR_WHITE, Points: 1, Dropped: true}, 1},
+		// test 2 - the doubler misses the double
+		{[2]Player{missingCube, heuristic}, nil, 1},
+		// test 3 - the taker misses the drop
+		{[2]Player{heuristic, missingCube}, nil, 2},
+	}
+}
+
+func TestPlayCube(t *testing.T) {
+	for idx, test := range makePlayCubeTests() {
+		// ARRANGE
+		// White is far ahead in a short race, black should drop a double
+		g := game.NewGame(board.DeserializeBoard("1-2/2-2:23-3/24-3 0 0 w"))
+
+		// ACT
+		err := playCube(g, test.players, ai.HeuristicEvaluator{})
+
+		// ASSERT
+		if err != nil || !reflect.DeepEqual(g.Result, test.expectedResult) || g.Cube.Value != test.expectedCube {
+			t.Errorf("Test %d: output %v %v %v not equal to expected %v %v", idx+1, g.Result, g.Cube.Value, err, test.expectedResult, test.expectedCube)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	// ACT
+	_, noGamesErr := Run(heuristic, heuristic, Options{})
+	_, illegalErr := Run(heuristic, Player{Name: "pass", AI: passAI{}}, Options{Games: 2})
+
+	// ASSERT
+	if noGamesErr != ErrNoGames {
+		t.Errorf("Output %v not equal to expected %v", noGamesErr, ErrNoGames)
+	}
+	if !errors.Is(illegalErr, game.ErrIllegalMove) || !strings.HasPrefix(illegalErr.Error(), "pass:") {
+		t.Errorf("Output %v not equal to expected %v", illegalErr, game.ErrIllegalMove)
+	}
+}
+
+type intervalTest struct {
+	samples  []float64
+	expected Interval
+}
+
+func makeIntervalTests() []intervalTest {
+	margin := CONFIDENCE_Z * math.Sqrt(2.0/3/4)
+	return []intervalTest{
+		// test 1 - a single sample
+		{[]float64{1}, Interval{1, 1, 1}},
+		// test 2 - equal samples
+		{[]float64{0.5, 0.5, 0.5}, Interval{0.5, 0.5, 0.5}},
+		// test 3 - the sample variance is 2/3
+		{[]float64{-1, 0, 0, 1}, Interval{0, -margin, margin}},
+	}
+}
+
+func TestInterval(t *testing.T) {
+	for idx, test := range makeIntervalTests() {
+		// ACT
+		output := interval(test.samples)
+
+		// ASSERT
+		if math.Abs(output.Mean-test.expected.Mean) > 1e-9 || math.Abs(output.Low-test.expected.Low) > 1e-9 ||
+			math.Abs(output.High-test.expected.High) > 1e-9 {
+			t.Errorf("Test %d: output %v not equal to expected %v", idx+1, output, test.expected)
+		}
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	// ARRANGE
+	report := Report{
+		Players:       [2]string{"a", "b"},
+		Games:         10,
+		Wins:          [2]int{6, 4},
+		PointsPerGame: Interval{0.2, -0.1, 0.5},
+		WinRate:       Interval{0.6, 0.3, 0.9},
+	}
+	out := &bytes.Buffer{}
+
+	// ACT
+	err := report.WriteText(out)
+
+	// ASSERT
+	for _, expected := range []string{"a vs b, 10 games", "Points per game  +0.200  [-0.100, +0.500]", "Game win rate    60.0%"} {
+		if err != nil || !strings.Contains(out.String(), expected) {
+			t.Errorf("Output %v not containing expected %v", out.String(), expected)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package arena
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+)
+
+// Quantile of the normal distribution for 95% confidence intervals
+const CONFIDENCE_Z = 1.96
+
+// Estimate with its 95% confidence interval
+type Interval struct {
+	Mean float64
+	Low  float64
+	High float64
+}
+
+// Statistics of the games played between two players, from the
+// perspective of the first player. Counts are indexed by player
+type Report struct {
+	Players [2]string
+	// 0 for single games
+	MatchLength int
+	Games       int
+	Matches     int
+	Wins        [2]int
+	// Games won with a gammon, backgammons included
+	Gammons     [2]int
+	Backgammons [2]int
+	// Games lost by running out of time
+	Timeouts  [2]int
+	MatchWins [2]int
+	// Fraction of the games won with a gammon or a backgammon
+	GammonRates [2]float64
+	// Average number of move rolls played in a game
+	AverageTurns  float64
+	PointsPerGame Interval
+	WinRate       Interval
+	// Only meaningful if MatchLength is positive
+	MatchWinRate Interval
+}
+
+// Function that computes the report of the pairs of mirrored games or matches.
+// The confidence intervals treat every pair as one sample, since the two
+// games of a pair are not independent
+func newReport(first Player, second Player, options Options, units [][2]unit) Report {
+	r := Report{Players: [2]string{first.Name, second.Name}, MatchLength: options.MatchLength}
+	points, wins, matchWins := []float64{}, []float64{}, []float64{}
+	turns := 0
+	for _, pair := range units {
+		pairPoints, pairWins, pairMatchWins, pairGames := 0, 0, 0, 0
+		for _, u := range pair {
+			if u.won {
+				pairMatchWins += 1
+			}
+			for _, result := range u.games {
+				player := 1
+				if result.Won {
+					player = 0
+					pairWins += 1
+				}
+				r.Wins[player] += 1
+				if result.Value >= 2 {
+					r.Gammons[player] += 1
+				}
+				if result.Value == 3 {
+					r.Backgammons[player] += 1
+				}
+				if result.TimedOut {
+					r.Timeouts[1-player] += 1
+				}
+				pairPoints += result.Points()
+				pairGames += 1
+				turns += result.Turns
+			}
+		}
+		r.Games += pairGames
+		points = append(points, float64(pairPoints)/float64(pairGames))
+		wins = append(wins, float64(pairWins)/float64(pairGames))
+		matchWins = append(matchWins, float64(pairMatchWins)/2)
+		r.MatchWins[0] += pairMatchWins
+		r.MatchWins[1] += 2 - pairMatchWins
+	}
+
+	if options.MatchLength > 0 {
+		r.Matches = 2 * len(units)
+		r.MatchWinRate = rateInterval(matchWins)
+	} else {
+		// Single games are not matches
+		r.MatchWins = [2]int{}
+	}
+	for player := range r.Wins {
+		r.GammonRates[player] = float64(r.Gammons[player]) / float64(r.Games)
+	}
+	r.AverageTurns = float64(turns) / float64(r.Games)
+	r.PointsPerGame = interval(points)
+	r.WinRate = rateInterval(wins)
+	return r
+}
+
+// Function that returns the mean of the samples with its confidence interval
+func interval(samples []float64) Interval {
+	n := float64(len(samples))
+	mean := 0.0
+	for _, sample := range samples {
+		mean += sample
+	}
+	mean /= n
+	if len(samples) < 2 {
+		return Interval{mean, mean, mean}
+	}
+
+	variance := 0.0
+	for _, sample := range samples {
+		variance += (sample - mean) * (sample - mean)
+	}
+	variance /= n - 1
+	margin := CONFIDENCE_Z * math.Sqrt(variance/n)
+	return Interval{mean, mean - margin, mean + margin}
+}
+
+// Function that returns the interval of a rate, clamped between 0 and 1
+func rateInterval(samples []float64) Interval {
+	i := interval(samples)
+	i.Low, i.High = math.Max(i.Low, 0), math.Min(i.High, 1)
+	return i
+}
+
+// Function that writes the report as indented JSON
+func (r Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// Function that writes the report as text, a table of the statistics of
+// both players followed by the estimates of the first player
+func (r Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if r.MatchLength > 0 {
+		fmt.Fprintf(tw, "%s vs %s, %d matches to %d points, %d games\n\n", r.Players[0], r.Players[1], r.Matches, r.MatchLength, r.Games)
+	} else {
+		fmt.Fprintf(tw, "%s vs %s, %d games\n\n", r.Players[0], r.Players[1], r.Games)
+	}
+
+	fmt.Fprintf(tw, "\t%s\t%s\t\n", r.Players[0], r.Players[1])
+	if r.MatchLength > 0 {
+		fmt.Fprintf(tw, "Matches won\t%d\t%d\t\n", r.MatchWins[0], r.MatchWins[1])
+	}
+	fmt.Fprintf(tw, "Games won\t%d\t%d\t\n", r.Wins[0], r.Wins[1])
+	fmt.Fprintf(tw, "Gammons\t%d\t%d\t\n", r.Gammons[0], r.Gammons[1])
+	fmt.Fprintf(tw, "Backgammons\t%d\t%d\t\n", r.Backgammons[0], r.Backgammons[1])
+	fmt.Fprintf(tw, "Gammon rate\t%.1f%%\t%.1f%%\t\n", 100*r.GammonRates[0], 100*r.GammonRates[1])
+	if r.Timeouts != [2]int{} {
+		fmt.Fprintf(tw, "Timeouts\t%d\t%d\t\n", r.Timeouts[0], r.Timeouts[1])
+	}
+	fmt.Fprintf(tw, "\nAverage turns per game\t%.1f\t\n", r.AverageTurns)
+
+	fmt.Fprintf(tw, "\n%s\tMean\t95%% interval\t\n", r.Players[0])
+	fmt.Fprintf(tw, "Points per game\t%+.3f\t[%+.3f, %+.3f]\t\n", r.PointsPerGame.Mean, r.PointsPerGame.Low, r.PointsPerGame.High)
+	fmt.Fprintf(tw, "Game win rate\t%.1f%%\t[%.1f%%, %.1f%%]\t\n", 100*r.WinRate.Mean, 100*r.WinRate.Low, 100*r.WinRate.High)
+	if r.MatchLength > 0 {
+		fmt.Fprintf(tw, "Match win rate\t%.1f%%\t[%.1f%%, %.1f%%]\t\n", 100*r.MatchWinRate.Mean, 100*r.MatchWinRate.Low, 100*r.MatchWinRate.High)
+	}
+	return tw.Flush()
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/parallel"
 	"github.com/mitchellh/hashstructure/v2"
 )
 
@@ -90,9 +91,16 @@ func NewBoard(color Color) Board {
 	return Board{points, color}
 }
 
-// Function to hash a board
+// Function to hash a board, the color of the checker of an empty point is
+// ignored so equal positions have equal hashes however they were reached
 func (b Board) Hash() uint64 {
-	hash, err := hashstructure.Hash(b, hashstructure.FormatV2, nil)
+	canonical := b.CopyBoard()
+	for idx := range canonical.Points {
+		if canonical.Points[idx].CheckerCount == 0 {
+			canonical.Points[idx].Checker = Checker{}
+		}
+	}
+	hash, err := hashstructure.Hash(canonical, hashstructure.FormatV2, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -141,8 +149,70 @@ func (b Board) ComputeGameState() GameState {
 	return NORMAL_PLAY
 }
 
+// Function that returns the number of checkers the player with the
+// given color has borne off
+func (b Board) CheckersOff(color Color) int {
+	return INIT_NUM_CHECKERS - numCheckersOfColor(b, color)
+}
+
+// Function that returns the pip count of the player with the given color,
+// i.e. the total number of pips he needs to bear off all of his checkers
+func (b Board) PipCount(color Color) int {
+	pips := b.Points[barPointIndex(color)].CheckerCount * (NUM_PLAYABLE_POINTS + 1)
+	for idx := 0; idx < NUM_PLAYABLE_POINTS; idx++ {
+		if b.Points[idx].Checker.Color == color {
+			pips += b.Points[idx].CheckerCount * PointNumber(PointIndex(idx), color)
+		}
+	}
+	return pips
+}
+
+// Function that returns the number of points the player with the given
+// color wins, assuming he has borne off all of his checkers:
+//   - 1 for a single game, if the opponent has borne off at least one checker
+//   - 2 for a gammon, if the opponent has not borne off any checker
+//   - 3 for a backgammon, if the opponent has not borne off any checker and
+//     still has checkers on the bar or in the winner's home board
+func (b Board) WinValue(winner Color) int {
+	loser := Color(1 - winner)
+	if b.CheckersOff(loser) > 0 {
+		return 1
+	}
+	if b.Points[barPointIndex(loser)].CheckerCount > 0 {
+		return 3
+	}
+	for idx := 0; idx < NUM_PLAYABLE_POINTS; idx++ {
+		if b.Points[idx].Checker.Color == loser && b.Points[idx].CheckerCount > 0 &&
+			PointNumber(PointIndex(idx), winner) <= 6 {
+			return 3
+		}
+	}
+	return 2
+}
+
 func (b Board) GetValidMovesForDieRoll(d DieRoll) []MoveRoll {
-	return getPossibleMoves(b, d)
+	moveRolls, _ := getPossibleMoves(b, d, 1)
+	return moveRolls
+}
+
+// Function that returns the valid move rolls for a die roll together
+// with the board each of them leads to and some metadata about them
+// NOTE: the resulting boards keep the color to move of the initial board
+func (b Board) GetValidMoveResultsForDieRoll(d DieRoll) []MoveRollResult {
+	return b.GetValidMoveResultsParallel(d, 1)
+}
+
+// Function that returns the same move roll results as
+// GetValidMoveResultsForDieRoll, in the same order, generating them on
+// at most workers goroutines. It is worth it for doubles, whose move
+// rolls can have thousands of leaves
+func (b Board) GetValidMoveResultsParallel(d DieRoll, workers int) []MoveRollResult {
+	moveRolls, boards := getPossibleMoves(b, d, workers)
+	results := make([]MoveRollResult, len(moveRolls))
+	parallel.For(len(moveRolls), workers, func(idx int) {
+		results[idx] = newMoveRollResult(b, moveRolls[idx], boards[idx])
+	})
+	return results
 }
 
 func (b Board) GetValidMovesForDie(d int) []Move {
@@ -186,6 +256,61 @@ func (b Board) SerializeBoard() string {
 	return fmt.Sprintf("%s:%s %d %d %s", whiteString, blackString, b.Points[WHITE_PIECES_BAR_POINT_INDEX].CheckerCount, b.Points[BLACK_PIECES_BAR_POINT_INDEX].CheckerCount, colorToMove)
 }
 
+// Function that parses a serialized board string, see SerializeBoard,
+// returning an error if the string is malformed or describes an
+// impossible position instead of panicking like DeserializeBoard
+func ParseBoard(boardStr string) (Board, error) {
+	invalid := func(reason string) (Board, error) {
+		return Board{}, fmt.Errorf("invalid board %q: %s", boardStr, reason)
+	}
+
+	fields := strings.Fields(boardStr)
+	if len(fields) != 4 {
+		return invalid("expected 4 space separated fields")
+	}
+	sides := strings.Split(fields[0], ":")
+	if len(sides) != 2 {
+		return invalid("expected white and black checkers separated by ':'")
+	}
+	if fields[3] != "w" && fields[3] != "b" {
+		return invalid("turn must be 'w' or 'b'")
+	}
+
+	occupied := map[int]bool{}
+	for sideIdx, side := range sides {
+		numCheckers, err := strconv.Atoi(fields[1+sideIdx])
+		if err != nil || numCheckers < 0 {
+			return invalid("invalid number of checkers on the bar")
+		}
+		if side == "" {
+			continue
+		}
+		for _, group := range strings.Split(side, "/") {
+			pointSplit := strings.Split(group, "-")
+			if len(pointSplit) != 2 {
+				return invalid(fmt.Sprintf("malformed group %q", group))
+			}
+			pointNumber, err := strconv.Atoi(pointSplit[0])
+			if err != nil || pointNumber < 1 || pointNumber > NUM_PLAYABLE_POINTS {
+				return invalid(fmt.Sprintf("invalid point in group %q", group))
+			}
+			count, err := strconv.Atoi(pointSplit[1])
+			if err != nil || count < 1 {
+				return invalid(fmt.Sprintf("invalid number of checkers in group %q", group))
+			}
+			if occupied[pointNumber] {
+				return invalid(fmt.Sprintf("point %d is listed more than once", pointNumber))
+			}
+			occupied[pointNumber] = true
+			numCheckers += count
+		}
+		if numCheckers > INIT_NUM_CHECKERS {
+			return invalid(fmt.Sprintf("a player has more than %d checkers", INIT_NUM_CHECKERS))
+		}
+	}
+	return DeserializeBoard(strings.Join(fields, " ")), nil
+}
+
 /**
 * Function to deserialize a string board to a Board struct
 * @param boardStr - serialized string of a backgammon board
@@ -216,6 +341,10 @@ func DeserializeBoard(boardStr string) Board {
 	}
 
 	for _, split := range strings.Split(whiteStr, "/") {
+		// A player might have no checkers left on the points
+		if split == "" {
+			continue
+		}
 		pointSplit := strings.Split(split, "-")
 		pointIdx, _ := strconv.Atoi(pointSplit[0])
 		numCheckers, _ := strconv.Atoi(pointSplit[1])
@@ -224,6 +353,9 @@ func DeserializeBoard(boardStr string) Board {
 	}
 
 	for _, split := range strings.Split(blackStr, "/") {
+		if split == "" {
+			continue
+		}
 		pointSplit := strings.Split(split, "-")
 		pointIdx, _ := strconv.Atoi(pointSplit[0])
 		numCheckers, _ := strconv.Atoi(pointSplit[1])
@@ -308,6 +440,23 @@ func numCheckersOfColor(b Board, color Color) int {
 	return s
 }
 
+func barPointIndex(color Color) PointIndex {
+	if color == COLOR_WHITE {
+		return WHITE_PIECES_BAR_POINT_INDEX
+	}
+	return BLACK_PIECES_BAR_POINT_INDEX
+}
+
+// Function that returns for every playable point whether the player
+// with the given color made it, i.e. has at least 2 checkers on it
+func madePoints(b Board, color Color) []bool {
+	made := make([]bool, NUM_PLAYABLE_POINTS)
+	for idx := 0; idx < NUM_PLAYABLE_POINTS; idx++ {
+		made[idx] = b.Points[idx].Checker.Color == color && b.Points[idx].CheckerCount > 1
+	}
+	return made
+}
+
 func numCheckersInHome(b Board, color Color) int {
 	s := 0
 	if color == COLOR_WHITE {
@@ -326,9 +475,60 @@ func numCheckersInHome(b Board, color Color) int {
 	return s
 }
 
-func getPossibleMoves(b Board, d DieRoll) []MoveRoll {
-	moveRolls := []MoveRoll{}
-	seenBoards := map[uint64]bool{}
+// Collects the move rolls found by the move generator, keeping only the
+// first move roll leading to each distinct resulting board
+type moveRollCollector struct {
+	moveRolls []MoveRoll
+	boards    []Board
+	// The boards are told apart by their serialization, which is exact and
+	// an order of magnitude faster to compute than Hash
+	seenBoards map[string]bool
+}
+
+func newMoveRollCollector() *moveRollCollector {
+	return &moveRollCollector{[]MoveRoll{}, []Board{}, map[string]bool{}}
+}
+
+func (c *moveRollCollector) add(mvRoll MoveRoll, resultBoard Board) {
+	position := resultBoard.SerializeBoard()
+	if _, ok := c.seenBoards[position]; !ok {
+		c.moveRolls = append(c.moveRolls, mvRoll)
+		c.boards = append(c.boards, resultBoard)
+		c.seenBoards[position] = true
+	}
+}
+
+func (c *moveRollCollector) addLeaves(leaves []moveLeaf) {
+	for _, leaf := range leaves {
+		c.add(leaf.moveRoll, leaf.board)
+	}
+}
+
+func (c *moveRollCollector) isEmpty() bool {
+	return len(c.moveRolls) == 0
+}
+
+// A move roll found by the move generator with the board it leads to
+type moveLeaf struct {
+	moveRoll MoveRoll
+	board    Board
+}
+
+// Move rolls of a double starting with the same two moves: the move rolls
+// using the four dice, and the shorter move rolls the generator falls back to
+type doublesBranch struct {
+	fours  []moveLeaf
+	threes []moveLeaf
+	two    moveLeaf
+}
+
+// Function that generates all the distinct move rolls for a die roll
+// together with the boards they lead to. The move rolls starting with
+// each first move are generated on at most workers goroutines, then
+// collected in the order of the first moves, so the result does not
+// depend on the number of workers
+func getPossibleMoves(b Board, d DieRoll, workers int) ([]MoveRoll, []Board) {
+	collector := newMoveRollCollector()
 
 	if d.Die1 != d.Die2 {
 		// Try the bigger die first
@@ -337,119 +537,99 @@ func getPossibleMoves(b Board, d DieRoll) []MoveRoll {
 		}
 
 		d1Moves := getMovesWithOneDie(b, d.Die1)
-		for idx := 0; idx < len(d1Moves); idx++ {
-			currMove := d1Moves[idx]
-			mv1Board := currMove.MakeMove(b)
-			d2Moves := getMovesWithOneDie(mv1Board, d.Die2)
-			for jdx := 0; jdx < len(d2Moves); jdx++ {
-				mvRollToAdd := MoveRoll{currMove, d2Moves[jdx]}
-				mv2Board := d2Moves[jdx].MakeMove(mv1Board)
-				mv2BoardHash := mv2Board.Hash()
-				if _, ok := seenBoards[mv2BoardHash]; !ok {
-					moveRolls = append(moveRolls, mvRollToAdd)
-					seenBoards[mv2BoardHash] = true
-				}
-			}
-		}
-
 		d1MovesRev := getMovesWithOneDie(b, d.Die2)
-		for idx := 0; idx < len(d1MovesRev); idx++ {
-			currMove := d1MovesRev[idx]
+		firstMoves := append(append([]Move{}, d1Moves...), d1MovesRev...)
+		branches := make([][]moveLeaf, len(firstMoves))
+		parallel.For(len(firstMoves), workers, func(idx int) {
+			secondDie := d.Die2
+			if idx >= len(d1Moves) {
+				secondDie = d.Die1
+			}
+			currMove := firstMoves[idx]
 			mv1Board := currMove.MakeMove(b)
-			d2MovesRev := getMovesWithOneDie(mv1Board, d.Die1)
-			for jdx := 0; jdx < len(d2MovesRev); jdx++ {
-				mvRollToAdd := MoveRoll{currMove, d2MovesRev[jdx]}
-				mv2Board := d2MovesRev[jdx].MakeMove(mv1Board)
-				mv2BoardHash := mv2Board.Hash()
-				if _, ok := seenBoards[mv2BoardHash]; !ok {
-					moveRolls = append(moveRolls, mvRollToAdd)
-					seenBoards[mv2BoardHash] = true
-				}
+			d2Moves := getMovesWithOneDie(mv1Board, secondDie)
+			for jdx := 0; jdx < len(d2Moves); jdx++ {
+				branches[idx] = append(branches[idx], moveLeaf{MoveRoll{currMove, d2Moves[jdx]}, d2Moves[jdx].MakeMove(mv1Board)})
 			}
+		})
+		for _, branch := range branches {
+			collector.addLeaves(branch)
 		}
 
 		// If thereare no possible moves with 2 die, take only possible moves with 1 dice
-		if len(moveRolls) == 0 {
+		if collector.isEmpty() {
 			for idx := 0; idx < len(d1Moves); idx++ {
-				currMove := d1Moves[idx]
-				boardHash := currMove.MakeMove(b).Hash()
-				if _, ok := seenBoards[boardHash]; !ok {
-					moveRolls = append(moveRolls, MoveRoll{currMove})
-					seenBoards[boardHash] = true
-				}
+				collector.add(MoveRoll{d1Moves[idx]}, d1Moves[idx].MakeMove(b))
 			}
 		}
 
-		if len(moveRolls) == 0 {
+		if collector.isEmpty() {
 			for idx := 0; idx < len(d1MovesRev); idx++ {
-				currMove := d1MovesRev[idx]
-				boardHash := currMove.MakeMove(b).Hash()
-				if _, ok := seenBoards[boardHash]; !ok {
-					moveRolls = append(moveRolls, MoveRoll{currMove})
-					seenBoards[boardHash] = true
-				}
+				collector.add(MoveRoll{d1MovesRev[idx]}, d1MovesRev[idx].MakeMove(b))
 			}
 		}
 	} else {
 		d1Moves := getMovesWithOneDie(b, d.Die1)
-		for idx := 0; idx < len(d1Moves); idx++ {
-			currd1Move := d1Moves[idx]
-			move1Board := currd1Move.MakeMove(b)
-			d2Moves := getMovesWithOneDie(move1Board, d.Die1)
-			for jdx := 0; jdx < len(d2Moves); jdx++ {
-				currd2Move := d2Moves[jdx]
-				move2Board := currd2Move.MakeMove(move1Board)
-				d3Moves := getMovesWithOneDie(move2Board, d.Die1)
-				for tdx := 0; tdx < len(d3Moves); tdx++ {
-					currd3Move := d3Moves[tdx]
-					move3Board := currd3Move.MakeMove(move2Board)
-					d4Moves := getMovesWithOneDie(move3Board, d.Die1)
-					for zdx := 0; zdx < len(d4Moves); zdx++ {
-						moveRollToAdd := MoveRoll{currd1Move, currd2Move, currd3Move, d4Moves[zdx]}
-						finalMoveBaordHash := d4Moves[zdx].MakeMove(move3Board).Hash()
-						if _, ok := seenBoards[finalMoveBaordHash]; !ok {
-							moveRolls = append(moveRolls, moveRollToAdd)
-							seenBoards[finalMoveBaordHash] = true
-						}
-					}
-				}
-				// If there are no moves with 4 die, trey with 3 die
-				if len(moveRolls) == 0 {
-					for tdx := 0; tdx < len(d3Moves); tdx++ {
-						moveRollToAdd := MoveRoll{currd1Move, currd2Move, d3Moves[tdx]}
-						finalMoveBaordHash := d3Moves[tdx].MakeMove(move2Board).Hash()
-						if _, ok := seenBoards[finalMoveBaordHash]; !ok {
-							moveRolls = append(moveRolls, moveRollToAdd)
-							seenBoards[finalMoveBaordHash] = true
-						}
-					}
+		branches := make([][]doublesBranch, len(d1Moves))
+		parallel.For(len(d1Moves), workers, func(idx int) {
+			branches[idx] = getDoublesBranches(b, d1Moves[idx], d.Die1)
+		})
+		// Only the move rolls playing the most dice are valid, whatever their
+		// first moves: the fallbacks are only tried if no branch plays more dice
+		for _, secondBranches := range branches {
+			for _, branch := range secondBranches {
+				collector.addLeaves(branch.fours)
+			}
+		}
+		// If there are no moves with 4 die, try with 3 die
+		if collector.isEmpty() {
+			for _, secondBranches := range branches {
+				for _, branch := range secondBranches {
+					collector.addLeaves(branch.threes)
 				}
 			}
-			// If there are no moves with 3 die, try with 2 die
-			if len(moveRolls) == 0 {
-				for jdx := 0; jdx < len(d2Moves); jdx++ {
-					moveRollToAdd := MoveRoll{currd1Move, d2Moves[jdx]}
-					finalMoveBaordHash := d2Moves[jdx].MakeMove(move1Board).Hash()
-					if _, ok := seenBoards[finalMoveBaordHash]; !ok {
-						moveRolls = append(moveRolls, moveRollToAdd)
-						seenBoards[finalMoveBaordHash] = true
-					}
+		}
+		// If there are no moves with 3 die, try with 2 die
+		if collector.isEmpty() {
+			for _, secondBranches := range branches {
+				for _, branch := range secondBranches {
+					collector.add(branch.two.moveRoll, branch.two.board)
 				}
 			}
 		}
 		// If there are no possible moves with 2 die, take only possible moves with 1 dice
-		if len(moveRolls) == 0 {
+		if collector.isEmpty() {
 			for idx := 0; idx < len(d1Moves); idx++ {
-				moveRollToAdd := MoveRoll{d1Moves[idx]}
-				finalMoveBaordHash := d1Moves[idx].MakeMove(b).Hash()
-				if _, ok := seenBoards[finalMoveBaordHash]; !ok {
-					moveRolls = append(moveRolls, moveRollToAdd)
-					seenBoards[finalMoveBaordHash] = true
-				}
+				collector.add(MoveRoll{d1Moves[idx]}, d1Moves[idx].MakeMove(b))
 			}
 		}
 	}
-	return moveRolls
+	return collector.moveRolls, collector.boards
+}
+
+// Function that generates the move rolls of a double starting with the
+// given first move, grouped by their second move
+func getDoublesBranches(b Board, currd1Move Move, dValue int) []doublesBranch {
+	move1Board := currd1Move.MakeMove(b)
+	d2Moves := getMovesWithOneDie(move1Board, dValue)
+	branches := make([]doublesBranch, len(d2Moves))
+	for jdx := 0; jdx < len(d2Moves); jdx++ {
+		currd2Move := d2Moves[jdx]
+		move2Board := currd2Move.MakeMove(move1Board)
+		branches[jdx].two = moveLeaf{MoveRoll{currd1Move, currd2Move}, move2Board}
+		d3Moves := getMovesWithOneDie(move2Board, dValue)
+		for tdx := 0; tdx < len(d3Moves); tdx++ {
+			currd3Move := d3Moves[tdx]
+			move3Board := currd3Move.MakeMove(move2Board)
+			branches[jdx].threes = append(branches[jdx].threes, moveLeaf{MoveRoll{currd1Move, currd2Move, currd3Move}, move3Board})
+			d4Moves := getMovesWithOneDie(move3Board, dValue)
+			for zdx := 0; zdx < len(d4Moves); zdx++ {
+				moveRollToAdd := MoveRoll{currd1Move, currd2Move, currd3Move, d4Moves[zdx]}
+				branches[jdx].fours = append(branches[jdx].fours, moveLeaf{moveRollToAdd, d4Moves[zdx].MakeMove(move3Board)})
+			}
+		}
+	}
+	return branches
 }
 
 func getMovesWithOneDie(b Board, dValue int) []Move {
@@ -510,13 +690,21 @@ func getMovesForCheckersOnBarState(b Board, dValue int) []Move {
 func getMovesForBearingOffState(b Board, dValue int) []Move {
 	movesMap := map[Move]bool{}
 	moves := []Move{}
+	// Moves are appended in a fixed order, so the generated move rolls do not
+	// depend on the iteration order of the map
+	addMove := func(move Move) {
+		if !movesMap[move] {
+			movesMap[move] = true
+			moves = append(moves, move)
+		}
+	}
 	// First go for normal moves that can be done during bear off
 	if b.ColorToMove == COLOR_WHITE {
 		for idx := 0; idx < 6; idx++ {
 			if b.ColorToMove == b.Points[idx].Checker.Color && b.Points[idx].CheckerCount > 0 {
 				destPointIndex := PointIndex(idx - dValue)
 				if isValidDestinationForChecker(b, destPointIndex) {
-					movesMap[Move{PointIndex(idx), destPointIndex, NORMAL_MOVE}] = true
+					addMove(Move{PointIndex(idx), destPointIndex, NORMAL_MOVE})
 				}
 			}
 		}
@@ -525,7 +713,7 @@ func getMovesForBearingOffState(b Board, dValue int) []Move {
 			if b.ColorToMove == b.Points[idx].Checker.Color && b.Points[idx].CheckerCount > 0 {
 				destPointIndex := PointIndex(idx + dValue)
 				if isValidDestinationForChecker(b, destPointIndex) {
-					movesMap[Move{PointIndex(idx), destPointIndex, NORMAL_MOVE}] = true
+					addMove(Move{PointIndex(idx), destPointIndex, NORMAL_MOVE})
 				}
 			}
 		}
@@ -542,12 +730,12 @@ func getMovesForBearingOffState(b Board, dValue int) []Move {
 			}
 		}
 		if dValue >= indexOfLastChecker+1 {
-			movesMap[Move{PointIndex(indexOfLastChecker), TO_INDEX_FOR_BEARING_OFF, BEARING_OFF_MOVE}] = true
+			addMove(Move{PointIndex(indexOfLastChecker), TO_INDEX_FOR_BEARING_OFF, BEARING_OFF_MOVE})
 		}
 		// Base case when player has checkers on the die position
 		// This can lead to duplicate moves, thus using a map
 		if b.Points[dValue-1].CheckerCount > 0 && b.Points[dValue-1].Checker.Color == b.ColorToMove {
-			movesMap[Move{PointIndex(dValue - 1), TO_INDEX_FOR_BEARING_OFF, BEARING_OFF_MOVE}] = true
+			addMove(Move{PointIndex(dValue - 1), TO_INDEX_FOR_BEARING_OFF, BEARING_OFF_MOVE})
 		}
 	} else {
 		indexOfLastChecker := 18
@@ -558,19 +746,16 @@ func getMovesForBearingOffState(b Board, dValue int) []Move {
 			}
 		}
 		if dValue >= NUM_PLAYABLE_POINTS-indexOfLastChecker {
-			movesMap[Move{PointIndex(indexOfLastChecker), TO_INDEX_FOR_BEARING_OFF, BEARING_OFF_MOVE}] = true
+			addMove(Move{PointIndex(indexOfLastChecker), TO_INDEX_FOR_BEARING_OFF, BEARING_OFF_MOVE})
 		}
 
 		// Base case when player has checkers on the die position
 		// This can lead to duplicate moves, thus using a map
 		if b.Points[NUM_PLAYABLE_POINTS-dValue].CheckerCount > 0 && b.Points[NUM_PLAYABLE_POINTS-dValue].Checker.Color == b.ColorToMove {
-			movesMap[Move{PointIndex(NUM_PLAYABLE_POINTS - dValue), TO_INDEX_FOR_BEARING_OFF, BEARING_OFF_MOVE}] = true
+			addMove(Move{PointIndex(NUM_PLAYABLE_POINTS - dValue), TO_INDEX_FOR_BEARING_OFF, BEARING_OFF_MOVE})
 		}
 	}
 
-	for move := range movesMap {
-		moves = append(moves, move)
-	}
 	return moves
 }
 
@@ -2,6 +2,7 @@ package board
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -110,8 +111,8 @@ func makeGetValidMovesFroMDieTests() []validMovesFromDieTest {
 		{Move{From: 0, To: 6, Type: NORMAL_MOVE}, Move{From: 16, To: 21, Type: NORMAL_MOVE}},
 		{Move{From: 11, To: 17, Type: NORMAL_MOVE}, Move{From: 11, To: 16, Type: NORMAL_MOVE}},
 		{Move{From: 11, To: 17, Type: NORMAL_MOVE}, Move{From: 16, To: 21, Type: NORMAL_MOVE}},
+		// 11/16 16/22 leads to the same position, it is not repeated
 		{Move{From: 11, To: 17, Type: NORMAL_MOVE}, Move{From: 17, To: 22, Type: NORMAL_MOVE}},
-		{Move{From: 11, To: 16, Type: NORMAL_MOVE}, Move{From: 16, To: 22, Type: NORMAL_MOVE}},
 		{Move{From: 16, To: 22, Type: NORMAL_MOVE}, Move{From: 16, To: 21, Type: NORMAL_MOVE}},
 	}
 
@@ -481,3 +482,149 @@ func makeGameStateTests() []gameStateTest {
 		{board4, expectedGameState4},
 	}
 }
+
+func TestGetValidMoveResultsForDieRoll(t *testing.T) {
+	// ARRANGE
+	board := NewBoard(COLOR_WHITE)
+	board.Points[18].CheckerCount -= 1
+	board.Points[20].CheckerCount = 1
+	board.Points[20].Checker.Color = COLOR_BLACK
+	dieRoll := DieRoll{3, 1}
+
+	// ACT
+	results := board.GetValidMoveResultsForDieRoll(dieRoll)
+
+	// ASSERT
+	if len(results) != len(board.GetValidMovesForDieRoll(dieRoll)) {
+		t.Fatalf("Output %d not equal to expected %d", len(results), len(board.GetValidMovesForDieRoll(dieRoll)))
+	}
+	foundHit := false
+	foundPoint := false
+	for _, result := range results {
+		if !result.Board.IsEqual(result.MoveRoll.MakeMoveRoll(board)) {
+			t.Errorf("Output %v not equal to expected %v", result.Board, result.MoveRoll.MakeMoveRoll(board))
+		}
+		if result.Notation != result.MoveRoll.Notation(board) {
+			t.Errorf("Output %q not equal to expected %q", result.Notation, result.MoveRoll.Notation(board))
+		}
+		switch result.Notation {
+		case "24/21* 21/20":
+			foundHit = true
+			if result.Hits != 1 || result.PointsMade != 0 || result.EntersFromBar || result.BorneOff != 0 {
+				t.Errorf("Unexpected metadata %+v", result)
+			}
+		case "8/5 6/5":
+			foundPoint = true
+			if result.Hits != 0 || result.PointsMade != 1 {
+				t.Errorf("Unexpected metadata %+v", result)
+			}
+		}
+	}
+	if !foundHit || !foundPoint {
+		t.Errorf("Expected move rolls not found in %v", results)
+	}
+
+	// ARRANGE
+	board1 := NewBoard(COLOR_BLACK)
+	board1.Points[0].CheckerCount -= 1
+	board1.Points[BLACK_PIECES_BAR_POINT_INDEX].CheckerCount = 1
+	board1 = DeserializeBoard(board1.SerializeBoard())
+
+	// ACT
+	results1 := board1.GetValidMoveResultsForDieRoll(DieRoll{4, 2})
+
+	// ASSERT
+	for _, result := range results1 {
+		if !result.EntersFromBar {
+			t.Errorf("Move roll %q should enter from the bar", result.Notation)
+		}
+	}
+}
+
+func TestParseBoard(t *testing.T) {
+	// ARRANGE
+	validBoards := []string{
+		"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w",
+		":19-14 0 0 b",
+		"1-13: 2 0 w",
+	}
+	invalidBoards := []string{
+		"",
+		"6-5/8-3 0 0 w",
+		"6-5:1-2 0 0 x",
+		"6-5:6-2 0 0 w",
+		"6-16:1-2 0 0 w",
+		"6-5:1-2 14 0 w",
+		"0-5:1-2 0 0 w",
+		"6-5/8:1-2 0 0 w",
+		"6-5:1-2 -1 0 w",
+	}
+
+	for _, boardStr := range validBoards {
+		// ACT
+		b, err := ParseBoard(boardStr)
+
+		// ASSERT
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+		} else if output := b.SerializeBoard(); output != boardStr {
+			t.Errorf("Output %q not equal to expected %q", output, boardStr)
+		}
+	}
+
+	for _, boardStr := range invalidBoards {
+		if _, err := ParseBoard(boardStr); err == nil {
+			t.Errorf("Expected an error for %q", boardStr)
+		}
+	}
+}
+
+func TestBearingOffMovesOrder(t *testing.T) {
+	// ARRANGE
+	board := DeserializeBoard("1-3/2-3/3-3/4-3/5-3:24-15 0 0 w")
+	expected := board.GetValidMovesForDie(2)
+
+	for idx := 0; idx < 20; idx++ {
+		// ACT
+		output := board.GetValidMovesForDie(2)
+
+		// ASSERT
+		if !reflect.DeepEqual(output, expected) {
+			t.Fatalf("Output %v not equal to expected %v", output, expected)
+		}
+	}
+}
+
+func TestGetValidMoveResultsParallel(t *testing.T) {
+	for _, test := range makeParallelMoveResultsTests() {
+		// ARRANGE
+		b := DeserializeBoard(test.board)
+		expected := b.GetValidMoveResultsForDieRoll(test.dice)
+
+		for _, workers := range []int{0, 2, 8} {
+			// ACT
+			output := b.GetValidMoveResultsParallel(test.dice, workers)
+
+			// ASSERT
+			if !reflect.DeepEqual(output, expected) {
+				t.Errorf("Output %v not equal to expected %v for %v with %d workers", output, expected, test.board, workers)
+			}
+		}
+	}
+}
+
+type parallelMoveResultsTest struct {
+	board string
+	dice  DieRoll
+}
+
+func makeParallelMoveResultsTests() []parallelMoveResultsTest {
+	return []parallelMoveResultsTest{
+		{"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w", DieRoll{Die1: 6, Die2: 5}},
+		{"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w", DieRoll{Die1: 1, Die2: 1}},
+		{"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 b", DieRoll{Die1: 4, Die2: 4}},
+		// A checker on the bar and a blocked double
+		{"6-4/8-3/13-5/24-2:1-2/2-2/3-2/4-2/5-2/12-5 1 0 w", DieRoll{Die1: 6, Die2: 6}},
+		{"1-3/2-3/3-3/4-3/5-3:24-15 0 0 w", DieRoll{Die1: 3, Die2: 3}},
+	}
+}
@@ -0,0 +1,136 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Version of the binary encoding of a board, stored in its first byte
+const BINARY_BOARD_VERSION = 1
+
+// Size of the binary encoding of a board: the version, the player to move,
+// the playable points and the two bars
+const BINARY_BOARD_SIZE = 1 + 1 + NUM_PLAYABLE_POINTS + 2
+
+// Size of the binary encoding of a move: the from index, the to index and the type
+const BINARY_MOVE_SIZE = 3
+
+var ErrInvalidBinaryEncoding = errors.New("invalid binary encoding")
+
+// Function that encodes a board as the serialized board string
+func (b Board) MarshalText() ([]byte, error) {
+	return []byte(b.SerializeBoard()), nil
+}
+
+// Function that decodes a serialized board string, see ParseBoard
+func (b *Board) UnmarshalText(text []byte) error {
+	decoded, err := ParseBoard(string(text))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// Function that encodes a board in 28 bytes: the encoding version, the
+// player to move, the checkers on the playable points as signed bytes,
+// positive for white, and the checkers on the white and the black bar
+func (b Board) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, BINARY_BOARD_SIZE)
+	data = append(data, BINARY_BOARD_VERSION, byte(b.ColorToMove))
+	for idx := 0; idx < NUM_PLAYABLE_POINTS; idx++ {
+		count := int8(b.Points[idx].CheckerCount)
+		if b.Points[idx].Checker.Color == COLOR_BLACK {
+			count = -count
+		}
+		data = append(data, byte(count))
+	}
+	data = append(data,
+		byte(b.Points[WHITE_PIECES_BAR_POINT_INDEX].CheckerCount),
+		byte(b.Points[BLACK_PIECES_BAR_POINT_INDEX].CheckerCount))
+	return data, nil
+}
+
+func (b *Board) UnmarshalBinary(data []byte) error {
+	if len(data) != BINARY_BOARD_SIZE || data[0] != BINARY_BOARD_VERSION {
+		return ErrInvalidBinaryEncoding
+	}
+	points := make([]int, NUM_PLAYABLE_POINTS)
+	for idx := range points {
+		points[idx] = int(int8(data[2+idx]))
+	}
+	decoded, err := newBoardFromCounts(Color(data[1]), points, int(data[2+NUM_PLAYABLE_POINTS]), int(data[3+NUM_PLAYABLE_POINTS]))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// Function that encodes a move in 3 bytes: the from index, the to index
+// as a signed byte and the type
+func (m Move) MarshalBinary() ([]byte, error) {
+	return []byte{byte(m.From), byte(int8(m.To)), byte(m.Type)}, nil
+}
+
+func (m *Move) UnmarshalBinary(data []byte) error {
+	if len(data) != BINARY_MOVE_SIZE {
+		return ErrInvalidBinaryEncoding
+	}
+	decoded := Move{From: PointIndex(data[0]), To: PointIndex(int8(data[1])), Type: MoveType(data[2])}
+	if err := decoded.validate(); err != nil {
+		return err
+	}
+	*m = decoded
+	return nil
+}
+
+// Function that encodes a move roll as the number of moves followed by the moves
+func (mvRoll MoveRoll) MarshalBinary() ([]byte, error) {
+	data := []byte{byte(len(mvRoll))}
+	for _, mv := range mvRoll {
+		encoded, _ := mv.MarshalBinary()
+		data = append(data, encoded...)
+	}
+	return data, nil
+}
+
+func (mvRoll *MoveRoll) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || len(data) != 1+int(data[0])*BINARY_MOVE_SIZE {
+		return ErrInvalidBinaryEncoding
+	}
+	decoded := MoveRoll{}
+	for start := 1; start < len(data); start += BINARY_MOVE_SIZE {
+		mv := Move{}
+		if err := mv.UnmarshalBinary(data[start : start+BINARY_MOVE_SIZE]); err != nil {
+			return err
+		}
+		decoded = append(decoded, mv)
+	}
+	*mvRoll = decoded
+	return nil
+}
+
+// Function that encodes a die roll in 2 bytes
+func (d DieRoll) MarshalBinary() ([]byte, error) {
+	return []byte{byte(d.Die1), byte(d.Die2)}, nil
+}
+
+func (d *DieRoll) UnmarshalBinary(data []byte) error {
+	if len(data) != 2 || !isDieValue(int(data[0])) || !isDieValue(int(data[1])) {
+		return ErrInvalidBinaryEncoding
+	}
+	*d = DieRoll{int(data[0]), int(data[1])}
+	return nil
+}
+
+// Function that checks the point indexes and the type of a decoded move
+func (m Move) validate() error {
+	if m.From < 0 || m.From >= NUM_POINTS || m.To < TO_INDEX_FOR_BEARING_OFF || m.To >= NUM_PLAYABLE_POINTS {
+		return fmt.Errorf("invalid move from %d to %d", m.From, m.To)
+	}
+	if _, ok := moveTypeNames[m.Type]; !ok {
+		return fmt.Errorf("invalid move type %d", int(m.Type))
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package board
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestBoardBinarySchema(t *testing.T) {
+	// ARRANGE
+	b := DeserializeBoard("1-3/2-2/5-1:20-4/23-6 2 1 b")
+	expected := []byte{
+		BINARY_BOARD_VERSION, 1,
+		3, 2, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0xfc, 0, 0, 0xfa, 0,
+		2, 1,
+	}
+
+	// ACT
+	data, err := b.MarshalBinary()
+
+	// ASSERT
+	if err != nil || !bytes.Equal(data, expected) {
+		t.Errorf("Output %v not equal to expected %v", data, expected)
+	}
+}
+
+func TestMoveRollBinarySchema(t *testing.T) {
+	// ARRANGE
+	mvRoll := MoveRoll{
+		{From: WHITE_PIECES_BAR_POINT_INDEX, To: 20, Type: CHECKER_ON_BAR_MOVE},
+		{From: 2, To: TO_INDEX_FOR_BEARING_OFF, Type: BEARING_OFF_MOVE},
+	}
+	expected := []byte{2, 25, 20, 1, 2, 0xff, 2}
+
+	// ACT
+	data, err := mvRoll.MarshalBinary()
+	decoded := MoveRoll{}
+	decodeErr := decoded.UnmarshalBinary(data)
+
+	// ASSERT
+	if err != nil || !bytes.Equal(data, expected) {
+		t.Errorf("Output %v not equal to expected %v", data, expected)
+	}
+	if decodeErr != nil || !reflect.DeepEqual(decoded, mvRoll) {
+		t.Errorf("Output %v not equal to expected %v", decoded, mvRoll)
+	}
+}
+
+func TestDieRollBinary(t *testing.T) {
+	// ARRANGE
+	d := DieRoll{2, 5}
+
+	// ACT
+	data, _ := d.MarshalBinary()
+	decoded := DieRoll{}
+	err := decoded.UnmarshalBinary(data)
+
+	// ASSERT
+	if err != nil || !bytes.Equal(data, []byte{2, 5}) || decoded != d {
+		t.Errorf("Output %v %v not equal to expected %v", data, decoded, d)
+	}
+}
+
+type invalidBinaryTest struct {
+	value interface{ UnmarshalBinary([]byte) error }
+	data  []byte
+}
+
+func makeInvalidBinaryTests() []invalidBinaryTest {
+	initial, _ := NewBoard(COLOR_WHITE).MarshalBinary()
+	wrongVersion := append([]byte{}, initial...)
+	wrongVersion[0] = 2
+	tooManyCheckers := append([]byte{}, initial...)
+	tooManyCheckers[2+5] = 6
+	invalidColor := append([]byte{}, initial...)
+	invalidColor[1] = 2
+
+	return []invalidBinaryTest{
+		{&Board{}, initial[:10]},
+		{&Board{}, wrongVersion},
+		{&Board{}, tooManyCheckers},
+		{&Board{}, invalidColor},
+		{&Move{}, []byte{26, 0, 0}},
+		{&Move{}, []byte{23, 24, 0}},
+		{&Move{}, []byte{23, 17, 3}},
+		{&MoveRoll{}, []byte{}},
+		{&MoveRoll{}, []byte{2, 23, 17, 0}},
+		{&DieRoll{}, []byte{0, 3}},
+		{&DieRoll{}, []byte{3}},
+	}
+}
+
+func TestUnmarshalBinaryValidation(t *testing.T) {
+	for _, test := range makeInvalidBinaryTests() {
+		// ACT
+		err := test.value.UnmarshalBinary(test.data)
+
+		// ASSERT
+		if err == nil {
+			t.Errorf("Expected an error decoding %v into %T", test.data, test.value)
+		}
+	}
+}
+
+func TestBoardText(t *testing.T) {
+	// ARRANGE
+	serialized := "6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w"
+	b := Board{}
+
+	// ACT
+	err := b.UnmarshalText([]byte(serialized))
+	text, _ := b.MarshalText()
+	invalidErr := b.UnmarshalText([]byte("6-5 0 0 w"))
+
+	// ASSERT
+	if err != nil || string(text) != serialized {
+		t.Errorf("Output %v not equal to expected %v", string(text), serialized)
+	}
+	if invalidErr == nil {
+		t.Errorf("Expected an error for an invalid serialized board")
+	}
+}
@@ -0,0 +1,53 @@
+package board
+
+// Function that returns the index of a point seen from the other
+// player's perspective: point numbers are kept, owners are swapped
+func flipPointIndex(idx PointIndex) PointIndex {
+	switch idx {
+	case TO_INDEX_FOR_BEARING_OFF:
+		return TO_INDEX_FOR_BEARING_OFF
+	case WHITE_PIECES_BAR_POINT_INDEX:
+		return BLACK_PIECES_BAR_POINT_INDEX
+	case BLACK_PIECES_BAR_POINT_INDEX:
+		return WHITE_PIECES_BAR_POINT_INDEX
+	default:
+		return NUM_PLAYABLE_POINTS - 1 - idx
+	}
+}
+
+// Function that mirrors a board and swaps the colors: white's checkers
+// become black's checkers on the same point numbers and vice versa,
+// the color to move is swapped as well. Flipping twice gives the same board
+func (b Board) Flip() Board {
+	points := make([]Point, len(b.Points))
+	for idx := 0; idx < len(b.Points); idx++ {
+		flippedIdx := flipPointIndex(PointIndex(idx))
+		point := b.Points[idx]
+		points[flippedIdx] = NewPoint(point.CheckerCount, flippedIdx, NewChecker(Color(1-point.Checker.Color)))
+	}
+	return Board{points, Color(1 - b.ColorToMove)}
+}
+
+// Function that returns the board from the perspective of the player to
+// move: the board itself if white is to move, the flipped board otherwise.
+// Evaluators can rely on normalized boards always having white to move
+func (b Board) Normalized() Board {
+	if b.ColorToMove == COLOR_WHITE {
+		return b
+	}
+	return b.Flip()
+}
+
+// Function that returns the same move played on the flipped board
+func (m Move) Flip() Move {
+	return Move{flipPointIndex(m.From), flipPointIndex(m.To), m.Type}
+}
+
+// Function that returns the same move roll played on the flipped board
+func (mvRoll MoveRoll) Flip() MoveRoll {
+	flipped := make(MoveRoll, len(mvRoll))
+	for idx := 0; idx < len(mvRoll); idx++ {
+		flipped[idx] = mvRoll[idx].Flip()
+	}
+	return flipped
+}
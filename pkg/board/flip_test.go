@@ -0,0 +1,113 @@
+package board
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Function that plays random games from the initial position and
+// returns the positions reached, the same seed gives the same positions
+func randomPositions(seed int64, numPositions int) []Board {
+	rng := rand.New(rand.NewSource(seed))
+	positions := []Board{}
+	b := NewBoard(Color(rng.Intn(2)))
+	for len(positions) < numPositions {
+		if b.ComputeGameState() == GAME_OVER {
+			b = NewBoard(Color(rng.Intn(2)))
+		}
+		positions = append(positions, b)
+		moveRolls := b.GetValidMovesForDieRoll(DieRoll{rng.Intn(6) + 1, rng.Intn(6) + 1})
+		if len(moveRolls) > 0 {
+			b = moveRolls[rng.Intn(len(moveRolls))].MakeMoveRoll(b)
+		}
+		b.ColorToMove = Color(1 - b.ColorToMove)
+	}
+	return positions
+}
+
+func allDieRolls() []DieRoll {
+	dieRolls := []DieRoll{}
+	for die1 := 1; die1 <= 6; die1++ {
+		for die2 := die1; die2 <= 6; die2++ {
+			dieRolls = append(dieRolls, DieRoll{die1, die2})
+		}
+	}
+	return dieRolls
+}
+
+func TestFlip(t *testing.T) {
+	// ARRANGE
+	board := NewBoard(COLOR_WHITE)
+	board.Points[5].CheckerCount -= 1
+	board.Points[WHITE_PIECES_BAR_POINT_INDEX].CheckerCount = 1
+	expected := NewBoard(COLOR_BLACK)
+	expected.Points[18].CheckerCount -= 1
+	expected.Points[BLACK_PIECES_BAR_POINT_INDEX].CheckerCount = 1
+
+	// ACT
+	flipped := board.Flip()
+
+	// ASSERT
+	if output := flipped.SerializeBoard(); output != expected.SerializeBoard() {
+		t.Errorf("Output %q not equal to expected %q", output, expected.SerializeBoard())
+	}
+	if output := board.Normalized(); !output.IsEqual(board) {
+		t.Errorf("Output %q not equal to expected %q", output.SerializeBoard(), board.SerializeBoard())
+	}
+	if output := flipped.Normalized(); !output.IsEqual(board) {
+		t.Errorf("Output %q not equal to expected %q", output.SerializeBoard(), board.SerializeBoard())
+	}
+}
+
+func TestFlipProperties(t *testing.T) {
+	for _, b := range randomPositions(1, 200) {
+		flipped := b.Flip()
+		if !flipped.Flip().IsEqual(b) {
+			t.Errorf("Flipping twice changed %q", b.SerializeBoard())
+		}
+		if flipped.Normalized().ColorToMove != COLOR_WHITE || b.Normalized().ColorToMove != COLOR_WHITE {
+			t.Errorf("Normalized board of %q does not have white to move", b.SerializeBoard())
+		}
+		if flipped.ComputeGameState() != b.ComputeGameState() {
+			t.Errorf("Output %q not equal to expected %q for %q", flipped.ComputeGameState(), b.ComputeGameState(), b.SerializeBoard())
+		}
+		for _, color := range []Color{COLOR_WHITE, COLOR_BLACK} {
+			if flipped.PipCount(Color(1-color)) != b.PipCount(color) || flipped.CheckersOff(Color(1-color)) != b.CheckersOff(color) {
+				t.Errorf("Pip counts or checkers off differ for %q", b.SerializeBoard())
+			}
+		}
+	}
+}
+
+func TestMoveGenerationCommutesWithFlip(t *testing.T) {
+	for _, b := range randomPositions(2, 12) {
+		flipped := b.Flip()
+		for _, dieRoll := range allDieRolls() {
+			expected := map[string]bool{}
+			for _, mvRoll := range b.GetValidMovesForDieRoll(dieRoll) {
+				resultBoard := mvRoll.MakeMoveRoll(b)
+				expected[resultBoard.Flip().SerializeBoard()] = true
+
+				// Flipped move rolls are played the same way on the flipped board
+				if output := mvRoll.Flip().MakeMoveRoll(flipped); output.SerializeBoard() != resultBoard.Flip().SerializeBoard() {
+					t.Errorf("Output %q not equal to expected %q", output.SerializeBoard(), resultBoard.Flip().SerializeBoard())
+				}
+			}
+
+			output := map[string]bool{}
+			for _, mvRoll := range flipped.GetValidMovesForDieRoll(dieRoll) {
+				output[mvRoll.MakeMoveRoll(flipped).SerializeBoard()] = true
+			}
+
+			if len(output) != len(expected) {
+				t.Errorf("Output %d positions not equal to expected %d for %q and %v", len(output), len(expected), b.SerializeBoard(), dieRoll)
+				continue
+			}
+			for position := range expected {
+				if !output[position] {
+					t.Errorf("Position %q missing for %q and %v", position, flipped.SerializeBoard(), dieRoll)
+				}
+			}
+		}
+	}
+}
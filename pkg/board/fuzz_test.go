@@ -0,0 +1,204 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var FUZZ_SEED_POSITIONS = []string{
+	"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w",
+	"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 b",
+	"1-3/2-4/3-3/5-3:19-4/20-4/22-3 0 0 w",
+	"6-4/8-3/13-5/24-2:1-2/12-5/17-3/19-4/20-1 1 0 w",
+	"1-2/2-2/3-2/4-2/5-2/6-2/7-3:20-5/22-5/24-3 0 2 b",
+	":1-1 0 0 w",
+}
+
+func FuzzSerializeRoundTrip(f *testing.F) {
+	for _, position := range FUZZ_SEED_POSITIONS {
+		f.Add(position)
+	}
+	f.Add("24-15:1-15 0 0 b")
+	f.Add("1-16:24-1 0 0 w")
+	f.Add("1-1/1-1:2-1 0 0 w")
+
+	f.Fuzz(func(t *testing.T, position string) {
+		// ARRANGE
+		b, err := ParseBoard(position)
+		if err != nil {
+			t.Skip()
+		}
+
+		// ACT
+		serialized := b.SerializeBoard()
+		output := DeserializeBoard(serialized)
+		reparsed, err := ParseBoard(serialized)
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("Unexpected error %v parsing %q", err, serialized)
+		}
+		if output.SerializeBoard() != serialized || !output.IsEqual(b) || !reparsed.IsEqual(b) {
+			t.Errorf("Output %q not equal to expected %q", output.SerializeBoard(), serialized)
+		}
+	})
+}
+
+func FuzzMoveGeneration(f *testing.F) {
+	for idx, position := range FUZZ_SEED_POSITIONS {
+		b, err := ParseBoard(position)
+		if err != nil {
+			f.Fatalf("Unexpected error %v parsing %q", err, position)
+		}
+		f.Add(fuzzBytes(b), uint8(idx), uint8(idx*5))
+	}
+	f.Add([]byte{}, uint8(6), uint8(6))
+	f.Add([]byte{0, 23, 0, 23, 24, 24, 24, 24}, uint8(1), uint8(1))
+	f.Add([]byte{5, 18, 4, 19, 3, 20, 25, 25}, uint8(3), uint8(3))
+
+	f.Fuzz(func(t *testing.T, data []byte, die1 uint8, die2 uint8) {
+		// ARRANGE
+		b, ok := fuzzBoard(data)
+		if !ok || b.ComputeGameState() == GAME_OVER {
+			t.Skip()
+		}
+		d := DieRoll{Die1: int(die1%6) + 1, Die2: int(die2%6) + 1}
+
+		// ACT
+		results := b.GetValidMoveResultsForDieRoll(d)
+
+		// ASSERT
+		seen := map[string]bool{}
+		for _, result := range results {
+			serialized := result.Board.SerializeBoard()
+			if seen[serialized] {
+				t.Fatalf("Move roll %v of %v on %q leads to the duplicate position %q", result.MoveRoll, d, b.SerializeBoard(), serialized)
+			}
+			seen[serialized] = true
+
+			output := result.MoveRoll.MakeMoveRoll(b)
+			if !output.IsEqual(result.Board) {
+				t.Errorf("Output %q not equal to expected %q for %v", output.SerializeBoard(), serialized, result.MoveRoll)
+			}
+			assertCheckersKept(t, b, output, result.MoveRoll)
+		}
+	})
+}
+
+func TestFuzzBytes(t *testing.T) {
+	for _, position := range FUZZ_SEED_POSITIONS {
+		// ARRANGE
+		b, err := ParseBoard(position)
+		if err != nil {
+			t.Fatalf("Unexpected error %v parsing %q", err, position)
+		}
+
+		// ACT
+		output, ok := fuzzBoard(fuzzBytes(b))
+
+		// ASSERT
+		if !ok || !output.IsEqual(b) || output.ColorToMove != b.ColorToMove {
+			t.Errorf("Output %q not equal to expected %q", output.SerializeBoard(), position)
+		}
+	}
+}
+
+// Function that fails the test if the move roll made on before did not
+// keep the 15 checkers of each player, counting the borne off ones
+func assertCheckersKept(t *testing.T, before Board, after Board, mvRoll MoveRoll) {
+	t.Helper()
+	bearOffs := 0
+	for _, mv := range mvRoll {
+		if mv.Type == BEARING_OFF_MOVE {
+			bearOffs++
+		}
+	}
+	color := before.ColorToMove
+	opponent := Color(1 - color)
+	if output, expected := after.CheckersOff(color), before.CheckersOff(color)+bearOffs; output != expected {
+		t.Errorf("Output %d checkers off not equal to expected %d for %v", output, expected, mvRoll)
+	}
+	if output, expected := after.CheckersOff(opponent), before.CheckersOff(opponent); output != expected {
+		t.Errorf("Output %d opponent checkers off not equal to expected %d for %v", output, expected, mvRoll)
+	}
+	for idx := 0; idx < NUM_PLAYABLE_POINTS; idx++ {
+		if count := after.Points[idx].CheckerCount; count < 0 || count > INIT_NUM_CHECKERS {
+			t.Errorf("Output %d checkers on point %d of %q", count, idx+1, after.SerializeBoard())
+		}
+	}
+}
+
+// Function that builds a board ParseBoard accepts from fuzzing input. The
+// bytes alternate between white and black, every byte puts a checker on
+// one of the 24 points, on the bar or off the board. A checker landing on
+// a point of the opponent is borne off, so are the checkers no byte placed.
+// The parity of the number of bytes decides the color to move
+func fuzzBoard(data []byte) (Board, bool) {
+	points := [2][NUM_PLAYABLE_POINTS + 1]int{}
+	placed := [2]int{}
+	for idx, value := range data {
+		color := idx % 2
+		if placed[color] == INIT_NUM_CHECKERS {
+			continue
+		}
+		placed[color]++
+		target := int(value) % (NUM_PLAYABLE_POINTS + 2)
+		if target == NUM_PLAYABLE_POINTS+1 || (target < NUM_PLAYABLE_POINTS && points[1-color][target] > 0) {
+			continue
+		}
+		points[color][target]++
+	}
+
+	sides := [2]string{}
+	for color := range points {
+		groups := []string{}
+		for idx := 0; idx < NUM_PLAYABLE_POINTS; idx++ {
+			if points[color][idx] > 0 {
+				groups = append(groups, fmt.Sprintf("%d-%d", idx+1, points[color][idx]))
+			}
+		}
+		sides[color] = strings.Join(groups, "/")
+	}
+	turn := "w"
+	if len(data)%2 == 1 {
+		turn = "b"
+	}
+	b, err := ParseBoard(fmt.Sprintf("%s:%s %d %d %s", sides[COLOR_WHITE], sides[COLOR_BLACK],
+		points[COLOR_WHITE][NUM_PLAYABLE_POINTS], points[COLOR_BLACK][NUM_PLAYABLE_POINTS], turn))
+	return b, err == nil
+}
+
+// Function that encodes a board as the fuzzing input fuzzBoard builds it
+// back from, to seed the fuzzer with real positions: the 15 checkers of
+// each player are placed in turn, the checkers off the board last
+func fuzzBytes(b Board) []byte {
+	targets := [2][]byte{}
+	bars := [2]PointIndex{WHITE_PIECES_BAR_POINT_INDEX, BLACK_PIECES_BAR_POINT_INDEX}
+	for color := range targets {
+		for idx := 0; idx < NUM_PLAYABLE_POINTS; idx++ {
+			if b.Points[idx].Checker.Color != Color(color) {
+				continue
+			}
+			for count := 0; count < b.Points[idx].CheckerCount; count++ {
+				targets[color] = append(targets[color], byte(idx))
+			}
+		}
+		for count := 0; count < b.Points[bars[color]].CheckerCount; count++ {
+			targets[color] = append(targets[color], NUM_PLAYABLE_POINTS)
+		}
+		for len(targets[color]) < INIT_NUM_CHECKERS {
+			targets[color] = append(targets[color], NUM_PLAYABLE_POINTS+1)
+		}
+	}
+
+	data := []byte{}
+	for idx := 0; idx < INIT_NUM_CHECKERS; idx++ {
+		data = append(data, targets[COLOR_WHITE][idx], targets[COLOR_BLACK][idx])
+	}
+	// One more byte for white, who has all its checkers placed, gives black the turn
+	if b.ColorToMove == COLOR_BLACK {
+		data = append(data, 0)
+	}
+	return data
+}
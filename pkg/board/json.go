@@ -0,0 +1,214 @@
+package board
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Names of the colors used by the text and JSON encodings
+const (
+	WHITE_NAME = "white"
+	BLACK_NAME = "black"
+)
+
+// Names of the move types used by the text and JSON encodings
+const (
+	NORMAL_MOVE_NAME         = "normal"
+	CHECKER_ON_BAR_MOVE_NAME = "bar"
+	BEARING_OFF_MOVE_NAME    = "bearoff"
+)
+
+var moveTypeNames = map[MoveType]string{
+	NORMAL_MOVE:         NORMAL_MOVE_NAME,
+	CHECKER_ON_BAR_MOVE: CHECKER_ON_BAR_MOVE_NAME,
+	BEARING_OFF_MOVE:    BEARING_OFF_MOVE_NAME,
+}
+
+func (t MoveType) MarshalText() ([]byte, error) {
+	name, ok := moveTypeNames[t]
+	if !ok {
+		return nil, fmt.Errorf("invalid move type %d", int(t))
+	}
+	return []byte(name), nil
+}
+
+func (t *MoveType) UnmarshalText(text []byte) error {
+	for moveType, name := range moveTypeNames {
+		if name == string(text) {
+			*t = moveType
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid move type %q", string(text))
+}
+
+// Function that decodes a move encoded as {"from": 23, "to": 17, "type": "normal"},
+// checking the point indexes are on the board
+func (m *Move) UnmarshalJSON(data []byte) error {
+	// The alias does not have the UnmarshalJSON method, avoiding the recursion
+	type moveAlias Move
+	decoded := moveAlias{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if err := Move(decoded).validate(); err != nil {
+		return err
+	}
+	*m = Move(decoded)
+	return nil
+}
+
+func (c Color) MarshalText() ([]byte, error) {
+	switch c {
+	case COLOR_WHITE:
+		return []byte(WHITE_NAME), nil
+	case COLOR_BLACK:
+		return []byte(BLACK_NAME), nil
+	}
+	return nil, fmt.Errorf("invalid color %d", int(c))
+}
+
+func (c *Color) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case WHITE_NAME:
+		*c = COLOR_WHITE
+	case BLACK_NAME:
+		*c = COLOR_BLACK
+	default:
+		return fmt.Errorf("invalid color %q", string(text))
+	}
+	return nil
+}
+
+// Function that encodes a move roll as an array of moves,
+// the empty move roll being the empty array
+func (mvRoll MoveRoll) MarshalJSON() ([]byte, error) {
+	if mvRoll == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Move(mvRoll))
+}
+
+// Function that encodes a die roll as [die1, die2]
+func (d DieRoll) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]int{d.Die1, d.Die2})
+}
+
+func (d *DieRoll) UnmarshalJSON(data []byte) error {
+	dice := []int{}
+	if err := json.Unmarshal(data, &dice); err != nil {
+		return err
+	}
+	if len(dice) != 2 || !isDieValue(dice[0]) || !isDieValue(dice[1]) {
+		return fmt.Errorf("invalid die roll %s", string(data))
+	}
+	*d = DieRoll{dice[0], dice[1]}
+	return nil
+}
+
+// Checkers of each player, keyed by the color name
+type checkersJSON struct {
+	White int `json:"white"`
+	Black int `json:"black"`
+}
+
+type boardJSON struct {
+	Turn Color `json:"turn"`
+	// Checkers on the playable points indexed like Board.Points,
+	// positive for white and negative for black
+	Points []int        `json:"points"`
+	Bar    checkersJSON `json:"bar"`
+	Off    checkersJSON `json:"off"`
+}
+
+// Function that encodes a board as
+//
+//	{
+//		"turn": "white",
+//		"points": [-2, 0, 0, 0, 0, 5, 0, 3, 0, 0, 0, -5, 5, 0, 0, 0, -3, 0, -5, 0, 0, 0, 0, 2],
+//		"bar": {"white": 0, "black": 0},
+//		"off": {"white": 0, "black": 0}
+//	}
+//
+// where the points are indexed like Board.Points, white checkers are
+// counted as positive and black checkers as negative
+func (b Board) MarshalJSON() ([]byte, error) {
+	encoded := boardJSON{
+		Turn:   b.ColorToMove,
+		Points: make([]int, NUM_PLAYABLE_POINTS),
+		Bar: checkersJSON{
+			White: b.Points[WHITE_PIECES_BAR_POINT_INDEX].CheckerCount,
+			Black: b.Points[BLACK_PIECES_BAR_POINT_INDEX].CheckerCount,
+		},
+		Off: checkersJSON{White: b.CheckersOff(COLOR_WHITE), Black: b.CheckersOff(COLOR_BLACK)},
+	}
+	for idx := 0; idx < NUM_PLAYABLE_POINTS; idx++ {
+		encoded.Points[idx] = b.Points[idx].CheckerCount
+		if b.Points[idx].Checker.Color == COLOR_BLACK {
+			encoded.Points[idx] = -encoded.Points[idx]
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+// Function that decodes a board encoded by MarshalJSON, checking that no
+// player has more than 15 checkers and that the borne off checkers are the
+// ones missing from the board
+func (b *Board) UnmarshalJSON(data []byte) error {
+	encoded := boardJSON{}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	if len(encoded.Points) != NUM_PLAYABLE_POINTS {
+		return fmt.Errorf("invalid board: expected %d points, got %d", NUM_PLAYABLE_POINTS, len(encoded.Points))
+	}
+
+	decoded, err := newBoardFromCounts(encoded.Turn, encoded.Points, encoded.Bar.White, encoded.Bar.Black)
+	if err != nil {
+		return err
+	}
+	if decoded.CheckersOff(COLOR_WHITE) != encoded.Off.White || decoded.CheckersOff(COLOR_BLACK) != encoded.Off.Black {
+		return errors.New("invalid board: the borne off checkers do not match the checkers on the board")
+	}
+	*b = decoded
+	return nil
+}
+
+// Function that builds a board from the signed checker counts of the playable
+// points, positive for white, and the checkers on the bars
+func newBoardFromCounts(turn Color, points []int, whiteBar int, blackBar int) (Board, error) {
+	if turn != COLOR_WHITE && turn != COLOR_BLACK {
+		return Board{}, fmt.Errorf("invalid board: invalid color %d to move", int(turn))
+	}
+	if whiteBar < 0 || blackBar < 0 {
+		return Board{}, errors.New("invalid board: negative number of checkers on the bar")
+	}
+
+	// Empty points get the colors DeserializeBoard gives them,
+	// so equal positions are equal boards
+	b := NewBoard(COLOR_BLACK)
+	b.ColorToMove = turn
+	numCheckers := map[Color]int{COLOR_WHITE: whiteBar, COLOR_BLACK: blackBar}
+	for idx, count := range points {
+		b.Points[idx].CheckerCount = count
+		if count < 0 {
+			b.Points[idx].CheckerCount = -count
+			b.Points[idx].Checker.Color = COLOR_BLACK
+		} else if count > 0 {
+			b.Points[idx].Checker.Color = COLOR_WHITE
+		}
+		numCheckers[b.Points[idx].Checker.Color] += b.Points[idx].CheckerCount
+	}
+	b.Points[WHITE_PIECES_BAR_POINT_INDEX].CheckerCount = whiteBar
+	b.Points[BLACK_PIECES_BAR_POINT_INDEX].CheckerCount = blackBar
+
+	if numCheckers[COLOR_WHITE] > INIT_NUM_CHECKERS || numCheckers[COLOR_BLACK] > INIT_NUM_CHECKERS {
+		return Board{}, fmt.Errorf("invalid board: a player has more than %d checkers", INIT_NUM_CHECKERS)
+	}
+	return b, nil
+}
+
+func isDieValue(value int) bool {
+	return value >= 1 && value <= 6
+}
@@ -0,0 +1,171 @@
+package board
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMoveRollJSON(t *testing.T) {
+	// ARRANGE
+	mvRoll := MoveRoll{
+		{From: WHITE_PIECES_BAR_POINT_INDEX, To: 20, Type: CHECKER_ON_BAR_MOVE},
+		{From: 20, To: 17, Type: NORMAL_MOVE},
+		{From: 2, To: TO_INDEX_FOR_BEARING_OFF, Type: BEARING_OFF_MOVE},
+	}
+	expected := `[{"from":25,"to":20,"type":"bar"},{"from":20,"to":17,"type":"normal"},{"from":2,"to":-1,"type":"bearoff"}]`
+
+	// ACT
+	data, err := json.Marshal(mvRoll)
+	decoded := MoveRoll{}
+	decodeErr := json.Unmarshal(data, &decoded)
+
+	// ASSERT
+	if err != nil || string(data) != expected {
+		t.Errorf("Output %v not equal to expected %v", string(data), expected)
+	}
+	if decodeErr != nil || !reflect.DeepEqual(decoded, mvRoll) {
+		t.Errorf("Output %v not equal to expected %v", decoded, mvRoll)
+	}
+}
+
+func makeInvalidMoveJSONTests() []string {
+	return []string{
+		`{"from":23,"to":17,"type":"jump"}`,
+		`{"from":23,"to":17,"type":0}`,
+		`{"from":26,"to":17,"type":"normal"}`,
+		`{"from":23,"to":-2,"type":"normal"}`,
+		`{"from":23,"to":24,"type":"normal"}`,
+		`[23, 17]`,
+	}
+}
+
+func TestMoveJSONValidation(t *testing.T) {
+	for _, test := range makeInvalidMoveJSONTests() {
+		// ACT
+		err := json.Unmarshal([]byte(test), &Move{})
+
+		// ASSERT
+		if err == nil {
+			t.Errorf("Expected an error for %v", test)
+		}
+	}
+}
+
+type boardJSONTest struct {
+	serialized string
+	expected   string
+}
+
+func makeBoardJSONTests() []boardJSONTest {
+	return []boardJSONTest{
+		{
+			"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w",
+			`{"turn":"white","points":[-2,0,0,0,0,5,0,3,0,0,0,-5,5,0,0,0,-3,0,-5,0,0,0,0,2],"bar":{"white":0,"black":0},"off":{"white":0,"black":0}}`,
+		},
+		{
+			"1-3/2-2/5-1:20-4/23-6 2 1 b",
+			`{"turn":"black","points":[3,2,0,0,1,0,0,0,0,0,0,0,0,0,0,0,0,0,0,-4,0,0,-6,0],"bar":{"white":2,"black":1},"off":{"white":7,"black":4}}`,
+		},
+	}
+}
+
+func TestBoardJSONSchema(t *testing.T) {
+	for _, test := range makeBoardJSONTests() {
+		// ARRANGE
+		b := DeserializeBoard(test.serialized)
+
+		// ACT
+		data, err := json.Marshal(b)
+		decoded := Board{}
+		decodeErr := json.Unmarshal([]byte(test.expected), &decoded)
+
+		// ASSERT
+		if err != nil || string(data) != test.expected {
+			t.Errorf("Output %v not equal to expected %v", string(data), test.expected)
+		}
+		if decodeErr != nil || !reflect.DeepEqual(decoded, b) {
+			t.Errorf("Output %v %v not equal to expected %v", decoded.SerializeBoard(), decodeErr, test.serialized)
+		}
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	for _, b := range randomPositions(35, 200) {
+		// ARRANGE
+		expected := DeserializeBoard(b.SerializeBoard())
+
+		// ACT
+		jsonData, _ := json.Marshal(b)
+		fromJSON := Board{}
+		jsonErr := json.Unmarshal(jsonData, &fromJSON)
+		binaryData, _ := b.MarshalBinary()
+		fromBinary := Board{}
+		binaryErr := fromBinary.UnmarshalBinary(binaryData)
+		textData, _ := b.MarshalText()
+		fromText := Board{}
+		textErr := fromText.UnmarshalText(textData)
+
+		// ASSERT
+		for _, decoded := range []Board{fromJSON, fromBinary, fromText} {
+			if !reflect.DeepEqual(decoded, expected) {
+				t.Errorf("Output %v not equal to expected %v", decoded.SerializeBoard(), expected.SerializeBoard())
+			}
+		}
+		if jsonErr != nil || binaryErr != nil || textErr != nil {
+			t.Errorf("Unexpected errors %v %v %v", jsonErr, binaryErr, textErr)
+		}
+	}
+}
+
+func TestDieRollJSON(t *testing.T) {
+	// ARRANGE
+	d := DieRoll{6, 3}
+
+	// ACT
+	data, err := json.Marshal(d)
+	decoded := DieRoll{}
+	decodeErr := json.Unmarshal(data, &decoded)
+	invalidErr := json.Unmarshal([]byte("[7, 1]"), &decoded)
+	tooManyErr := json.Unmarshal([]byte("[1, 1, 1]"), &decoded)
+
+	// ASSERT
+	if err != nil || string(data) != "[6,3]" || decodeErr != nil || decoded != d {
+		t.Errorf("Output %v %v not equal to expected %v", string(data), decoded, d)
+	}
+	if invalidErr == nil || tooManyErr == nil {
+		t.Errorf("Expected errors for invalid die rolls")
+	}
+}
+
+func TestEmptyMoveRollJSON(t *testing.T) {
+	// ACT
+	data, err := json.Marshal(MoveRoll(nil))
+
+	// ASSERT
+	if err != nil || string(data) != "[]" {
+		t.Errorf("Output %v not equal to expected %v", string(data), "[]")
+	}
+}
+
+func makeInvalidBoardJSONTests() []string {
+	return []string{
+		`{"turn":"red","points":[-2,0,0,0,0,5,0,3,0,0,0,-5,5,0,0,0,-3,0,-5,0,0,0,0,2],"bar":{"white":0,"black":0},"off":{"white":0,"black":0}}`,
+		`{"turn":"white","points":[-2,0,0,0,0,5,0,3,0,0,0,-5,5,0,0,0,-3,0,-5,0,0,0,0],"bar":{"white":0,"black":0},"off":{"white":0,"black":0}}`,
+		`{"turn":"white","points":[-2,0,0,0,0,5,0,3,0,0,0,-5,5,0,0,0,-3,0,-5,0,0,0,0,2],"bar":{"white":1,"black":0},"off":{"white":0,"black":0}}`,
+		`{"turn":"white","points":[-2,0,0,0,0,5,0,3,0,0,0,-5,5,0,0,0,-3,0,-5,0,0,0,0,2],"bar":{"white":-1,"black":0},"off":{"white":1,"black":0}}`,
+		`{"turn":"white","points":[-2,0,0,0,0,5,0,3,0,0,0,-5,5,0,0,0,-3,0,-5,0,0,0,0,1],"bar":{"white":0,"black":0},"off":{"white":0,"black":0}}`,
+	}
+}
+
+func TestBoardJSONValidation(t *testing.T) {
+	for _, test := range makeInvalidBoardJSONTests() {
+		// ACT
+		err := json.Unmarshal([]byte(test), &Board{})
+
+		// ASSERT
+		if err == nil {
+			t.Errorf("Expected an error for %v", test)
+		}
+	}
+}
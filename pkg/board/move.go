@@ -11,9 +11,9 @@ const (
 )
 
 type Move struct {
-	From PointIndex
-	To   PointIndex
-	Type MoveType
+	From PointIndex `json:"from"`
+	To   PointIndex `json:"to"`
+	Type MoveType   `json:"type"`
 }
 
 // Funcion to apply a move to a given board
@@ -60,6 +60,52 @@ func (mvRoll MoveRoll) MakeMoveRoll(b Board) Board {
 	return boardForRoll
 }
 
+// A valid move roll together with the board it leads to and
+// metadata that evaluators and user interfaces usually need
+type MoveRollResult struct {
+	MoveRoll MoveRoll
+	// Board after the move roll, the color to move is NOT switched
+	Board Board
+	// Number of opponent checkers sent to the bar
+	Hits int
+	// Number of checkers borne off
+	BorneOff int
+	// Number of points made that were not made before the move roll
+	PointsMade int
+	// Whether the move roll enters at least one checker from the bar
+	EntersFromBar bool
+	// Canonical notation of the move roll, see MoveRoll.Notation
+	Notation string
+}
+
+func newMoveRollResult(b Board, mvRoll MoveRoll, resultBoard Board) MoveRollResult {
+	color := b.ColorToMove
+	opponentBar := barPointIndex(Color(1 - color))
+
+	result := MoveRollResult{
+		MoveRoll: mvRoll,
+		Board:    resultBoard,
+		Hits:     resultBoard.Points[opponentBar].CheckerCount - b.Points[opponentBar].CheckerCount,
+		Notation: mvRoll.Notation(b),
+	}
+	for _, mv := range mvRoll {
+		if mv.Type == BEARING_OFF_MOVE {
+			result.BorneOff += 1
+		} else if mv.Type == CHECKER_ON_BAR_MOVE {
+			result.EntersFromBar = true
+		}
+	}
+
+	madeBefore := madePoints(b, color)
+	madeAfter := madePoints(resultBoard, color)
+	for idx := 0; idx < NUM_PLAYABLE_POINTS; idx++ {
+		if madeAfter[idx] && !madeBefore[idx] {
+			result.PointsMade += 1
+		}
+	}
+	return result
+}
+
 // This compares two move rolls, VERY naively
 // NOTE: DO NOT USE, ONLY IN TESTS
 // TODO: make this more efficient, use maps/something else, I don't want to
@@ -0,0 +1,209 @@
+package board
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const BAR_NOTATION = "bar"
+const OFF_NOTATION = "off"
+
+// Function that converts a point index to the point number seen by
+// the player of the given color: every player bears off from his own
+// points 1..6 and enters from the bar on his opponent's points 19..24
+func PointNumber(idx PointIndex, color Color) int {
+	if idx == TO_INDEX_FOR_BEARING_OFF {
+		return 0
+	}
+	if idx == BLACK_PIECES_BAR_POINT_INDEX || idx == WHITE_PIECES_BAR_POINT_INDEX {
+		return NUM_PLAYABLE_POINTS + 1
+	}
+	if color == COLOR_WHITE {
+		return int(idx) + 1
+	}
+	return NUM_PLAYABLE_POINTS - int(idx)
+}
+
+// Function that converts a point number seen by the player of the given
+// color back to a point index, it is the inverse of PointNumber
+func PointIndexFromNumber(number int, color Color) PointIndex {
+	if number == 0 {
+		return TO_INDEX_FOR_BEARING_OFF
+	}
+	if number == NUM_PLAYABLE_POINTS+1 {
+		return barPointIndex(color)
+	}
+	if color == COLOR_WHITE {
+		return PointIndex(number - 1)
+	}
+	return PointIndex(NUM_PLAYABLE_POINTS - number)
+}
+
+// Function that returns the notation of a single move, from the
+// perspective of the player with the given color, e.g. 24/18, bar/22, 6/off
+func (m Move) Notation(color Color) string {
+	return pointNotation(m.From, color) + "/" + pointNotation(m.To, color)
+}
+
+// Function that returns the canonical notation of a move roll played on
+// the given board, e.g. "bar/22* 13/11" or "8/5(2) 6/5(2)"
+// Moves are ordered from the highest starting point to the lowest,
+// identical moves are grouped and moves that hit a blot are marked with *
+func (mvRoll MoveRoll) Notation(b Board) string {
+	type notationMove struct {
+		from int
+		to   int
+		text string
+		hit  bool
+	}
+
+	color := b.ColorToMove
+	counts := make([]int, len(b.Points))
+	colors := make([]Color, len(b.Points))
+	for idx := 0; idx < len(b.Points); idx++ {
+		counts[idx] = b.Points[idx].CheckerCount
+		colors[idx] = b.Points[idx].Checker.Color
+	}
+
+	notationMoves := []notationMove{}
+	for _, mv := range mvRoll {
+		hit := false
+		counts[mv.From] -= 1
+		if mv.Type != BEARING_OFF_MOVE {
+			if counts[mv.To] == 1 && colors[mv.To] != color {
+				hit = true
+				counts[mv.To] = 0
+			}
+			counts[mv.To] += 1
+			colors[mv.To] = color
+		}
+		notationMoves = append(notationMoves, notationMove{
+			from: PointNumber(mv.From, color),
+			to:   PointNumber(mv.To, color),
+			text: mv.Notation(color),
+			hit:  hit,
+		})
+	}
+
+	sort.SliceStable(notationMoves, func(i, j int) bool {
+		if notationMoves[i].from != notationMoves[j].from {
+			return notationMoves[i].from > notationMoves[j].from
+		}
+		return notationMoves[i].to > notationMoves[j].to
+	})
+
+	parts := []string{}
+	for idx := 0; idx < len(notationMoves); {
+		jdx := idx
+		hit := false
+		for jdx < len(notationMoves) && notationMoves[jdx].text == notationMoves[idx].text {
+			hit = hit || notationMoves[jdx].hit
+			jdx++
+		}
+		part := notationMoves[idx].text
+		if hit {
+			part += "*"
+		}
+		if jdx-idx > 1 {
+			part += fmt.Sprintf("(%d)", jdx-idx)
+		}
+		parts = append(parts, part)
+		idx = jdx
+	}
+	return strings.Join(parts, " ")
+}
+
+// Function that parses the notation of a move roll played by the player
+// with the given color. Besides the canonical notation returned by
+// MoveRoll.Notation it accepts moves of a checker over several points,
+// e.g. 24/18/13, and point numbers 25 and 0 for the bar and off
+// NOTE: the parsed move roll is not checked to be legal
+func ParseMoveRollNotation(notation string, color Color) (MoveRoll, error) {
+	mvRoll := MoveRoll{}
+	for _, token := range strings.Fields(strings.ToLower(notation)) {
+		repeat := 1
+		if open := strings.Index(token, "("); open >= 0 {
+			if !strings.HasSuffix(token, ")") {
+				return nil, fmt.Errorf("invalid move %q", token)
+			}
+			count, err := strconv.Atoi(token[open+1 : len(token)-1])
+			if err != nil || count < 1 || count > 4 {
+				return nil, fmt.Errorf("invalid repetition in move %q", token)
+			}
+			repeat = count
+			token = token[:open]
+		}
+
+		points := strings.Split(strings.ReplaceAll(token, "*", ""), "/")
+		if len(points) < 2 {
+			return nil, fmt.Errorf("invalid move %q", token)
+		}
+		indexes := []PointIndex{}
+		for _, point := range points {
+			idx, err := parsePointNotation(point, color)
+			if err != nil {
+				return nil, fmt.Errorf("invalid move %q: %w", token, err)
+			}
+			indexes = append(indexes, idx)
+		}
+
+		for count := 0; count < repeat; count++ {
+			for idx := 0; idx+1 < len(indexes); idx++ {
+				mv, err := newNotationMove(indexes[idx], indexes[idx+1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid move %q: %w", token, err)
+				}
+				mvRoll = append(mvRoll, mv)
+			}
+		}
+	}
+	if len(mvRoll) == 0 {
+		return nil, fmt.Errorf("empty move notation")
+	}
+	return mvRoll, nil
+}
+
+func parsePointNotation(point string, color Color) (PointIndex, error) {
+	switch point {
+	case BAR_NOTATION:
+		return barPointIndex(color), nil
+	case OFF_NOTATION:
+		return TO_INDEX_FOR_BEARING_OFF, nil
+	}
+	number, err := strconv.Atoi(point)
+	if err != nil || number < 0 || number > NUM_PLAYABLE_POINTS+1 {
+		return 0, fmt.Errorf("invalid point %q", point)
+	}
+	return PointIndexFromNumber(number, color), nil
+}
+
+func newNotationMove(from PointIndex, to PointIndex) (Move, error) {
+	isBar := func(idx PointIndex) bool {
+		return idx == WHITE_PIECES_BAR_POINT_INDEX || idx == BLACK_PIECES_BAR_POINT_INDEX
+	}
+	switch {
+	case from == TO_INDEX_FOR_BEARING_OFF || isBar(to):
+		return Move{}, fmt.Errorf("checkers cannot move from off or to the bar")
+	case isBar(from) && to == TO_INDEX_FOR_BEARING_OFF:
+		return Move{}, fmt.Errorf("checkers cannot be borne off from the bar")
+	case isBar(from):
+		return Move{from, to, CHECKER_ON_BAR_MOVE}, nil
+	case to == TO_INDEX_FOR_BEARING_OFF:
+		return Move{from, to, BEARING_OFF_MOVE}, nil
+	default:
+		return Move{from, to, NORMAL_MOVE}, nil
+	}
+}
+
+func pointNotation(idx PointIndex, color Color) string {
+	switch PointNumber(idx, color) {
+	case 0:
+		return OFF_NOTATION
+	case NUM_PLAYABLE_POINTS + 1:
+		return BAR_NOTATION
+	default:
+		return strconv.Itoa(PointNumber(idx, color))
+	}
+}
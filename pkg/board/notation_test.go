@@ -0,0 +1,111 @@
+package board
+
+import "testing"
+
+type notationTest struct {
+	board            Board
+	moveRoll         MoveRoll
+	expectedNotation string
+}
+
+func TestMoveRollNotation(t *testing.T) {
+	for _, test := range makeNotationTests() {
+		if output := test.moveRoll.Notation(test.board); output != test.expectedNotation {
+			t.Errorf("Output %q not equal to expected %q", output, test.expectedNotation)
+		}
+	}
+}
+
+func TestPointNumber(t *testing.T) {
+	for idx := TO_INDEX_FOR_BEARING_OFF; idx < NUM_PLAYABLE_POINTS; idx++ {
+		for _, color := range []Color{COLOR_WHITE, COLOR_BLACK} {
+			if output := PointIndexFromNumber(PointNumber(idx, color), color); output != idx {
+				t.Errorf("Output %d not equal to expected %d", output, idx)
+			}
+		}
+	}
+	if output := PointNumber(WHITE_PIECES_BAR_POINT_INDEX, COLOR_WHITE); output != 25 {
+		t.Errorf("Output %d not equal to expected %d", output, 25)
+	}
+	if output := PointNumber(0, COLOR_BLACK); output != 24 {
+		t.Errorf("Output %d not equal to expected %d", output, 24)
+	}
+}
+
+func makeNotationTests() []notationTest {
+	// test 1 - white runs a back checker with 6-5
+	board := NewBoard(COLOR_WHITE)
+	moveRoll := MoveRoll{
+		Move{From: 23, To: 17, Type: NORMAL_MOVE},
+		Move{From: 17, To: 12, Type: NORMAL_MOVE},
+	}
+
+	// test 2 - black makes his 5 point with 3-1, moves are ordered
+	board1 := NewBoard(COLOR_BLACK)
+	moveRoll1 := MoveRoll{
+		Move{From: 18, To: 19, Type: NORMAL_MOVE},
+		Move{From: 16, To: 19, Type: NORMAL_MOVE},
+	}
+
+	// test 3 - white plays 6-6, identical moves are grouped
+	board2 := NewBoard(COLOR_WHITE)
+	moveRoll2 := MoveRoll{
+		Move{From: 12, To: 6, Type: NORMAL_MOVE},
+		Move{From: 23, To: 17, Type: NORMAL_MOVE},
+		Move{From: 12, To: 6, Type: NORMAL_MOVE},
+		Move{From: 23, To: 17, Type: NORMAL_MOVE},
+	}
+
+	// test 4 - white enters from the bar hitting a black blot
+	board3 := NewBoard(COLOR_WHITE)
+	board3.Points[7].CheckerCount -= 1
+	board3.Points[WHITE_PIECES_BAR_POINT_INDEX].CheckerCount = 1
+	board3.Points[18].CheckerCount -= 1
+	board3.Points[21].CheckerCount = 1
+	board3.Points[21].Checker.Color = COLOR_BLACK
+	moveRoll3 := MoveRoll{
+		Move{From: WHITE_PIECES_BAR_POINT_INDEX, To: 21, Type: CHECKER_ON_BAR_MOVE},
+		Move{From: 12, To: 10, Type: NORMAL_MOVE},
+	}
+
+	// test 5 - black bears off
+	board4 := DeserializeBoard("1-15:19-3/24-12 0 0 b")
+	moveRoll4 := MoveRoll{
+		Move{From: 23, To: TO_INDEX_FOR_BEARING_OFF, Type: BEARING_OFF_MOVE},
+		Move{From: 18, To: 22, Type: NORMAL_MOVE},
+	}
+
+	return []notationTest{
+		{board, moveRoll, "24/18 18/13"},
+		{board1, moveRoll1, "8/5 6/5"},
+		{board2, moveRoll2, "24/18(2) 13/7(2)"},
+		{board3, moveRoll3, "bar/22* 13/11"},
+		{board4, moveRoll4, "6/2 1/off"},
+	}
+}
+
+func TestParseMoveRollNotation(t *testing.T) {
+	for _, test := range makeNotationTests() {
+		output, err := ParseMoveRollNotation(test.expectedNotation, test.board.ColorToMove)
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+			continue
+		}
+		if !output.MakeMoveRoll(test.board).IsEqual(test.moveRoll.MakeMoveRoll(test.board)) {
+			t.Errorf("Output %v not equal to expected %v", output, test.moveRoll)
+		}
+	}
+
+	// Moves of a checker over several points are split
+	output, err := ParseMoveRollNotation("24/18/13", COLOR_WHITE)
+	expected := MoveRoll{Move{From: 23, To: 17, Type: NORMAL_MOVE}, Move{From: 17, To: 12, Type: NORMAL_MOVE}}
+	if err != nil || !output.isEqual(expected) {
+		t.Errorf("Output %v not equal to expected %v", output, expected)
+	}
+
+	for _, notation := range []string{"", "24", "24/x", "off/3", "6/bar", "13/7(5)", "bar/off"} {
+		if _, err := ParseMoveRollNotation(notation, COLOR_WHITE); err == nil {
+			t.Errorf("Expected an error for %q", notation)
+		}
+	}
+}
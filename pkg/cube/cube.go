@@ -0,0 +1,171 @@
+// Package cube evaluates doubling cube decisions: whether the player to
+// move should double and whether the opponent should take, for money
+// games with the Janowski cubeful model and at a match score with a
+// match equity table
+package cube
+
+import (
+	"errors"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+// Cube efficiency of the Janowski model: 0 for a dead cube, 1 for a fully
+// live cube, 0.68 is the usual estimate for contact positions
+const DEFAULT_CUBE_EFFICIENCY = 0.68
+
+var ErrCrawford = errors.New("the cube can not be turned in the Crawford game")
+
+type Action string
+
+const (
+	ACTION_NO_DOUBLE   Action = "no double"
+	ACTION_DOUBLE_TAKE Action = "double, take"
+	ACTION_DOUBLE_PASS Action = "double, pass"
+	// The player is too good to double: playing on for a gammon
+	// is worth more than the point the opponent would pass
+	ACTION_TOO_GOOD Action = "too good, play on"
+)
+
+// Equities of the cube actions of the player to move. Money equities are
+// normalized to a cube of value 1, match equities are match winning chances
+type Decision struct {
+	NoDouble   float64
+	DoubleTake float64
+	DoublePass float64
+	Action     Action
+	// Proper response of the opponent to a double
+	Take bool
+	// Minimum winning chances the opponent needs to take a double
+	TakePoint float64
+}
+
+// Function that returns the best action given the equities of the cube actions
+func newDecision(noDouble float64, doubleTake float64, doublePass float64, takePoint float64) Decision {
+	decision := Decision{
+		NoDouble:   noDouble,
+		DoubleTake: doubleTake,
+		DoublePass: doublePass,
+		Take:       doubleTake <= doublePass,
+		TakePoint:  takePoint,
+	}
+	double := doublePass
+	if decision.Take {
+		double = doubleTake
+	}
+	switch {
+	case double > noDouble && decision.Take:
+		decision.Action = ACTION_DOUBLE_TAKE
+	case double > noDouble:
+		decision.Action = ACTION_DOUBLE_PASS
+	case !decision.Take:
+		decision.Action = ACTION_TOO_GOOD
+	default:
+		decision.Action = ACTION_NO_DOUBLE
+	}
+	return decision
+}
+
+// AI player deciding its cube actions from the proper cube decision of the
+// position instead of always playing the proper action, e.g. to make
+// mistakes on purpose
+type Player interface {
+	// Function that decides whether the player to move doubles
+	ShouldDouble(b board.Board, decision Decision) bool
+	// Function that decides whether to take the double of the player to
+	// move, the decision is seen by the doubler
+	ShouldTake(b board.Board, decision Decision) bool
+}
+
+// Function that checks whether the proper action of a decision is to double
+func (d Decision) Double() bool {
+	return d.Action == ACTION_DOUBLE_TAKE || d.Action == ACTION_DOUBLE_PASS
+}
+
+// Function that evaluates the cube decision of the player to move in a
+// money game, the cubeless evaluation is converted to cubeful equities
+// with the Janowski model and the given cube efficiency
+func MoneyDecision(evaluation ai.Evaluation, c game.Cube, color board.Color, efficiency float64) (Decision, error) {
+	if !c.CanDouble(color) {
+		return Decision{}, game.ErrCannotDouble
+	}
+	ownership := OWNERSHIP_CENTERED
+	if !c.Centered {
+		ownership = OWNERSHIP_PLAYER
+	}
+	noDouble := CubefulEquity(evaluation, ownership, efficiency)
+	doubleTake := 2 * CubefulEquity(evaluation, OWNERSHIP_OPPONENT, efficiency)
+	return newDecision(noDouble, doubleTake, 1, TakePoint(evaluation, efficiency)), nil
+}
+
+// Owner of the cube seen by the player to move
+type Ownership int
+
+const (
+	OWNERSHIP_CENTERED Ownership = iota
+	OWNERSHIP_PLAYER
+	OWNERSHIP_OPPONENT
+)
+
+// Function that converts a cubeless evaluation to the cubeful money equity
+// of the player to move for a cube of value 1, with the Janowski model:
+// a mix of the equity with a dead cube and the equity with a fully live cube
+func CubefulEquity(evaluation ai.Evaluation, ownership Ownership, efficiency float64) float64 {
+	p := evaluation.Win
+	w, l := winValues(evaluation)
+	dead := p*w - (1-p)*l
+
+	// With a live cube the players double out their opponent as soon as
+	// they reach their cash point, the equity is piecewise linear between
+	// -L when losing for sure, -1 at the take point, 1 at the cash point
+	// and W when winning for sure, the points of the cube the player can
+	// not turn are left out
+	takePoint := (l - 0.5) / (w + l + 0.5)
+	cashPoint := (l + 1) / (w + l + 0.5)
+	live := 0.0
+	switch ownership {
+	case OWNERSHIP_CENTERED:
+		live = piecewiseLinear(p, [][2]float64{{0, -l}, {takePoint, -1}, {cashPoint, 1}, {1, w}})
+	case OWNERSHIP_PLAYER:
+		live = piecewiseLinear(p, [][2]float64{{0, -l}, {cashPoint, 1}, {1, w}})
+	case OWNERSHIP_OPPONENT:
+		live = piecewiseLinear(p, [][2]float64{{0, -l}, {takePoint, -1}, {1, w}})
+	}
+	return efficiency*live + (1-efficiency)*dead
+}
+
+// Function that returns the minimum winning chances the opponent of the
+// player to move needs to take a double in a money game, with the Janowski
+// formula (L - 0.5) / (W + L + 0.5x) from the opponent's perspective
+func TakePoint(evaluation ai.Evaluation, efficiency float64) float64 {
+	w, l := winValues(evaluation.Invert())
+	return (l - 0.5) / (w + l + 0.5*efficiency)
+}
+
+// Function that returns the average points won by the player to move when
+// winning and the average points lost when losing
+func winValues(evaluation ai.Evaluation) (float64, float64) {
+	w, l := 1.0, 1.0
+	if evaluation.Win > 0 {
+		w += (evaluation.WinGammon + evaluation.WinBackgammon) / evaluation.Win
+	}
+	if evaluation.Win < 1 {
+		l += (evaluation.LoseGammon + evaluation.LoseBackgammon) / (1 - evaluation.Win)
+	}
+	return w, l
+}
+
+// Function that returns the value at x of the polyline through the given
+// points, sorted by their first coordinate
+func piecewiseLinear(x float64, points [][2]float64) float64 {
+	for idx := 1; idx < len(points); idx++ {
+		x1, y1 := points[idx-1][0], points[idx-1][1]
+		x2, y2 := points[idx][0], points[idx][1]
+		if x <= x2 || idx == len(points)-1 {
+			return y1 + (x-x1)*(y2-y1)/(x2-x1)
+		}
+	}
+	return points[0][1]
+}
@@ -0,0 +1,139 @@
+package cube
+
+import (
+	"math"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+type decisionTest struct {
+	evaluation         ai.Evaluation
+	expectedNoDouble   float64
+	expectedDoubleTake float64
+	expectedAction     Action
+}
+
+func makeMoneyDecisionTests() []decisionTest {
+	return []decisionTest{
+		// test 1 - an even position
+		{ai.Evaluation{Win: 0.5}, 0, -0.34, ACTION_NO_DOUBLE},
+		// test 2 - a double inside the market window
+		{ai.Evaluation{Win: 0.7}, 0.68*2.0/3 + 0.32*0.4, 2 * (0.68*0.25 + 0.32*0.4), ACTION_DOUBLE_TAKE},
+		// test 3 - the opponent passes
+		{ai.Evaluation{Win: 0.8}, 0.68 + 0.32*0.6, 2 * (0.68*0.5 + 0.32*0.6), ACTION_DOUBLE_PASS},
+		// test 4 - the player plays on for the gammon, the live cube equity
+		// goes on from 1 at the cash point to W = 5/3 at p = 1
+		{ai.Evaluation{Win: 0.9, WinGammon: 0.6}, 0.68*1.485714 + 0.32*1.4, 2.732, ACTION_TOO_GOOD},
+		// test 5 - too good although the cubeless equity is below 1
+		{ai.Evaluation{Win: 0.78, WinGammon: 0.35}, 1.067686, 1.6704, ACTION_TOO_GOOD},
+	}
+}
+
+func TestMoneyDecision(t *testing.T) {
+	for idx, test := range makeMoneyDecisionTests() {
+		// ACT
+		decision, err := MoneyDecision(test.evaluation, game.NewCube(), board.COLOR_WHITE, DEFAULT_CUBE_EFFICIENCY)
+
+		// ASSERT
+		if err != nil || decision.Action != test.expectedAction {
+			t.Errorf("Test %d: output %v %v not equal to expected %v", idx+1, decision.Action, err, test.expectedAction)
+		}
+		if math.Abs(decision.NoDouble-test.expectedNoDouble) > 1e-3 || decision.DoublePass != 1 {
+			t.Errorf("Test %d: output %v not equal to expected %v", idx+1, decision.NoDouble, test.expectedNoDouble)
+		}
+		if math.Abs(decision.DoubleTake-test.expectedDoubleTake) > 1e-3 {
+			t.Errorf("Test %d: output %v not equal to expected %v", idx+1, decision.DoubleTake, test.expectedDoubleTake)
+		}
+	}
+}
+
+func TestTakePoint(t *testing.T) {
+	// ACT
+	dead := TakePoint(ai.Evaluation{Win: 0.7}, 0)
+	live := TakePoint(ai.Evaluation{Win: 0.7}, 1)
+	gammonish := TakePoint(ai.Evaluation{Win: 0.7, WinGammon: 0.2}, 0)
+
+	// ASSERT
+	if math.Abs(dead-0.25) > 1e-9 || math.Abs(live-0.2) > 1e-9 || gammonish <= dead {
+		t.Errorf("Output %v %v %v not equal to expected %v %v", dead, live, gammonish, 0.25, 0.2)
+	}
+}
+
+func TestCubefulEquityOwnership(t *testing.T) {
+	// ARRANGE
+	evaluation := ai.Evaluation{Win: 0.6, WinGammon: 0.1, LoseGammon: 0.05}
+
+	// ACT
+	centered := CubefulEquity(evaluation, OWNERSHIP_CENTERED, DEFAULT_CUBE_EFFICIENCY)
+	owned := CubefulEquity(evaluation, OWNERSHIP_PLAYER, DEFAULT_CUBE_EFFICIENCY)
+	opponent := CubefulEquity(evaluation, OWNERSHIP_OPPONENT, DEFAULT_CUBE_EFFICIENCY)
+	dead := CubefulEquity(evaluation, OWNERSHIP_CENTERED, 0)
+
+	// ASSERT
+	if !(owned > centered && centered > opponent) {
+		t.Errorf("Output %v %v %v not ordered", owned, centered, opponent)
+	}
+	if math.Abs(dead-evaluation.Equity()) > 1e-9 {
+		t.Errorf("Output %v not equal to expected %v", dead, evaluation.Equity())
+	}
+}
+
+func TestCubefulEquityCertainGammon(t *testing.T) {
+	// ARRANGE
+	gammonLoss := ai.Evaluation{LoseGammon: 1}
+	gammonWin := ai.Evaluation{Win: 1, WinGammon: 1}
+
+	// ACT & ASSERT
+	// The live cube lines go on to -L and W past the take and cash points
+	for _, ownership := range []Ownership{OWNERSHIP_CENTERED, OWNERSHIP_PLAYER, OWNERSHIP_OPPONENT} {
+		if output := CubefulEquity(gammonLoss, ownership, DEFAULT_CUBE_EFFICIENCY); math.Abs(output+2) > 1e-9 {
+			t.Errorf("Output %v not equal to expected %v", output, -2)
+		}
+		if output := CubefulEquity(gammonWin, ownership, DEFAULT_CUBE_EFFICIENCY); math.Abs(output-2) > 1e-9 {
+			t.Errorf("Output %v not equal to expected %v", output, 2)
+		}
+	}
+}
+
+func TestCannotDouble(t *testing.T) {
+	// ARRANGE
+	owned := game.Cube{Value: 2, Owner: board.COLOR_BLACK}
+
+	// ACT
+	_, moneyErr := MoneyDecision(ai.Evaluation{Win: 0.7}, owned, board.COLOR_WHITE, DEFAULT_CUBE_EFFICIENCY)
+	_, matchErr := MatchDecision(ai.Evaluation{Win: 0.7}, game.NewCube(), board.COLOR_WHITE, MatchScore{Away: 1, OpponentAway: 3, Crawford: true}, proportionalTable{})
+
+	// ASSERT
+	if moneyErr != game.ErrCannotDouble || matchErr != ErrCrawford {
+		t.Errorf("Output %v %v not equal to expected %v %v", moneyErr, matchErr, game.ErrCannotDouble, ErrCrawford)
+	}
+}
+
+// Match equity of the tests: the chances are proportional to the points the opponent needs
+type proportionalTable struct{}
+
+func (proportionalTable) MWC(away int, opponentAway int) float64 {
+	return float64(opponentAway) / float64(away+opponentAway)
+}
+
+func (proportionalTable) PostCrawfordMWC(away int, opponentAway int) float64 {
+	return proportionalTable{}.MWC(away, opponentAway)
+}
+
+func TestMatchDecision(t *testing.T) {
+	// ACT
+	decision, err := MatchDecision(ai.Evaluation{Win: 0.7}, game.NewCube(), board.COLOR_WHITE, MatchScore{Away: 2, OpponentAway: 2}, proportionalTable{})
+
+	// ASSERT
+	if err != nil || decision.Action != ACTION_DOUBLE_PASS {
+		t.Fatalf("Output %v %v not equal to expected %v", decision.Action, err, ACTION_DOUBLE_PASS)
+	}
+	expected := Decision{NoDouble: 0.7*2/3.0 + 0.3/3, DoubleTake: 0.7, DoublePass: 2 / 3.0, Action: ACTION_DOUBLE_PASS, TakePoint: 1 / 3.0}
+	if math.Abs(decision.NoDouble-expected.NoDouble) > 1e-9 || math.Abs(decision.DoubleTake-expected.DoubleTake) > 1e-9 ||
+		math.Abs(decision.DoublePass-expected.DoublePass) > 1e-9 || math.Abs(decision.TakePoint-expected.TakePoint) > 1e-9 {
+		t.Errorf("Output %+v not equal to expected %+v", decision, expected)
+	}
+}
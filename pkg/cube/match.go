@@ -0,0 +1,106 @@
+package cube
+
+import (
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+// Match winning chances at every score, e.g. a match equity table
+type MatchEquity interface {
+	// Function that returns the match winning chances of a player who needs
+	// away points to win the match against an opponent who needs
+	// opponentAway points, both are at least 1. If one of them needs
+	// 1 point the next game is the Crawford game
+	MWC(away int, opponentAway int) float64
+	// Function that returns the match winning chances after the Crawford
+	// game, one of the players needs 1 point
+	PostCrawfordMWC(away int, opponentAway int) float64
+}
+
+// Score of a match from the perspective of the player to move
+type MatchScore struct {
+	// Points the player to move needs to win the match
+	Away int
+	// Points the opponent needs to win the match
+	OpponentAway int
+	// The game is the Crawford game, the cube can not be turned
+	Crawford bool
+	// The Crawford game was played
+	PostCrawford bool
+}
+
+// Function that returns the match winning chances of the player to move
+// after a game played at the given score, the player who reached the
+// match length won
+func mwc(table MatchEquity, score MatchScore, away int, opponentAway int) float64 {
+	switch {
+	case away <= 0:
+		return 1
+	case opponentAway <= 0:
+		return 0
+	case (away == 1 || opponentAway == 1) && (score.Crawford || score.PostCrawford):
+		return table.PostCrawfordMWC(away, opponentAway)
+	default:
+		return table.MWC(away, opponentAway)
+	}
+}
+
+// Function that evaluates the cube decision of the player to move at a
+// match score. The equities are match winning chances, computed from the
+// outcome probabilities of the evaluation for the current and the doubled
+// cube value
+// NOTE: the cube is considered dead after the decision, redoubles are
+// not taken into account
+func MatchDecision(evaluation ai.Evaluation, c game.Cube, color board.Color, score MatchScore, table MatchEquity) (Decision, error) {
+	if score.Crawford {
+		return Decision{}, ErrCrawford
+	}
+	if !c.CanDouble(color) {
+		return Decision{}, game.ErrCannotDouble
+	}
+
+	noDouble := CubelessMWC(evaluation, c.Value, score, table)
+	winning, losing := conditionalMWC(evaluation, 2*c.Value, score, table)
+	doubleTake := evaluation.Win*winning + (1-evaluation.Win)*losing
+	doublePass := mwc(table, score, score.Away-c.Value, score.OpponentAway)
+
+	// The opponent takes if winning chances t of the opponent give the doubler
+	// (1 - t) * winning + t * losing at most the match winning
+	// chances of a pass
+	takePoint := 0.0
+	if winning > losing {
+		takePoint = (winning - doublePass) / (winning - losing)
+	}
+	return newDecision(noDouble, doubleTake, doublePass, takePoint), nil
+}
+
+// Function that converts the cubeless outcome probabilities of the player
+// to move to match winning chances, for a game played at the given score
+// for the given cube value
+func CubelessMWC(evaluation ai.Evaluation, value int, score MatchScore, table MatchEquity) float64 {
+	winning, losing := conditionalMWC(evaluation, value, score, table)
+	return evaluation.Win*winning + (1-evaluation.Win)*losing
+}
+
+// Function that returns the average match winning chances of the player to
+// move when winning and when losing the game for the given cube value
+func conditionalMWC(evaluation ai.Evaluation, value int, score MatchScore, table MatchEquity) (float64, float64) {
+	winning := mwc(table, score, score.Away-value, score.OpponentAway)
+	if evaluation.Win > 0 {
+		single := evaluation.Win - evaluation.WinGammon
+		gammon := evaluation.WinGammon - evaluation.WinBackgammon
+		winning = (single*mwc(table, score, score.Away-value, score.OpponentAway) +
+			gammon*mwc(table, score, score.Away-2*value, score.OpponentAway) +
+			evaluation.WinBackgammon*mwc(table, score, score.Away-3*value, score.OpponentAway)) / evaluation.Win
+	}
+	losing := mwc(table, score, score.Away, score.OpponentAway-value)
+	if evaluation.Win < 1 {
+		single := 1 - evaluation.Win - evaluation.LoseGammon
+		gammon := evaluation.LoseGammon - evaluation.LoseBackgammon
+		losing = (single*mwc(table, score, score.Away, score.OpponentAway-value) +
+			gammon*mwc(table, score, score.Away, score.OpponentAway-2*value) +
+			evaluation.LoseBackgammon*mwc(table, score, score.Away, score.OpponentAway-3*value)) / (1 - evaluation.Win)
+	}
+	return winning, losing
+}
@@ -0,0 +1,271 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+// Time left to a player between the deadline of the context of a move
+// and the fall of the flag, to answer after stopping a search
+const MOVE_DEADLINE_MARGIN = 100 * time.Millisecond
+
+var (
+	ErrTimeout            = errors.New("the player ran out of time")
+	ErrInvalidTimeControl = errors.New("invalid time control, expected e.g. fischer:5m+10s or bronstein:5m+12s")
+)
+
+type TimeControlType string
+
+const (
+	// The increment is added to the time of a player after every move
+	TIME_CONTROL_FISCHER TimeControlType = "fischer"
+	// The increment is a delay: the time a player uses for a move up to the
+	// delay is not taken from the player's time, unused delay is lost
+	TIME_CONTROL_BRONSTEIN TimeControlType = "bronstein"
+)
+
+// Rule deciding what a player who runs out of time loses
+type TimeoutRule string
+
+const (
+	// The player loses a single game, multiplied by the cube
+	TIMEOUT_RULE_SINGLE TimeoutRule = "single"
+	// The player loses the points the opponent would win by bearing off all
+	// of the opponent's checkers in the current position, e.g. a gammon if
+	// the player has not borne off any checker
+	TIMEOUT_RULE_POSITION TimeoutRule = "position"
+)
+
+type TimeControl struct {
+	Type TimeControlType
+	// Time of each player at the start of the game
+	Initial time.Duration
+	// Fischer increment or Bronstein delay
+	Increment time.Duration
+	Rule      TimeoutRule
+}
+
+// Function that parses a time control written type:initial+increment,
+// e.g. fischer:5m+10s, the timeout rule is single unless it is appended
+// after a comma, e.g. bronstein:5m+12s,position
+func ParseTimeControl(text string) (TimeControl, error) {
+	text, rule, hasRule := strings.Cut(text, ",")
+	controlType, times, ok := strings.Cut(text, ":")
+	initialText, incrementText, hasIncrement := strings.Cut(times, "+")
+	if !ok || !hasIncrement {
+		return TimeControl{}, ErrInvalidTimeControl
+	}
+	control := TimeControl{Type: TimeControlType(controlType), Rule: TIMEOUT_RULE_SINGLE}
+	if control.Type != TIME_CONTROL_FISCHER && control.Type != TIME_CONTROL_BRONSTEIN {
+		return TimeControl{}, ErrInvalidTimeControl
+	}
+	if hasRule {
+		control.Rule = TimeoutRule(rule)
+		if control.Rule != TIMEOUT_RULE_SINGLE && control.Rule != TIMEOUT_RULE_POSITION {
+			return TimeControl{}, ErrInvalidTimeControl
+		}
+	}
+	var err error
+	if control.Initial, err = time.ParseDuration(initialText); err != nil || control.Initial <= 0 {
+		return TimeControl{}, ErrInvalidTimeControl
+	}
+	if control.Increment, err = time.ParseDuration(incrementText); err != nil || control.Increment < 0 {
+		return TimeControl{}, ErrInvalidTimeControl
+	}
+	return control, nil
+}
+
+// Source of the current time, mocked in tests
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Clock whose time only moves when it is advanced
+type MockClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func NewMockClock(now time.Time) *MockClock {
+	return &MockClock{now: now}
+}
+
+func (c *MockClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *MockClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Chess clock of a game: the time of the player whose turn is running
+// goes down, the time of the other player is stopped
+type GameClock struct {
+	Control TimeControl
+	Clock   Clock
+	// Time left of each player indexed by color, without the running turn
+	Remaining [2]time.Duration
+
+	running   bool
+	color     board.Color
+	turnStart time.Time
+}
+
+// Function that creates a stopped clock, every player has the initial time
+func NewGameClock(control TimeControl, clock Clock) *GameClock {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	return &GameClock{Control: control, Clock: clock, Remaining: [2]time.Duration{control.Initial, control.Initial}}
+}
+
+// Function that starts the turn of the player with the given color
+func (c *GameClock) Start(color board.Color) {
+	c.running, c.color, c.turnStart = true, color, c.Clock.Now()
+}
+
+// Function that returns the color of the player whose turn is running
+func (c *GameClock) Running() (board.Color, bool) {
+	return c.color, c.running
+}
+
+// Function that returns the time the player whose turn is running has
+// left to finish the turn, the Bronstein delay included
+func (c *GameClock) TimeLeft() time.Duration {
+	if !c.running {
+		return 0
+	}
+	left := c.Remaining[c.color] - c.Clock.Now().Sub(c.turnStart)
+	if c.Control.Type == TIME_CONTROL_BRONSTEIN {
+		left += c.Control.Increment
+	}
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// Function that checks whether the player whose turn is running ran out of time
+func (c *GameClock) Expired() bool {
+	return c.running && c.TimeLeft() <= 0
+}
+
+// Function that ends the running turn: the time used is taken from the
+// player and the increment of the time control applied. It returns
+// ErrTimeout if the player ran out of time during the turn
+func (c *GameClock) Stop() error {
+	if !c.running {
+		return nil
+	}
+	c.running = false
+	used := c.Clock.Now().Sub(c.turnStart)
+	switch c.Control.Type {
+	case TIME_CONTROL_BRONSTEIN:
+		used -= c.Control.Increment
+		if used < 0 {
+			used = 0
+		}
+	}
+	if used >= c.Remaining[c.color] {
+		c.Remaining[c.color] = 0
+		return ErrTimeout
+	}
+	c.Remaining[c.color] -= used
+	if c.Control.Type == TIME_CONTROL_FISCHER {
+		c.Remaining[c.color] += c.Control.Increment
+	}
+	return nil
+}
+
+// Function that times the game with the given time control, the clock
+// of the player to move starts at once. While the opponent answers a
+// double the clock of the opponent runs instead
+func (g *Game) SetTimeControl(control TimeControl, clock Clock) {
+	g.Clock = NewGameClock(control, clock)
+	if !g.IsOver() {
+		g.Clock.Start(g.Board.ColorToMove)
+	}
+}
+
+// Function that ends the game if the player whose clock is running ran
+// out of time, it is meant to be called periodically while the player is
+// thinking
+func (g *Game) CheckTime() error {
+	if g.Clock == nil || g.IsOver() || !g.Clock.Expired() {
+		return nil
+	}
+	return g.stopClock()
+}
+
+// Function that returns a context whose deadline is MOVE_DEADLINE_MARGIN
+// before the player whose clock is running runs out of time, without a deadline in
+// untimed games. The deadline is in real time even if the clock is mocked
+func (g *Game) MoveContext(parent context.Context) (context.Context, context.CancelFunc) {
+	if g.Clock == nil || g.IsOver() {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, g.Clock.TimeLeft()-MOVE_DEADLINE_MARGIN)
+}
+
+// Function that stops the running clock, a player who ran out of time
+// loses by the timeout rule
+func (g *Game) stopClock() error {
+	if g.Clock == nil {
+		return nil
+	}
+	if err := g.Clock.Stop(); err != nil {
+		g.loseOnTime()
+		return err
+	}
+	return nil
+}
+
+// Function that stops the running clock and starts the clock of the player
+// with the given color, e.g. when a player doubles and the opponent has to
+// answer
+func (g *Game) switchClock(color board.Color) error {
+	if g.Clock == nil {
+		return nil
+	}
+	if err := g.stopClock(); err != nil {
+		return err
+	}
+	g.Clock.Start(color)
+	return nil
+}
+
+// Function that ends the game with the loss of the player whose clock is
+// running by the timeout rule
+func (g *Game) loseOnTime() {
+	g.pauseClock()
+	loser, _ := g.Clock.Running()
+	g.Clock.Remaining[loser] = 0
+	winner := board.Color(1 - loser)
+	points := 1
+	if g.Clock.Control.Rule == TIMEOUT_RULE_POSITION {
+		points = g.Board.WinValue(winner)
+	}
+	g.Result = &Result{Winner: winner, Points: points, TimedOut: true}
+}
+
+// Function that stops the clock of a game that ended, without a timeout
+func (g *Game) pauseClock() {
+	if g.Clock != nil {
+		g.Clock.running = false
+	}
+}
@@ -0,0 +1,320 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+var OPENING_MOVE_ROLL = board.MoveRoll{{From: 23, To: 12, Type: board.NORMAL_MOVE}}
+
+type parseTimeControlTest struct {
+	text          string
+	expected      TimeControl
+	expectedError error
+}
+
+func TestParseTimeControl(t *testing.T) {
+	for _, test := range makeParseTimeControlTests() {
+		// ACT
+		output, err := ParseTimeControl(test.text)
+
+		// ASSERT
+		if err != test.expectedError {
+			t.Errorf("Output %v not equal to expected %v for %q", err, test.expectedError, test.text)
+		}
+		if output != test.expected {
+			t.Errorf("Output %v not equal to expected %v for %q", output, test.expected, test.text)
+		}
+	}
+}
+
+func TestFischerIncrement(t *testing.T) {
+	// ARRANGE
+	clock := NewMockClock(time.Unix(0, 0))
+	g := newTimedGame(TimeControl{Type: TIME_CONTROL_FISCHER, Initial: time.Minute, Increment: 10 * time.Second}, clock)
+
+	// ACT
+	clock.Advance(15 * time.Second)
+	err := g.Play(OPENING_MOVE_ROLL)
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := [2]time.Duration{55 * time.Second, time.Minute}
+	if g.Clock.Remaining != expected {
+		t.Errorf("Output %v not equal to expected %v", g.Clock.Remaining, expected)
+	}
+	if color, running := g.Clock.Running(); !running || color != board.COLOR_BLACK {
+		t.Errorf("Output %v not equal to expected %v", color, board.COLOR_BLACK)
+	}
+}
+
+func TestBronsteinDelay(t *testing.T) {
+	for _, test := range makeBronsteinDelayTests() {
+		// ARRANGE
+		clock := NewMockClock(time.Unix(0, 0))
+		g := newTimedGame(TimeControl{Type: TIME_CONTROL_BRONSTEIN, Initial: time.Minute, Increment: 12 * time.Second}, clock)
+
+		// ACT
+		clock.Advance(test.used)
+		if output := g.Clock.TimeLeft(); output != test.expectedTimeLeft {
+			t.Errorf("Output %v not equal to expected %v", output, test.expectedTimeLeft)
+		}
+		if err := g.Play(OPENING_MOVE_ROLL); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+
+		// ASSERT
+		if output := g.Clock.Remaining[board.COLOR_WHITE]; output != test.expectedRemaining {
+			t.Errorf("Output %v not equal to expected %v", output, test.expectedRemaining)
+		}
+	}
+}
+
+func TestTimeoutLoss(t *testing.T) {
+	for _, test := range makeTimeoutLossTests() {
+		// ARRANGE
+		clock := NewMockClock(time.Unix(0, 0))
+		g := newTimedGame(TimeControl{Type: TIME_CONTROL_FISCHER, Initial: time.Minute, Rule: test.rule}, clock)
+
+		// ACT
+		clock.Advance(time.Minute)
+		err := g.Play(OPENING_MOVE_ROLL)
+
+		// ASSERT
+		if err != ErrTimeout {
+			t.Errorf("Output %v not equal to expected %v", err, ErrTimeout)
+		}
+		expected := Result{Winner: board.COLOR_BLACK, Points: test.expectedPoints, TimedOut: true}
+		if g.Result == nil || *g.Result != expected {
+			t.Errorf("Output %v not equal to expected %v", g.Result, expected)
+		}
+		if g.Board.ColorToMove != board.COLOR_WHITE {
+			t.Errorf("The move roll played after the flag fell should not be recorded")
+		}
+	}
+}
+
+func TestCheckTime(t *testing.T) {
+	// ARRANGE
+	clock := NewMockClock(time.Unix(0, 0))
+	g := newTimedGame(TimeControl{Type: TIME_CONTROL_FISCHER, Initial: time.Minute}, clock)
+
+	// ACT
+	clock.Advance(59 * time.Second)
+	before := g.CheckTime()
+	clock.Advance(time.Second)
+	after := g.CheckTime()
+
+	// ASSERT
+	if before != nil {
+		t.Errorf("Output %v not equal to expected %v", before, nil)
+	}
+	if after != ErrTimeout || !g.IsOver() || g.Result.Winner != board.COLOR_BLACK {
+		t.Errorf("Output %v not equal to expected %v", after, ErrTimeout)
+	}
+	if err := g.CheckTime(); err != nil {
+		t.Errorf("Output %v not equal to expected %v", err, nil)
+	}
+}
+
+func TestTimedGameCannotUndo(t *testing.T) {
+	// ARRANGE
+	clock := NewMockClock(time.Unix(0, 0))
+	g := newTimedGame(TimeControl{Type: TIME_CONTROL_FISCHER, Initial: time.Minute}, clock)
+	if err := g.Play(OPENING_MOVE_ROLL); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	// ACT
+	err := g.Undo()
+
+	// ASSERT
+	if err != ErrTimedUndo || g.CanUndo() {
+		t.Errorf("Output %v not equal to expected %v", err, ErrTimedUndo)
+	}
+}
+
+func TestDoubleSwitchesClock(t *testing.T) {
+	// ARRANGE
+	clock := NewMockClock(time.Unix(0, 0))
+	g := NewGame(board.NewBoard(board.COLOR_WHITE))
+	g.SetTimeControl(TimeControl{Type: TIME_CONTROL_FISCHER, Initial: time.Minute, Increment: 10 * time.Second}, clock)
+
+	// ACT
+	clock.Advance(5 * time.Second)
+	doubleErr := g.Double()
+	doubleColor, _ := g.Clock.Running()
+	clock.Advance(20 * time.Second)
+	takeErr := g.Take()
+	takeColor, running := g.Clock.Running()
+
+	// ASSERT
+	if doubleErr != nil || takeErr != nil {
+		t.Fatalf("Unexpected error %v %v", doubleErr, takeErr)
+	}
+	if doubleColor != board.COLOR_BLACK || takeColor != board.COLOR_WHITE || !running {
+		t.Errorf("Output %v %v not equal to expected %v %v", doubleColor, takeColor, board.COLOR_BLACK, board.COLOR_WHITE)
+	}
+	expected := [2]time.Duration{65 * time.Second, 50 * time.Second}
+	if g.Clock.Remaining != expected {
+		t.Errorf("Output %v not equal to expected %v", g.Clock.Remaining, expected)
+	}
+}
+
+func TestDoubleTimeout(t *testing.T) {
+	// ARRANGE
+	clock := NewMockClock(time.Unix(0, 0))
+	g := NewGame(board.NewBoard(board.COLOR_WHITE))
+	g.SetTimeControl(TimeControl{Type: TIME_CONTROL_FISCHER, Initial: time.Minute, Rule: TIMEOUT_RULE_SINGLE}, clock)
+	if err := g.Double(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	// ACT
+	// The opponent runs out of time while answering the double
+	clock.Advance(time.Minute)
+	err := g.Take()
+
+	// ASSERT
+	expected := Result{Winner: board.COLOR_WHITE, Points: 1, TimedOut: true}
+	if err != ErrTimeout || g.Result == nil || *g.Result != expected {
+		t.Errorf("Output %v %v not equal to expected %v %v", err, g.Result, ErrTimeout, expected)
+	}
+	if g.Cube.Value != 1 || g.Clock.Remaining != [2]time.Duration{time.Minute, 0} {
+		t.Errorf("Output %v %v not equal to expected %v %v", g.Cube.Value, g.Clock.Remaining, 1, [2]time.Duration{time.Minute, 0})
+	}
+}
+
+func TestMoveContextDeadline(t *testing.T) {
+	// ARRANGE
+	clock := NewMockClock(time.Unix(0, 0))
+	g := newTimedGame(TimeControl{Type: TIME_CONTROL_FISCHER, Initial: time.Minute}, clock)
+	untimed := NewGame(board.NewBoard(board.COLOR_WHITE))
+
+	// ACT
+	ctx, cancel := g.MoveContext(context.Background())
+	defer cancel()
+	end := time.Now()
+	untimedCtx, untimedCancel := untimed.MoveContext(context.Background())
+	defer untimedCancel()
+
+	// ASSERT
+	deadline, ok := ctx.Deadline()
+	if !ok || deadline.Sub(end) > time.Minute-MOVE_DEADLINE_MARGIN {
+		t.Errorf("Output %v not equal to expected %v", deadline.Sub(end), time.Minute-MOVE_DEADLINE_MARGIN)
+	}
+	if _, ok := untimedCtx.Deadline(); ok {
+		t.Errorf("An untimed game should not have a move deadline")
+	}
+}
+
+func newTimedGame(control TimeControl, clock Clock) *Game {
+	g := NewGame(board.NewBoard(board.COLOR_WHITE))
+	g.SetDice(board.DieRoll{Die1: 6, Die2: 5})
+	g.SetTimeControl(control, clock)
+	return g
+}
+
+type bronsteinDelayTest struct {
+	used              time.Duration
+	expectedTimeLeft  time.Duration
+	expectedRemaining time.Duration
+}
+
+func makeBronsteinDelayTests() []bronsteinDelayTest {
+	return []bronsteinDelayTest{
+		// The delay covers the whole move
+		{used: 5 * time.Second, expectedTimeLeft: 67 * time.Second, expectedRemaining: time.Minute},
+		// Only the time used after the delay is taken
+		{used: 20 * time.Second, expectedTimeLeft: 52 * time.Second, expectedRemaining: 52 * time.Second},
+	}
+}
+
+type timeoutLossTest struct {
+	rule           TimeoutRule
+	expectedPoints int
+}
+
+func makeTimeoutLossTests() []timeoutLossTest {
+	return []timeoutLossTest{
+		{rule: TIMEOUT_RULE_SINGLE, expectedPoints: 1},
+		// White still has checkers in black's home board
+		{rule: TIMEOUT_RULE_POSITION, expectedPoints: 3},
+	}
+}
+
+func makeParseTimeControlTests() []parseTimeControlTest {
+	return []parseTimeControlTest{
+		{
+			text:     "fischer:5m+10s",
+			expected: TimeControl{Type: TIME_CONTROL_FISCHER, Initial: 5 * time.Minute, Increment: 10 * time.Second, Rule: TIMEOUT_RULE_SINGLE},
+		},
+		{
+			text:     "bronstein:90s+12s,position",
+			expected: TimeControl{Type: TIME_CONTROL_BRONSTEIN, Initial: 90 * time.Second, Increment: 12 * time.Second, Rule: TIMEOUT_RULE_POSITION},
+		},
+		{text: "fischer:5m", expectedError: ErrInvalidTimeControl},
+		{text: "hourglass:5m+10s", expectedError: ErrInvalidTimeControl},
+		{text: "fischer:5m+10s,gammon", expectedError: ErrInvalidTimeControl},
+		{text: "fischer:0s+10s", expectedError: ErrInvalidTimeControl},
+		{text: "fischer:5m+ten", expectedError: ErrInvalidTimeControl},
+	}
+}
+
+// AI that is still thinking at the deadline of its move
+type stuckAI struct{}
+
+func (stuckAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestPlayAITimeout(t *testing.T) {
+	// ARRANGE
+	g := newTimedGame(TimeControl{Type: TIME_CONTROL_FISCHER, Initial: MOVE_DEADLINE_MARGIN + 50*time.Millisecond}, SystemClock{})
+
+	// ACT
+	_, err := g.PlayAI(context.Background(), AIPlayer{Color: board.COLOR_WHITE, AI: stuckAI{}})
+
+	// ASSERT
+	if err != ErrTimeout {
+		t.Errorf("Output %v not equal to expected %v", err, ErrTimeout)
+	}
+	expected := Result{Winner: board.COLOR_BLACK, Points: 1, TimedOut: true}
+	if g.Result == nil || *g.Result != expected {
+		t.Errorf("Output %v not equal to expected %v", g.Result, expected)
+	}
+}
+
+// AI player implementing only GetMove, stuck thinking
+type stuckPlayer struct{}
+
+func (stuckPlayer) GetColor() board.Color {
+	return board.COLOR_WHITE
+}
+
+func (stuckPlayer) GetMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestPlayAIPlayerTimeout(t *testing.T) {
+	// ARRANGE
+	g := newTimedGame(TimeControl{Type: TIME_CONTROL_FISCHER, Initial: MOVE_DEADLINE_MARGIN + 50*time.Millisecond}, SystemClock{})
+
+	// ACT
+	// The player without a context is waited for until the deadline
+	start := time.Now()
+	_, err := g.PlayAI(context.Background(), stuckPlayer{})
+	elapsed := time.Since(start)
+
+	// ASSERT
+	if err != ErrTimeout || elapsed > 500*time.Millisecond {
+		t.Errorf("Output %v %v not equal to expected %v", err, elapsed, ErrTimeout)
+	}
+}
@@ -0,0 +1,41 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+type Dice interface {
+	Roll() board.DieRoll
+}
+
+// Dice rolled with a seeded pseudo random generator, two dice created
+// with the same seed roll the same sequence
+// NOTE: not safe for concurrent use
+type RandomDice struct {
+	rng *rand.Rand
+}
+
+func NewRandomDice(seed int64) *RandomDice {
+	return &RandomDice{rand.New(rand.NewSource(seed))}
+}
+
+func (d *RandomDice) Roll() board.DieRoll {
+	return board.DieRoll{Die1: d.rng.Intn(6) + 1, Die2: d.rng.Intn(6) + 1}
+}
+
+// Function that rolls the opening roll of a game: each player rolls one
+// die, re-rolling on ties, and the player with the higher die starts the
+// game playing both dice. Die1 is white's die and Die2 is black's die
+func OpeningRoll(dice Dice) (board.Color, board.DieRoll) {
+	for {
+		roll := dice.Roll()
+		if roll.Die1 > roll.Die2 {
+			return board.COLOR_WHITE, roll
+		}
+		if roll.Die2 > roll.Die1 {
+			return board.COLOR_BLACK, roll
+		}
+	}
+}
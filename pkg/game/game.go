@@ -0,0 +1,315 @@
+package game
+
+import (
+	"errors"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+var (
+	ErrGameOver           = errors.New("the game is over")
+	ErrDiceNotRolled      = errors.New("the dice were not rolled")
+	ErrDiceAlreadyRolled  = errors.New("the dice were already rolled")
+	ErrInvalidDice        = errors.New("dice values must be between 1 and 6")
+	ErrIllegalMove        = errors.New("illegal move roll")
+	ErrNothingToUndo      = errors.New("nothing to undo")
+	ErrInvalidResignation = errors.New("a resignation must be worth 1, 2 or 3 points")
+	ErrCannotDouble       = errors.New("the player to move can not double")
+	ErrNoDoubleOffered    = errors.New("no double was offered")
+	ErrDoubleOffered      = errors.New("the double must be taken or dropped first")
+	ErrTimedUndo          = errors.New("moves can not be undone in timed games")
+)
+
+type Result struct {
+	Winner board.Color
+	// 1 for a single game, 2 for a gammon and 3 for a backgammon,
+	// the points won are multiplied by the value of the cube
+	Points   int
+	Resigned bool
+	// The loser dropped a double
+	Dropped bool
+	// The loser ran out of time
+	TimedOut bool
+}
+
+type CubeAction string
+
+const (
+	CUBE_ACTION_DOUBLE CubeAction = "double"
+	CUBE_ACTION_TAKE   CubeAction = "take"
+	CUBE_ACTION_DROP   CubeAction = "drop"
+)
+
+// Doubling cube, a centered cube can be turned by both players
+// and an owned cube only by its owner
+type Cube struct {
+	Value int
+	// Owner of the cube, only meaningful if the cube is not centered
+	Owner    board.Color
+	Centered bool
+}
+
+func NewCube() Cube {
+	return Cube{Value: 1, Centered: true}
+}
+
+// Function that checks whether the player with the given color can turn the cube
+func (c Cube) CanDouble(color board.Color) bool {
+	return c.Centered || c.Owner == color
+}
+
+type gameSnapshot struct {
+	board       board.Board
+	dice        board.DieRoll
+	rolled      bool
+	cube        Cube
+	cubeActions []CubeAction
+}
+
+// A single game of backgammon: it keeps the board, the dice rolled by
+// the player to move, the cube and the previous turns, so they can be
+// undone and recorded
+type Game struct {
+	Board board.Board
+	// Dice rolled by the player to move, only meaningful if Rolled is true
+	Dice   board.DieRoll
+	Rolled bool
+	Cube   Cube
+	// The player to move doubled and the opponent has to take or drop
+	Offered bool
+	// Result of the game, nil while the game is not over
+	Result *Result
+	// Clock of a timed game, nil if the game is not timed
+	Clock *GameClock
+	// Match the game is played in, e.g. a met.Match, nil for a money
+	// game. AI players play for its match winning chances
+	Match ai.MatchEquity
+
+	start   board.Board
+	history []gameSnapshot
+	turns   []Turn
+	// Cube actions of the turn being played
+	cubeActions []CubeAction
+}
+
+func NewGame(b board.Board) *Game {
+	return &Game{Board: b, Cube: NewCube(), start: b}
+}
+
+func (g *Game) IsOver() bool {
+	return g.Result != nil
+}
+
+// Function that rolls the dice for the player to move
+func (g *Game) Roll(dice Dice) (board.DieRoll, error) {
+	roll := dice.Roll()
+	if err := g.SetDice(roll); err != nil {
+		return board.DieRoll{}, err
+	}
+	return roll, nil
+}
+
+// Function that sets the dice of the player to move, e.g. to the
+// opening roll or to dice rolled outside of the game
+func (g *Game) SetDice(d board.DieRoll) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+	if g.Rolled {
+		return ErrDiceAlreadyRolled
+	}
+	if g.Offered {
+		return ErrDoubleOffered
+	}
+	if d.Die1 < 1 || d.Die1 > 6 || d.Die2 < 1 || d.Die2 > 6 {
+		return ErrInvalidDice
+	}
+	g.Dice = d
+	g.Rolled = true
+	return nil
+}
+
+// Function that returns the legal move rolls for the rolled dice
+func (g *Game) ValidMoves() ([]board.MoveRollResult, error) {
+	if g.IsOver() {
+		return nil, ErrGameOver
+	}
+	if !g.Rolled {
+		return nil, ErrDiceNotRolled
+	}
+	return g.Board.GetValidMoveResultsForDieRoll(g.Dice), nil
+}
+
+// Function that plays a move roll for the player to move and passes the
+// turn to the opponent. The move roll is legal if it leads to the same
+// position as one of the valid move rolls for the dice, an empty move
+// roll is only legal if there are no valid move rolls
+func (g *Game) Play(mvRoll board.MoveRoll) error {
+	results, err := g.ValidMoves()
+	if err != nil {
+		return err
+	}
+
+	nextBoard := g.Board
+	if len(results) > 0 {
+		applied, ok := applyMoveRoll(g.Board, mvRoll)
+		if !ok {
+			return ErrIllegalMove
+		}
+		position := applied.SerializeBoard()
+		found := false
+		for _, result := range results {
+			if result.Board.SerializeBoard() == position {
+				// Recorded as the legal move roll, e.g. 24/13 as 24/18 18/13
+				nextBoard, mvRoll = result.Board, result.MoveRoll
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ErrIllegalMove
+		}
+	} else if len(mvRoll) > 0 {
+		return ErrIllegalMove
+	}
+
+	if err := g.stopClock(); err != nil {
+		return err
+	}
+	g.history = append(g.history, gameSnapshot{g.Board, g.Dice, g.Rolled, g.Cube, g.cubeActions})
+	dice := g.Dice
+	g.turns = append(g.turns, Turn{g.Board.ColorToMove, g.cubeActions, &dice, mvRoll})
+	g.cubeActions = nil
+	mover := g.Board.ColorToMove
+	g.Board = nextBoard.CopyBoard()
+	g.Board.ColorToMove = board.Color(1 - mover)
+	g.Dice = board.DieRoll{}
+	g.Rolled = false
+
+	if g.Board.CheckersOff(mover) == board.INIT_NUM_CHECKERS {
+		g.Result = &Result{Winner: mover, Points: g.Board.WinValue(mover)}
+	} else if g.Clock != nil {
+		g.Clock.Start(g.Board.ColorToMove)
+	}
+	return nil
+}
+
+// Function that ends the game with the resignation of the player with
+// the given color, who gives away the given number of points
+func (g *Game) Resign(color board.Color, points int) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+	if points < 1 || points > 3 {
+		return ErrInvalidResignation
+	}
+	g.Result = &Result{Winner: board.Color(1 - color), Points: points, Resigned: true}
+	g.pauseClock()
+	return nil
+}
+
+// Function that offers a double for the player to move, before rolling
+func (g *Game) Double() error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+	if g.Rolled || g.Offered || !g.Cube.CanDouble(g.Board.ColorToMove) {
+		return ErrCannotDouble
+	}
+	if err := g.switchClock(board.Color(1 - g.Board.ColorToMove)); err != nil {
+		return err
+	}
+	g.Offered = true
+	g.cubeActions = append(g.cubeActions, CUBE_ACTION_DOUBLE)
+	return nil
+}
+
+// Function that takes the double offered by the player to move,
+// the opponent owns the cube at twice its value
+func (g *Game) Take() error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+	if !g.Offered {
+		return ErrNoDoubleOffered
+	}
+	if err := g.switchClock(g.Board.ColorToMove); err != nil {
+		return err
+	}
+	g.Cube = Cube{Value: 2 * g.Cube.Value, Owner: board.Color(1 - g.Board.ColorToMove)}
+	g.Offered = false
+	g.cubeActions = append(g.cubeActions, CUBE_ACTION_TAKE)
+	return nil
+}
+
+// Function that drops the double offered by the player to move,
+// who wins the value of the cube
+func (g *Game) Drop() error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+	if !g.Offered {
+		return ErrNoDoubleOffered
+	}
+	g.Offered = false
+	g.cubeActions = append(g.cubeActions, CUBE_ACTION_DROP)
+	g.turns = append(g.turns, Turn{Color: g.Board.ColorToMove, CubeActions: g.cubeActions})
+	g.cubeActions = nil
+	g.Result = &Result{Winner: g.Board.ColorToMove, Points: 1, Dropped: true}
+	g.pauseClock()
+	return nil
+}
+
+// Function that returns the points won by the winner of the game
+func (g *Game) PointsWon() int {
+	if !g.IsOver() {
+		return 0
+	}
+	return g.Result.Points * g.Cube.Value
+}
+
+func (g *Game) CanUndo() bool {
+	return len(g.history) > 0 && !g.IsOver() && g.Clock == nil
+}
+
+// Function that undoes the last played move roll, restoring the
+// board and the dice the move roll was played with
+func (g *Game) Undo() error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+	if g.Clock != nil {
+		return ErrTimedUndo
+	}
+	if len(g.history) == 0 {
+		return ErrNothingToUndo
+	}
+	last := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+	g.turns = g.turns[:len(g.turns)-1]
+	g.Board, g.Dice, g.Rolled = last.board, last.dice, last.rolled
+	g.Cube, g.cubeActions, g.Offered = last.cube, last.cubeActions, false
+	return nil
+}
+
+// Function that applies a move roll that might be illegal, returning
+// false instead of panicking if a move does not move a checker of
+// the player to move
+func applyMoveRoll(b board.Board, mvRoll board.MoveRoll) (board.Board, bool) {
+	for _, mv := range mvRoll {
+		if mv.From < 0 || int(mv.From) >= board.NUM_POINTS ||
+			mv.To < board.TO_INDEX_FOR_BEARING_OFF || int(mv.To) >= board.NUM_PLAYABLE_POINTS {
+			return board.Board{}, false
+		}
+		if (mv.Type == board.BEARING_OFF_MOVE) != (mv.To == board.TO_INDEX_FOR_BEARING_OFF) {
+			return board.Board{}, false
+		}
+		from := b.Points[mv.From]
+		if from.CheckerCount == 0 || from.Checker.Color != b.ColorToMove {
+			return board.Board{}, false
+		}
+		b = mv.MakeMove(b)
+	}
+	return b, true
+}
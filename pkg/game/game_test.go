@@ -0,0 +1,176 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+type fixedDice struct {
+	rolls []board.DieRoll
+}
+
+func (d *fixedDice) Roll() board.DieRoll {
+	roll := d.rolls[0]
+	d.rolls = d.rolls[1:]
+	return roll
+}
+
+type playTest struct {
+	board         board.Board
+	dice          board.DieRoll
+	moveRoll      board.MoveRoll
+	expectedError error
+}
+
+func TestGamePlay(t *testing.T) {
+	for _, test := range makePlayTests() {
+		g := NewGame(test.board)
+		g.SetDice(test.dice)
+		if output := g.Play(test.moveRoll); output != test.expectedError {
+			t.Errorf("Output %v not equal to expected %v for %v", output, test.expectedError, test.moveRoll)
+		}
+	}
+}
+
+func TestGamePlaySwitchesTurnAndUndo(t *testing.T) {
+	// ARRANGE
+	g := NewGame(board.NewBoard(board.COLOR_WHITE))
+	dice := &fixedDice{[]board.DieRoll{{Die1: 6, Die2: 5}}}
+
+	// ACT
+	if _, err := g.Roll(dice); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	err := g.Play(board.MoveRoll{{From: 23, To: 12, Type: board.NORMAL_MOVE}})
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := "6-5/8-3/13-6/24-1:1-2/12-5/17-3/19-5 0 0 b"
+	if output := g.Board.SerializeBoard(); output != expected {
+		t.Errorf("Output %q not equal to expected %q", output, expected)
+	}
+	if g.Rolled {
+		t.Errorf("Dice should not be rolled for the next player")
+	}
+
+	// ACT
+	err = g.Undo()
+
+	// ASSERT
+	if err != nil || !g.Board.IsEqual(board.NewBoard(board.COLOR_WHITE)) || g.Dice != (board.DieRoll{Die1: 6, Die2: 5}) || !g.Rolled {
+		t.Errorf("Undo did not restore the game: %v %q %v", err, g.Board.SerializeBoard(), g.Dice)
+	}
+	if err := g.Undo(); err != ErrNothingToUndo {
+		t.Errorf("Output %v not equal to expected %v", err, ErrNothingToUndo)
+	}
+}
+
+func TestGameResult(t *testing.T) {
+	// ARRANGE
+	g := NewGame(board.DeserializeBoard("1-1:13-15 0 0 w"))
+	g.SetDice(board.DieRoll{Die1: 2, Die2: 1})
+
+	// ACT
+	err := g.Play(board.MoveRoll{{From: 0, To: board.TO_INDEX_FOR_BEARING_OFF, Type: board.BEARING_OFF_MOVE}})
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := Result{Winner: board.COLOR_WHITE, Points: 2}
+	if !g.IsOver() || *g.Result != expected {
+		t.Errorf("Output %v not equal to expected %v", g.Result, expected)
+	}
+	if _, err := g.ValidMoves(); err != ErrGameOver {
+		t.Errorf("Output %v not equal to expected %v", err, ErrGameOver)
+	}
+}
+
+func TestGameResign(t *testing.T) {
+	g := NewGame(board.NewBoard(board.COLOR_BLACK))
+	if err := g.Resign(board.COLOR_BLACK, 4); err != ErrInvalidResignation {
+		t.Errorf("Output %v not equal to expected %v", err, ErrInvalidResignation)
+	}
+	if err := g.Resign(board.COLOR_BLACK, 2); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	expected := Result{Winner: board.COLOR_WHITE, Points: 2, Resigned: true}
+	if *g.Result != expected {
+		t.Errorf("Output %v not equal to expected %v", *g.Result, expected)
+	}
+}
+
+func TestOpeningRoll(t *testing.T) {
+	dice := &fixedDice{[]board.DieRoll{{Die1: 3, Die2: 3}, {Die1: 2, Die2: 5}}}
+	color, roll := OpeningRoll(dice)
+	if color != board.COLOR_BLACK || roll != (board.DieRoll{Die1: 2, Die2: 5}) {
+		t.Errorf("Output %v %v not equal to expected %v %v", color, roll, board.COLOR_BLACK, board.DieRoll{Die1: 2, Die2: 5})
+	}
+}
+
+func makePlayTests() []playTest {
+	// test 1 - a legal move roll
+	board1 := board.NewBoard(board.COLOR_WHITE)
+	moveRoll1 := board.MoveRoll{{From: 7, To: 4, Type: board.NORMAL_MOVE}, {From: 5, To: 4, Type: board.NORMAL_MOVE}}
+
+	// test 2 - the same move roll played in a different order is legal
+	moveRoll2 := board.MoveRoll{{From: 5, To: 4, Type: board.NORMAL_MOVE}, {From: 7, To: 4, Type: board.NORMAL_MOVE}}
+
+	// test 3 - a move roll that does not use the dice
+	moveRoll3 := board.MoveRoll{{From: 7, To: 3, Type: board.NORMAL_MOVE}, {From: 5, To: 4, Type: board.NORMAL_MOVE}}
+
+	// test 4 - a move roll moving opponent's checkers
+	moveRoll4 := board.MoveRoll{{From: 0, To: 3, Type: board.NORMAL_MOVE}, {From: 0, To: 1, Type: board.NORMAL_MOVE}}
+
+	// test 5 - playing only one die when both can be played
+	moveRoll5 := board.MoveRoll{{From: 7, To: 4, Type: board.NORMAL_MOVE}}
+
+	// test 6 - passing when there are no legal moves
+	board6 := board.NewBoard(board.COLOR_WHITE)
+	board6.Points[12].CheckerCount = 0
+	board6.Points[23].CheckerCount = 0
+	board6.Points[7].CheckerCount = 0
+	board6.Points[5].CheckerCount = 0
+	board6.Points[0].Checker.Color = board.COLOR_WHITE
+	board6.Points[19].CheckerCount = 1
+	board6.Points[19].Checker.Color = board.COLOR_WHITE
+
+	return []playTest{
+		{board1, board.DieRoll{Die1: 3, Die2: 1}, moveRoll1, nil},
+		{board1, board.DieRoll{Die1: 3, Die2: 1}, moveRoll2, nil},
+		{board1, board.DieRoll{Die1: 3, Die2: 1}, moveRoll3, ErrIllegalMove},
+		{board1, board.DieRoll{Die1: 3, Die2: 1}, moveRoll4, ErrIllegalMove},
+		{board1, board.DieRoll{Die1: 3, Die2: 1}, moveRoll5, ErrIllegalMove},
+		{board6, board.DieRoll{Die1: 3, Die2: 1}, board.MoveRoll{}, nil},
+		{board1, board.DieRoll{Die1: 3, Die2: 1}, board.MoveRoll{}, ErrIllegalMove},
+	}
+}
+
+func TestGameCube(t *testing.T) {
+	// ARRANGE
+	g := NewGame(board.NewBoard(board.COLOR_WHITE))
+
+	// ACT & ASSERT
+	if err := g.Take(); err != ErrNoDoubleOffered {
+		t.Errorf("Output %v not equal to expected %v", err, ErrNoDoubleOffered)
+	}
+	if err := g.Double(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := g.SetDice(board.DieRoll{Die1: 6, Die2: 5}); err != ErrDoubleOffered {
+		t.Errorf("Output %v not equal to expected %v", err, ErrDoubleOffered)
+	}
+	if err := g.Take(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := Cube{Value: 2, Owner: board.COLOR_BLACK}
+	if g.Cube != expected {
+		t.Errorf("Output %v not equal to expected %v", g.Cube, expected)
+	}
+	if err := g.Double(); err != ErrCannotDouble {
+		t.Errorf("Output %v not equal to expected %v", err, ErrCannotDouble)
+	}
+}
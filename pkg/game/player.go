@@ -1,6 +1,8 @@
 package game
 
 import (
+	"context"
+
 	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
 	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
 )
@@ -14,6 +16,13 @@ type IAIPlayer interface {
 	GetMove(b board.Board, d board.DieRoll) board.MoveRoll
 }
 
+// AI player that stops thinking when the context is done, an error is
+// returned if it could not choose a move roll
+type IProgressAIPlayer interface {
+	IAIPlayer
+	GetMoveProgress(ctx context.Context, b board.Board, d board.DieRoll) (board.MoveRoll, error)
+}
+
 type HumanPlayer struct {
 	Color board.Color
 	Name  string
@@ -26,12 +35,66 @@ func (hp HumanPlayer) GetColor() board.Color {
 type AIPlayer struct {
 	Color board.Color
 	AI    ai.AI
+	// Optional function receiving the progress of the AI while it is thinking
+	Progress ai.ProgressFunc
+}
+
+func (player AIPlayer) GetColor() board.Color {
+	return player.Color
+}
+
+func (player AIPlayer) GetMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	return player.AI.ChooseMove(b, d)
 }
 
-func (ai AIPlayer) GetColor() board.Color {
-	return ai.Color
+// Function that chooses the move roll of the AI with ai.Choose, reporting
+// the progress of the AI to the Progress function
+func (player AIPlayer) GetMoveProgress(ctx context.Context, b board.Board, d board.DieRoll) (board.MoveRoll, error) {
+	return ai.Choose(ctx, player.AI, b, d, player.Progress)
 }
 
-func (ai AIPlayer) GetMove(b board.Board, d board.DieRoll) board.MoveRoll {
-	return ai.AI.ChooseMove(b, d)
+// Adapter of an AI player to an ai.AI, so the wait for its move roll can
+// be cancelled with ai.Choose
+type playerAI struct {
+	IAIPlayer
+}
+
+func (player playerAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	return player.GetMove(b, d)
+}
+
+// Function that asks the player for its move roll with the context
+func getMove(ctx context.Context, player IAIPlayer, b board.Board, d board.DieRoll) (board.MoveRoll, error) {
+	if p, ok := player.(IProgressAIPlayer); ok {
+		return p.GetMoveProgress(ctx, b, d)
+	}
+	return ai.Choose(ctx, playerAI{player}, b, d, nil)
+}
+
+// Function that plays the turn of an AI player with the rolled dice, the
+// AI gets the time left on the clock of a timed game as the deadline of
+// the context and the match of the game with ai.WithMatch, players that
+// do not implement IProgressAIPlayer are waited for until the deadline.
+// It returns ErrTimeout if the AI ran out of time and the error of the AI
+// if it could not choose a move roll
+func (g *Game) PlayAI(ctx context.Context, player IAIPlayer) (board.MoveRoll, error) {
+	if !g.Rolled {
+		return nil, ErrDiceNotRolled
+	}
+	moveCtx, cancel := g.MoveContext(ctx)
+	defer cancel()
+	if g.Match != nil {
+		moveCtx = ai.WithMatch(moveCtx, g.Match, g.Cube.Value)
+	}
+
+	mvRoll, err := getMove(moveCtx, player, g.Board, g.Dice)
+	if err != nil {
+		// An AI without a move roll at the deadline of its move ran out of time
+		if g.Clock != nil && ctx.Err() == nil && moveCtx.Err() == context.DeadlineExceeded {
+			g.loseOnTime()
+			return nil, ErrTimeout
+		}
+		return nil, err
+	}
+	return mvRoll, g.Play(mvRoll)
 }
@@ -0,0 +1,294 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+// Version of the record format, stored in every record
+const RECORD_VERSION = 1
+
+// Ways a recorded game can end
+const (
+	END_BEAR_OFF    = "bearoff"
+	END_RESIGNATION = "resignation"
+	END_DROP        = "drop"
+)
+
+var ErrInvalidRecord = errors.New("invalid game record")
+
+type Players struct {
+	White string `json:"white"`
+	Black string `json:"black"`
+}
+
+// A turn of a player: the cube actions taken before rolling, i.e. a double
+// and the answer of the opponent, the dice and the move roll played. The
+// turn of a dropped double has no dice
+type Turn struct {
+	Color       board.Color    `json:"color"`
+	CubeActions []CubeAction   `json:"cubeActions,omitempty"`
+	Dice        *board.DieRoll `json:"dice,omitempty"`
+	MoveRoll    board.MoveRoll `json:"moveRoll"`
+}
+
+type RecordResult struct {
+	Winner board.Color `json:"winner"`
+	// Points won, including the value of the cube
+	Points int `json:"points"`
+	// END_BEAR_OFF, END_RESIGNATION or END_DROP
+	Reason string `json:"reason"`
+}
+
+// Record of a game, from its starting position to its result. The result
+// is nil for a game that is not over
+type Record struct {
+	Version int           `json:"version"`
+	Players Players       `json:"players"`
+	Start   board.Board   `json:"start"`
+	Turns   []Turn        `json:"turns"`
+	Result  *RecordResult `json:"result"`
+}
+
+// Function that returns the record of the turns played so far
+func (g *Game) Record(players Players) Record {
+	turns := append([]Turn{}, g.turns...)
+	if len(g.cubeActions) > 0 {
+		// A double waiting for an answer
+		turns = append(turns, Turn{Color: g.Board.ColorToMove, CubeActions: g.cubeActions})
+	}
+	record := Record{Version: RECORD_VERSION, Players: players, Start: g.start, Turns: turns}
+	if g.IsOver() {
+		reason := END_BEAR_OFF
+		if g.Result.Dropped {
+			reason = END_DROP
+		} else if g.Result.Resigned {
+			reason = END_RESIGNATION
+		}
+		record.Result = &RecordResult{g.Result.Winner, g.PointsWon(), reason}
+	}
+	return record
+}
+
+func (r Record) Save(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func (r Record) SaveToFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Function that reads a record written by Save, the turns
+// are verified by replaying them, see NewReplayer
+func LoadRecord(rd io.Reader) (Record, error) {
+	record := Record{}
+	if err := json.NewDecoder(rd).Decode(&record); err != nil {
+		return Record{}, err
+	}
+	if record.Version != RECORD_VERSION {
+		return Record{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidRecord, record.Version)
+	}
+	if _, err := NewReplayer(record); err != nil {
+		return Record{}, err
+	}
+	return record, nil
+}
+
+func LoadRecordFromFile(path string) (Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Record{}, err
+	}
+	defer f.Close()
+	return LoadRecord(f)
+}
+
+// Position of a replayed game after a number of turns
+type ReplayPosition struct {
+	Board board.Board
+	Cube  Cube
+	// Turn played to reach the position, nil for the starting position
+	Turn *Turn
+}
+
+// Steps through a recorded game. Every turn is replayed when the replayer
+// is created, so stepping forward and backward never fails
+type Replayer struct {
+	record    Record
+	positions []ReplayPosition
+	current   int
+}
+
+// Function that replays a record, checking that every cube action is
+// allowed, that every move roll is legal for its dice, i.e. leads to the
+// position of one of the move rolls returned by GetValidMovesForDieRoll,
+// and that the result matches the last position
+func NewReplayer(record Record) (*Replayer, error) {
+	if err := validateStart(record.Start); err != nil {
+		return nil, err
+	}
+	g := NewGame(record.Start)
+	positions := []ReplayPosition{{g.Board, g.Cube, nil}}
+	for idx := range record.Turns {
+		turn := &record.Turns[idx]
+		if err := replayTurn(g, *turn); err != nil {
+			return nil, fmt.Errorf("%w: turn %d: %v", ErrInvalidRecord, idx+1, err)
+		}
+		if g.IsOver() && idx != len(record.Turns)-1 {
+			return nil, fmt.Errorf("%w: turn %d: the game is over", ErrInvalidRecord, idx+2)
+		}
+		positions = append(positions, ReplayPosition{g.Board, g.Cube, turn})
+	}
+	if err := validateResult(g, record.Result); err != nil {
+		return nil, err
+	}
+	return &Replayer{record, positions, 0}, nil
+}
+
+// Function that plays a recorded turn on the game
+func replayTurn(g *Game, turn Turn) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+	if turn.Color != g.Board.ColorToMove {
+		return fmt.Errorf("expected a turn of %v", g.Board.ColorToMove)
+	}
+	for idx, action := range turn.CubeActions {
+		var err error
+		switch {
+		case idx == 0 && action == CUBE_ACTION_DOUBLE:
+			err = g.Double()
+		case idx == 1 && action == CUBE_ACTION_TAKE:
+			err = g.Take()
+		case idx == 1 && action == CUBE_ACTION_DROP:
+			err = g.Drop()
+		default:
+			err = fmt.Errorf("unexpected cube action %q", action)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if g.IsOver() {
+		if turn.Dice != nil || len(turn.MoveRoll) > 0 {
+			return errors.New("a dropped double ends the game")
+		}
+		return nil
+	}
+	if g.Offered {
+		return errors.New("the double was not answered")
+	}
+	if turn.Dice == nil {
+		return ErrDiceNotRolled
+	}
+	if err := g.SetDice(*turn.Dice); err != nil {
+		return err
+	}
+	return g.Play(turn.MoveRoll)
+}
+
+func validateStart(b board.Board) error {
+	if len(b.Points) != board.NUM_POINTS {
+		return fmt.Errorf("%w: missing starting position", ErrInvalidRecord)
+	}
+	if b.CheckersOff(board.COLOR_WHITE) == board.INIT_NUM_CHECKERS || b.CheckersOff(board.COLOR_BLACK) == board.INIT_NUM_CHECKERS {
+		return fmt.Errorf("%w: the game is over in the starting position", ErrInvalidRecord)
+	}
+	return nil
+}
+
+// Function that checks the recorded result against the replayed game
+func validateResult(g *Game, result *RecordResult) error {
+	invalid := func(message string) error {
+		return fmt.Errorf("%w: result: %s", ErrInvalidRecord, message)
+	}
+	if result == nil {
+		if g.IsOver() {
+			return invalid("missing result of a finished game")
+		}
+		return nil
+	}
+
+	switch result.Reason {
+	case END_BEAR_OFF, END_DROP:
+		if !g.IsOver() || g.Result.Resigned {
+			return invalid("the game did not end with a " + result.Reason)
+		}
+		if result.Winner != g.Result.Winner || result.Points != g.PointsWon() {
+			return invalid(fmt.Sprintf("expected %d points for %v", g.PointsWon(), g.Result.Winner))
+		}
+	case END_RESIGNATION:
+		if g.IsOver() {
+			return invalid("the game did not end with a resignation")
+		}
+		if result.Points%g.Cube.Value != 0 {
+			return invalid(fmt.Sprintf("points must be a multiple of the cube value %d", g.Cube.Value))
+		}
+		if err := g.Resign(board.Color(1-result.Winner), result.Points/g.Cube.Value); err != nil {
+			return invalid(err.Error())
+		}
+	default:
+		return invalid(fmt.Sprintf("unknown reason %q", result.Reason))
+	}
+	return nil
+}
+
+func (r *Replayer) Record() Record {
+	return r.record
+}
+
+// Function that returns the number of turns of the record
+func (r *Replayer) Len() int {
+	return len(r.positions) - 1
+}
+
+// Function that returns the number of turns played to reach the current position
+func (r *Replayer) Index() int {
+	return r.current
+}
+
+func (r *Replayer) Position() ReplayPosition {
+	return r.positions[r.current]
+}
+
+// Function that plays the next turn, returning false at the end of the game
+func (r *Replayer) Forward() bool {
+	if r.current == r.Len() {
+		return false
+	}
+	r.current += 1
+	return true
+}
+
+// Function that undoes the last turn, returning false at the starting position
+func (r *Replayer) Backward() bool {
+	if r.current == 0 {
+		return false
+	}
+	r.current -= 1
+	return true
+}
+
+// Function that moves to the position reached after the given number of turns
+func (r *Replayer) Seek(index int) error {
+	if index < 0 || index > r.Len() {
+		return fmt.Errorf("turn %d out of range [0, %d]", index, r.Len())
+	}
+	r.current = index
+	return nil
+}
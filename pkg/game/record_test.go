@@ -0,0 +1,149 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+var RECORD_PLAYERS = Players{White: "alice", Black: "bob"}
+
+// Function that plays the opening 6-5 of white, a double of black that white
+// takes and the 3-1 of black
+func playRecordedGame(t *testing.T) *Game {
+	g := NewGame(board.NewBoard(board.COLOR_WHITE))
+	g.SetDice(board.DieRoll{Die1: 6, Die2: 5})
+	if err := g.Play(board.MoveRoll{{From: 23, To: 17, Type: board.NORMAL_MOVE}, {From: 17, To: 12, Type: board.NORMAL_MOVE}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := g.Double(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := g.Take(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	g.SetDice(board.DieRoll{Die1: 3, Die2: 1})
+	if err := g.Play(board.MoveRoll{{From: 16, To: 19, Type: board.NORMAL_MOVE}, {From: 18, To: 19, Type: board.NORMAL_MOVE}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return g
+}
+
+func TestRecordSaveAndLoad(t *testing.T) {
+	// ARRANGE
+	g := playRecordedGame(t)
+	g.Resign(board.COLOR_WHITE, 2)
+	record := g.Record(RECORD_PLAYERS)
+	path := filepath.Join(t.TempDir(), "game.json")
+
+	// ACT
+	err := record.SaveToFile(path)
+	loaded, loadErr := LoadRecordFromFile(path)
+
+	// ASSERT
+	if err != nil || loadErr != nil {
+		t.Fatalf("Unexpected errors %v %v", err, loadErr)
+	}
+	expected := RecordResult{Winner: board.COLOR_BLACK, Points: 4, Reason: END_RESIGNATION}
+	if loaded.Result == nil || *loaded.Result != expected {
+		t.Errorf("Output %v not equal to expected %v", loaded.Result, expected)
+	}
+	if len(loaded.Turns) != 2 || loaded.Players != RECORD_PLAYERS || !reflect.DeepEqual(loaded.Turns[1].CubeActions, []CubeAction{CUBE_ACTION_DOUBLE, CUBE_ACTION_TAKE}) {
+		t.Errorf("Output %v not equal to expected %v", loaded.Turns, record.Turns)
+	}
+}
+
+func TestRecordLegalMoveRoll(t *testing.T) {
+	// ARRANGE
+	g := NewGame(board.NewBoard(board.COLOR_WHITE))
+	g.SetDice(board.DieRoll{Die1: 6, Die2: 5})
+
+	// ACT
+	err := g.Play(board.MoveRoll{{From: 23, To: 12, Type: board.NORMAL_MOVE}})
+	record := g.Record(RECORD_PLAYERS)
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := board.MoveRoll{{From: 23, To: 17, Type: board.NORMAL_MOVE}, {From: 17, To: 12, Type: board.NORMAL_MOVE}}
+	if len(record.Turns) != 1 || !reflect.DeepEqual(record.Turns[0].MoveRoll, expected) {
+		t.Errorf("Output %v not equal to expected %v", record.Turns, expected)
+	}
+}
+
+func TestReplayerForwardAndBackward(t *testing.T) {
+	// ARRANGE
+	record := playRecordedGame(t).Record(RECORD_PLAYERS)
+	replayer, err := NewReplayer(record)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := []string{
+		"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w",
+		"6-5/8-3/13-6/24-1:1-2/12-5/17-3/19-5 0 0 b",
+		"6-5/8-3/13-6/24-1:1-2/12-5/17-2/19-4/20-2 0 0 w",
+	}
+
+	// ACT & ASSERT
+	for idx := 0; idx < len(expected); idx++ {
+		if output := replayer.Position().Board.SerializeBoard(); output != expected[idx] {
+			t.Errorf("Output %v not equal to expected %v", output, expected[idx])
+		}
+		if replayer.Forward() != (idx < len(expected)-1) {
+			t.Errorf("Unexpected forward at turn %d", idx)
+		}
+	}
+	if cube := replayer.Position().Cube; cube.Value != 2 || cube.Owner != board.COLOR_WHITE {
+		t.Errorf("Output %v not equal to expected %v", cube, Cube{Value: 2, Owner: board.COLOR_WHITE})
+	}
+	replayer.Backward()
+	if output := replayer.Position().Board.SerializeBoard(); output != expected[1] || replayer.Position().Cube != NewCube() {
+		t.Errorf("Output %v not equal to expected %v", output, expected[1])
+	}
+	if replayer.Seek(0) != nil || replayer.Backward() || replayer.Seek(3) == nil {
+		t.Errorf("Unexpected seek at turn %d", replayer.Index())
+	}
+}
+
+func TestReplayerRejectsIllegalMove(t *testing.T) {
+	// ARRANGE
+	record := playRecordedGame(t).Record(RECORD_PLAYERS)
+	record.Turns[1].MoveRoll = board.MoveRoll{{From: 16, To: 21, Type: board.NORMAL_MOVE}}
+	data := bytes.Buffer{}
+	record.Save(&data)
+
+	// ACT
+	_, err := LoadRecord(&data)
+
+	// ASSERT
+	if !errors.Is(err, ErrInvalidRecord) {
+		t.Errorf("Output %v not equal to expected %v", err, ErrInvalidRecord)
+	}
+}
+
+func TestRecordDrop(t *testing.T) {
+	// ARRANGE
+	g := playRecordedGame(t)
+	g.Double()
+	g.Drop()
+	record := g.Record(RECORD_PLAYERS)
+
+	// ACT
+	_, err := NewReplayer(record)
+	record.Result.Points = 4
+	_, wrongPointsErr := NewReplayer(record)
+
+	// ASSERT
+	expected := RecordResult{Winner: board.COLOR_WHITE, Points: 2, Reason: END_DROP}
+	if err != nil || g.PointsWon() != 2 || len(record.Turns) != 3 {
+		t.Errorf("Output %v %v not equal to expected %v", err, g.PointsWon(), expected)
+	}
+	if !errors.Is(wrongPointsErr, ErrInvalidRecord) {
+		t.Errorf("Output %v not equal to expected %v", wrongPointsErr, ErrInvalidRecord)
+	}
+}
@@ -0,0 +1,358 @@
+package matchfile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+// Width of the left column of the move lines of a .mat file
+const MAT_COLUMN_WIDTH = 28
+
+// Offset of the right column of a .mat file, after the move number and the left column
+const MAT_RIGHT_COLUMN = 5 + MAT_COLUMN_WIDTH + 1
+
+// Indent of a win written in the left column
+const MAT_WIN_INDENT = 6
+
+const (
+	MAT_DOUBLES = "Doubles"
+	MAT_TAKES   = "Takes"
+	MAT_DROPS   = "Drops"
+	MAT_WINS    = "Wins"
+)
+
+var (
+	matLengthPattern = regexp.MustCompile(`^\s*(\d+) point match\s*$`)
+	matGamePattern   = regexp.MustCompile(`^\s*Game (\d+)\s*$`)
+	matScorePattern  = regexp.MustCompile(`^\s*(\S.*?)\s*:\s*(\d+)\s+(\S.*?)\s*:\s*(\d+)\s*$`)
+	matMovePattern   = regexp.MustCompile(`^\s*\d+\)`)
+	matWinPattern    = regexp.MustCompile(`^\s*Wins (\d+) points?( and the match)?\s*$`)
+	matDicePattern   = regexp.MustCompile(`^([1-6])([1-6]):$`)
+	matDoublePattern = regexp.MustCompile(`^Doubles => (\d+)$`)
+	matTokenPattern  = regexp.MustCompile(`\S+`)
+)
+
+// A player action of a .mat file: a roll, a double or its answer
+type matAction struct {
+	color board.Color
+	text  string
+}
+
+// Parser of a .mat file, it keeps the game being imported
+type matParser struct {
+	match  Match
+	line   int
+	game   *gameBuilder
+	score  Score
+	scored bool
+	// Offset splitting the left and the right column, from the score line
+	middle int
+}
+
+// Function that imports a match in the text format of Jellyfish and
+// GNU Backgammon, e.g.
+//
+//	5 point match
+//
+//	Game 1
+//	alice : 0                         bob : 0
+//	 1) 31: 8/5 6/5                   52: 13/8 13/11
+//	 2)  Doubles => 2                  Takes
+//	 3) 64: 24/18 13/9
+//
+// Every move is validated by replaying the games, invalid files
+// return a *ParseError with the line of the error
+func ReadMat(r io.Reader) (Match, error) {
+	p := &matParser{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		p.line += 1
+		if err := p.parseLine(strings.TrimRight(scanner.Text(), " \t\r")); err != nil {
+			return Match{}, &ParseError{p.line, err}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Match{}, err
+	}
+	if err := p.endGame(); err != nil {
+		return Match{}, &ParseError{p.line, err}
+	}
+	if len(p.match.Games) == 0 {
+		return Match{}, &ParseError{p.line, errors.New("no games found")}
+	}
+	return p.match, nil
+}
+
+func (p *matParser) parseLine(line string) error {
+	switch {
+	case strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), ";"):
+		return nil
+	case matLengthPattern.MatchString(line):
+		if p.game != nil || len(p.match.Games) > 0 {
+			return errors.New("the match length must precede the games")
+		}
+		p.match.Length, _ = strconv.Atoi(matLengthPattern.FindStringSubmatch(line)[1])
+		return nil
+	case matGamePattern.MatchString(line):
+		if err := p.endGame(); err != nil {
+			return err
+		}
+		p.game, p.scored = nil, false
+		p.match.Games = append(p.match.Games, MatchGame{})
+		return nil
+	case len(p.match.Games) == 0:
+		return fmt.Errorf("unexpected line %q before the first game", strings.TrimSpace(line))
+	case !p.scored:
+		return p.parseScore(line)
+	case matMovePattern.MatchString(line):
+		actions, err := p.splitActions(line)
+		if err != nil {
+			return err
+		}
+		for _, action := range actions {
+			if err := p.play(action); err != nil {
+				return err
+			}
+		}
+		return nil
+	case matWinPattern.MatchString(line):
+		return p.play(matAction{p.column(line), strings.TrimSpace(line)})
+	default:
+		return fmt.Errorf("unexpected line %q", strings.TrimSpace(line))
+	}
+}
+
+// Function that parses the players and their scores before a game
+func (p *matParser) parseScore(line string) error {
+	groups := matScorePattern.FindStringSubmatchIndex(line)
+	if groups == nil {
+		return fmt.Errorf("expected the score of the players, got %q", strings.TrimSpace(line))
+	}
+	white, black := line[groups[2]:groups[3]], line[groups[6]:groups[7]]
+	whiteScore, _ := strconv.Atoi(line[groups[4]:groups[5]])
+	blackScore, _ := strconv.Atoi(line[groups[8]:groups[9]])
+
+	score := Score{whiteScore, blackScore}
+	if len(p.match.Games) == 1 {
+		p.match.Players = game.Players{White: white, Black: black}
+	} else if (game.Players{White: white, Black: black}) != p.match.Players {
+		return fmt.Errorf("the players changed to %s and %s", white, black)
+	} else if score != p.score {
+		return fmt.Errorf("the score %d-%d does not follow the previous games, expected %d-%d",
+			score.White, score.Black, p.score.White, p.score.Black)
+	}
+	p.match.Games[len(p.match.Games)-1].Score = score
+	p.score, p.scored = score, true
+	p.middle = (groups[2] + groups[6]) / 2
+	return nil
+}
+
+// Function that returns the color of the column the text of a line starts in
+func (p *matParser) column(line string) board.Color {
+	if len(line)-len(strings.TrimLeft(line, " \t")) < p.middle {
+		return board.COLOR_WHITE
+	}
+	return board.COLOR_BLACK
+}
+
+// Function that splits a move line into the actions of its columns
+func (p *matParser) splitActions(line string) ([]matAction, error) {
+	prefix := matMovePattern.FindString(line)
+	rest := line[len(prefix):]
+	tokens := matTokenPattern.FindAllStringIndex(rest, -1)
+
+	actions := []matAction{}
+	for _, token := range tokens {
+		text := rest[token[0]:token[1]]
+		offset := len(prefix) + token[0]
+		if matDicePattern.MatchString(text) || text == MAT_DOUBLES || text == MAT_TAKES || text == MAT_DROPS || text == MAT_WINS {
+			color := board.COLOR_WHITE
+			if len(actions) > 0 || offset >= p.middle {
+				color = board.COLOR_BLACK
+			}
+			if len(actions) > 1 || (len(actions) == 1 && actions[0].color == board.COLOR_BLACK) {
+				return nil, fmt.Errorf("too many actions in %q", strings.TrimSpace(line))
+			}
+			actions = append(actions, matAction{color, text})
+			continue
+		}
+		if len(actions) == 0 {
+			return nil, fmt.Errorf("unexpected %q", text)
+		}
+		actions[len(actions)-1].text += " " + text
+	}
+	return actions, nil
+}
+
+// Function that plays an action of the game being imported
+func (p *matParser) play(action matAction) error {
+	if p.game == nil {
+		p.game = newGameBuilder(action.color)
+	}
+	gb := p.game
+	text := action.text
+	fields := strings.Fields(text)
+
+	var err error
+	switch {
+	case matDicePattern.MatchString(fields[0]):
+		dice := matDicePattern.FindStringSubmatch(fields[0])
+		d1, _ := strconv.Atoi(dice[1])
+		d2, _ := strconv.Atoi(dice[2])
+		var mvRoll board.MoveRoll
+		if len(fields) > 1 {
+			mvRoll, err = board.ParseMoveRollNotation(strings.Join(fields[1:], " "), action.color)
+			if err != nil {
+				return err
+			}
+		}
+		err = gb.roll(action.color, board.DieRoll{Die1: d1, Die2: d2}, mvRoll)
+	case matDoublePattern.MatchString(text):
+		value, _ := strconv.Atoi(matDoublePattern.FindStringSubmatch(text)[1])
+		err = gb.double(action.color, value)
+	case text == MAT_TAKES || text == MAT_DROPS:
+		err = gb.answer(action.color, text == MAT_TAKES)
+	case matWinPattern.MatchString(text):
+		points, _ := strconv.Atoi(matWinPattern.FindStringSubmatch(text)[1])
+		err = gb.win(action.color, points)
+	default:
+		return fmt.Errorf("invalid action %q", text)
+	}
+	if err != nil {
+		return fmt.Errorf("%q of %s: %w", text, colorName(action.color), err)
+	}
+	return nil
+}
+
+// Function that records the game being imported
+func (p *matParser) endGame() error {
+	if len(p.match.Games) == 0 {
+		return nil
+	}
+	if !p.scored {
+		return errors.New("missing the score of the players")
+	}
+	if p.game == nil {
+		return errors.New("the game has no moves")
+	}
+	record := p.game.record(p.match.Players)
+	p.match.Games[len(p.match.Games)-1].Record = record
+	p.score = nextScore(p.score, record.Result)
+	return nil
+}
+
+// Function that exports a match in the text format of Jellyfish and GNU
+// Backgammon, the games must start from the initial position
+func WriteMat(w io.Writer, match Match) error {
+	out := &strings.Builder{}
+	fmt.Fprintf(out, " %d point match\n", match.Length)
+	for idx, matchGame := range match.Games {
+		lines, err := matGameLines(match, matchGame)
+		if err != nil {
+			return fmt.Errorf("game %d: %w", idx+1, err)
+		}
+		fmt.Fprintf(out, "\n Game %d\n", idx+1)
+		for _, line := range lines {
+			fmt.Fprintln(out, strings.TrimRight(line, " "))
+		}
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// Function that returns the score line and the move lines of a game
+func matGameLines(match Match, matchGame MatchGame) ([]string, error) {
+	record := matchGame.Record
+	positions, err := replayForExport(record)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := []matAction{}
+	for idx, turn := range record.Turns {
+		opponent := board.Color(1 - turn.Color)
+		for _, action := range turn.CubeActions {
+			switch action {
+			case game.CUBE_ACTION_DOUBLE:
+				actions = append(actions, matAction{turn.Color, fmt.Sprintf("%s => %d", MAT_DOUBLES, 2*cubeValue(record, idx))})
+			case game.CUBE_ACTION_TAKE:
+				actions = append(actions, matAction{opponent, MAT_TAKES})
+			case game.CUBE_ACTION_DROP:
+				actions = append(actions, matAction{opponent, MAT_DROPS})
+			}
+		}
+		if turn.Dice != nil {
+			text := fmt.Sprintf("%d%d:", turn.Dice.Die1, turn.Dice.Die2)
+			if len(turn.MoveRoll) > 0 {
+				text += " " + turn.MoveRoll.Notation(positions[idx])
+			}
+			actions = append(actions, matAction{turn.Color, text})
+		}
+	}
+
+	lines := []string{fmt.Sprintf(" %-*s%s : %d", MAT_RIGHT_COLUMN-1,
+		fmt.Sprintf("%s : %d", match.Players.White, matchGame.Score.White),
+		match.Players.Black, matchGame.Score.Black)}
+	number := 0
+	for idx := 0; idx < len(actions); idx++ {
+		number += 1
+		left, right := "", ""
+		if actions[idx].color == board.COLOR_WHITE {
+			left = actions[idx].text
+			if idx+1 < len(actions) && actions[idx+1].color == board.COLOR_BLACK {
+				idx += 1
+				right = actions[idx].text
+			}
+		} else {
+			right = actions[idx].text
+		}
+		lines = append(lines, fmt.Sprintf("%3d) %-*s %s", number, MAT_COLUMN_WIDTH, matCell(left), matCell(right)))
+	}
+
+	if result := record.Result; result != nil {
+		indent := MAT_WIN_INDENT
+		if result.Winner == board.COLOR_BLACK {
+			indent = MAT_RIGHT_COLUMN
+		}
+		text := fmt.Sprintf("%s %d point", MAT_WINS, result.Points)
+		if result.Points > 1 {
+			text += "s"
+		}
+		score := nextScore(matchGame.Score, result)
+		if match.Length > 0 && (score.White >= match.Length || score.Black >= match.Length) {
+			text += " and the match"
+		}
+		lines = append(lines, strings.Repeat(" ", indent)+text)
+	}
+	return lines, nil
+}
+
+// Function that returns a cell of a move line, cube actions
+// are written after a space as in GNU Backgammon
+func matCell(text string) string {
+	if text == "" || matDicePattern.MatchString(strings.Fields(text)[0]) {
+		return text
+	}
+	return " " + text
+}
+
+// Function that returns the value of the cube before the given turn of a record
+func cubeValue(record game.Record, turn int) int {
+	value := 1
+	for _, previous := range record.Turns[:turn] {
+		for _, action := range previous.CubeActions {
+			if action == game.CUBE_ACTION_TAKE {
+				value *= 2
+			}
+		}
+	}
+	return value
+}
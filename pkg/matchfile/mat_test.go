@@ -0,0 +1,113 @@
+package matchfile
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+func readTestMatch(t *testing.T) Match {
+	f, err := os.Open("testdata/match.mat")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	defer f.Close()
+	match, err := ReadMat(f)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return match
+}
+
+func TestReadMat(t *testing.T) {
+	// ACT
+	match := readTestMatch(t)
+
+	// ASSERT
+	if match.Length != 3 || match.Players != (game.Players{White: "alice", Black: "bob"}) || len(match.Games) != 2 {
+		t.Fatalf("Unexpected match %v", match)
+	}
+	first, second := match.Games[0].Record, match.Games[1].Record
+	expected := game.RecordResult{Winner: board.COLOR_BLACK, Points: 2, Reason: game.END_DROP}
+	if *first.Result != expected || len(first.Turns) != 4 {
+		t.Errorf("Output %v not equal to expected %v", *first.Result, expected)
+	}
+	expected = game.RecordResult{Winner: board.COLOR_WHITE, Points: 1, Reason: game.END_RESIGNATION}
+	if *second.Result != expected || match.Games[1].Score != (Score{White: 0, Black: 2}) {
+		t.Errorf("Output %v not equal to expected %v", *second.Result, expected)
+	}
+	// 24/13 is recorded with one move per die
+	expectedMoveRoll := board.MoveRoll{{From: 23, To: 17, Type: board.NORMAL_MOVE}, {From: 17, To: 12, Type: board.NORMAL_MOVE}}
+	if second.Start.ColorToMove != board.COLOR_BLACK || !reflect.DeepEqual(second.Turns[1].MoveRoll, expectedMoveRoll) {
+		t.Errorf("Output %v not equal to expected %v", second.Turns[1].MoveRoll, expectedMoveRoll)
+	}
+}
+
+func TestMatRoundTrip(t *testing.T) {
+	// ARRANGE
+	match := readTestMatch(t)
+	out := bytes.Buffer{}
+
+	// ACT
+	err := WriteMat(&out, match)
+	written := out.String()
+	reread, readErr := ReadMat(&out)
+
+	// ASSERT
+	if err != nil || readErr != nil {
+		t.Fatalf("Unexpected errors %v %v", err, readErr)
+	}
+	if !reflect.DeepEqual(reread, match) {
+		t.Errorf("Output %v not equal to expected %v", reread, match)
+	}
+	expectedLine := "  3) 64: 24/18 13/9                Doubles => 4"
+	if !strings.Contains(written, expectedLine+"\n") {
+		t.Errorf("Output %v does not contain %q", written, expectedLine)
+	}
+}
+
+type matErrorTest struct {
+	content string
+	line    int
+}
+
+func makeMatErrorTests() []matErrorTest {
+	header := " 1 point match\n\n Game 1\n alice : 0                         bob : 0\n"
+	return []matErrorTest{
+		// test 1 - an illegal move
+		{header + "  1) 31: 8/4 6/5\n", 5},
+		// test 2 - a move of the player not on roll
+		{header + "  1) 31: 8/5 6/5\n  2) 42: 8/4 6/4\n", 6},
+		// test 3 - a double to a wrong value
+		{header + "  1) 31: 8/5 6/5                   Doubles => 4\n", 5},
+		// test 4 - a win that does not match the end of the game
+		{header + "  1) 31: 8/5 6/5                   Doubles => 2\n  2)  Drops\n      Wins 1 point\n", 7},
+		// test 5 - a missing score line
+		{" 1 point match\n\n Game 1\n  1) 31: 8/5 6/5\n", 4},
+		// test 6 - a garbled line
+		{header + "  1) 31: 8/5 6/5\n what is this\n", 6},
+		// test 7 - a score that does not follow the previous game
+		{header + "  1) 31: 8/5 6/5\n      Wins 1 point\n\n Game 2\n alice : 0                         bob : 0\n", 9},
+		// test 8 - no games
+		{" 1 point match\n", 1},
+	}
+}
+
+func TestReadMatErrors(t *testing.T) {
+	for idx, test := range makeMatErrorTests() {
+		// ACT
+		_, err := ReadMat(strings.NewReader(test.content))
+
+		// ASSERT
+		parseErr := &ParseError{}
+		if !errors.As(err, &parseErr) || parseErr.Line != test.line {
+			t.Errorf("Test %d: output %v not equal to expected line %d", idx+1, err, test.line)
+		}
+	}
+}
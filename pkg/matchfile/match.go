@@ -0,0 +1,157 @@
+// Package matchfile imports and exports matches in the formats of other
+// backgammon software: the text match format (.mat) of Jellyfish and
+// GNU Backgammon and the SGF format for backgammon
+package matchfile
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+var ErrUnsupportedStart = errors.New("only games starting from the initial position can be exported")
+
+// Scores of the players before a game
+type Score struct {
+	White int `json:"white"`
+	Black int `json:"black"`
+}
+
+type MatchGame struct {
+	Score  Score       `json:"score"`
+	Record game.Record `json:"record"`
+}
+
+// A match of several games. White is the first player of a match file,
+// i.e. the left column of a .mat file
+type Match struct {
+	// Length of the match in points, 0 for money games
+	Length  int          `json:"length"`
+	Players game.Players `json:"players"`
+	Games   []MatchGame  `json:"games"`
+}
+
+// Error of an imported file, at the given line starting from 1
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Replays the actions of an imported game, validating each of them
+type gameBuilder struct {
+	g *game.Game
+}
+
+// Function that starts an imported game from the initial position
+func newGameBuilder(first board.Color) *gameBuilder {
+	return &gameBuilder{game.NewGame(board.NewBoard(first))}
+}
+
+func (gb *gameBuilder) checkOnRoll(color board.Color) error {
+	if gb.g.IsOver() {
+		return game.ErrGameOver
+	}
+	if gb.g.Board.ColorToMove != color {
+		return fmt.Errorf("%s is not on roll", colorName(color))
+	}
+	return nil
+}
+
+func (gb *gameBuilder) roll(color board.Color, dice board.DieRoll, mvRoll board.MoveRoll) error {
+	if err := gb.checkOnRoll(color); err != nil {
+		return err
+	}
+	if err := gb.g.SetDice(dice); err != nil {
+		return err
+	}
+	return gb.g.Play(mvRoll)
+}
+
+func (gb *gameBuilder) double(color board.Color, value int) error {
+	if err := gb.checkOnRoll(color); err != nil {
+		return err
+	}
+	if value != 2*gb.g.Cube.Value {
+		return fmt.Errorf("the cube is doubled to %d instead of %d", value, 2*gb.g.Cube.Value)
+	}
+	return gb.g.Double()
+}
+
+// Function that takes or drops the double offered to the player with the given color
+func (gb *gameBuilder) answer(color board.Color, take bool) error {
+	if err := gb.checkOnRoll(board.Color(1 - color)); err != nil {
+		return err
+	}
+	if take {
+		return gb.g.Take()
+	}
+	return gb.g.Drop()
+}
+
+// Function that checks that the player with the given color won the given
+// points, the game ends with the resignation of the opponent if it is
+// not over yet
+func (gb *gameBuilder) win(color board.Color, points int) error {
+	if !gb.g.IsOver() {
+		if points%gb.g.Cube.Value != 0 {
+			return fmt.Errorf("%d points is not a multiple of the cube value %d", points, gb.g.Cube.Value)
+		}
+		return gb.g.Resign(board.Color(1-color), points/gb.g.Cube.Value)
+	}
+	if gb.g.Result.Winner != color || gb.g.PointsWon() != points {
+		return fmt.Errorf("%s won %d points", colorName(gb.g.Result.Winner), gb.g.PointsWon())
+	}
+	return nil
+}
+
+func (gb *gameBuilder) record(players game.Players) game.Record {
+	return gb.g.Record(players)
+}
+
+// Function that replays a record for exporting it, returning the position
+// before every turn
+func replayForExport(record game.Record) ([]board.Board, error) {
+	initial := board.NewBoard(record.Start.ColorToMove)
+	if len(record.Start.Points) != board.NUM_POINTS || record.Start.SerializeBoard() != initial.SerializeBoard() {
+		return nil, ErrUnsupportedStart
+	}
+	replayer, err := game.NewReplayer(record)
+	if err != nil {
+		return nil, err
+	}
+	positions := []board.Board{}
+	for {
+		positions = append(positions, replayer.Position().Board)
+		if !replayer.Forward() {
+			return positions, nil
+		}
+	}
+}
+
+// Function that returns the score of the next game of a match
+func nextScore(score Score, result *game.RecordResult) Score {
+	if result == nil {
+		return score
+	}
+	if result.Winner == board.COLOR_WHITE {
+		score.White += result.Points
+	} else {
+		score.Black += result.Points
+	}
+	return score
+}
+
+func colorName(color board.Color) string {
+	name, _ := color.MarshalText()
+	return string(name)
+}
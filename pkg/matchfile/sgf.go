@@ -0,0 +1,405 @@
+package matchfile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+// Game type of backgammon in SGF files
+const SGF_GAME_BACKGAMMON = "6"
+
+// Points of SGF moves: 'a'..'x' are the point indexes 0..23,
+// followed by the bar and the checkers borne off
+const (
+	SGF_FIRST_POINT = 'a'
+	SGF_BAR         = 'y'
+	SGF_OFF         = 'z'
+)
+
+const (
+	SGF_DOUBLE = "double"
+	SGF_TAKE   = "take"
+	SGF_DROP   = "drop"
+	// Suffix of the result of a resigned game, e.g. W+2R
+	SGF_RESIGNATION = "R"
+)
+
+var (
+	sgfResultPattern = regexp.MustCompile(`^([WB])\+(\d+)(R?)$`)
+	sgfMovePattern   = regexp.MustCompile(`^([1-6])([1-6])((?:[a-z][a-z])*)$`)
+)
+
+type sgfProperty struct {
+	ident  string
+	values []string
+	line   int
+}
+
+type sgfNode struct {
+	properties []sgfProperty
+	line       int
+}
+
+func (n sgfNode) property(ident string) (sgfProperty, bool) {
+	for _, property := range n.properties {
+		if property.ident == ident {
+			return property, true
+		}
+	}
+	return sgfProperty{}, false
+}
+
+// Tokenizer of SGF collections, it keeps the current line for errors
+type sgfReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+func (s *sgfReader) next() (byte, error) {
+	c, err := s.r.ReadByte()
+	if c == '\n' {
+		s.line += 1
+	}
+	return c, err
+}
+
+// Function that returns the next character that is not a white space
+func (s *sgfReader) skipSpaces() (byte, error) {
+	for {
+		c, err := s.next()
+		if err != nil || (c != ' ' && c != '\t' && c != '\r' && c != '\n') {
+			return c, err
+		}
+	}
+}
+
+func (s *sgfReader) errorf(format string, args ...interface{}) error {
+	return &ParseError{s.line, fmt.Errorf(format, args...)}
+}
+
+// Function that reads the nodes of the game trees of a collection,
+// variations are not supported as match files have a single line of play
+func (s *sgfReader) readGameTrees() ([][]sgfNode, error) {
+	trees := [][]sgfNode{}
+	for {
+		c, err := s.skipSpaces()
+		if err == io.EOF {
+			return trees, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if c != '(' {
+			return nil, s.errorf("expected a game tree, got %q", c)
+		}
+		nodes, err := s.readSequence()
+		if err != nil {
+			return nil, err
+		}
+		trees = append(trees, nodes)
+	}
+}
+
+func (s *sgfReader) readSequence() ([]sgfNode, error) {
+	nodes := []sgfNode{}
+	c, err := s.skipSpaces()
+	for {
+		switch {
+		case err == io.EOF:
+			return nil, s.errorf("unterminated game tree")
+		case err != nil:
+			return nil, err
+		case c == ')':
+			if len(nodes) == 0 {
+				return nil, s.errorf("empty game tree")
+			}
+			return nodes, nil
+		case c == '(':
+			return nil, s.errorf("variations are not supported")
+		case c != ';':
+			return nil, s.errorf("expected a node, got %q", c)
+		}
+		node := sgfNode{line: s.line}
+		c, err = s.skipSpaces()
+		for err == nil && c >= 'A' && c <= 'Z' {
+			var property sgfProperty
+			property, c, err = s.readProperty(c)
+			node.properties = append(node.properties, property)
+		}
+		nodes = append(nodes, node)
+	}
+}
+
+// Function that reads a property starting with the given character,
+// returning the character following it
+func (s *sgfReader) readProperty(c byte) (sgfProperty, byte, error) {
+	property := sgfProperty{line: s.line}
+	ident := []byte{}
+	var err error
+	for ; err == nil && c >= 'A' && c <= 'Z'; c, err = s.next() {
+		ident = append(ident, c)
+	}
+	property.ident = string(ident)
+	for err == nil && (c == ' ' || c == '\t' || c == '\r' || c == '\n') {
+		c, err = s.skipSpaces()
+	}
+	for err == nil && c == '[' {
+		value := []byte{}
+		for {
+			c, err = s.next()
+			if err != nil {
+				return property, 0, s.errorf("unterminated value of property %s", property.ident)
+			}
+			if c == ']' {
+				break
+			}
+			if c == '\\' {
+				if c, err = s.next(); err != nil {
+					return property, 0, s.errorf("unterminated value of property %s", property.ident)
+				}
+			}
+			value = append(value, c)
+		}
+		property.values = append(property.values, string(value))
+		c, err = s.skipSpaces()
+	}
+	if err == nil && len(property.values) == 0 {
+		return property, 0, s.errorf("property %s has no value", property.ident)
+	}
+	return property, c, err
+}
+
+// Function that imports a match from SGF game trees, one per game, as
+// written by GNU Backgammon, e.g.
+//
+//	(;FF[4]GM[6]MI[length:5][game:0][ws:0][bs:0]PW[alice]PB[bob]RE[B+1]
+//	;W[31hefe]
+//	;B[double]
+//	;W[drop])
+//
+// Every move is validated by replaying the games, invalid files
+// return a *ParseError with the line of the error
+func ReadSGF(r io.Reader) (Match, error) {
+	s := &sgfReader{bufio.NewReader(r), 1}
+	trees, err := s.readGameTrees()
+	if err != nil {
+		return Match{}, err
+	}
+	if len(trees) == 0 {
+		return Match{}, s.errorf("no games found")
+	}
+
+	match := Match{}
+	score := Score{}
+	for idx, nodes := range trees {
+		matchGame, length, err := readSGFGame(nodes)
+		if err != nil {
+			return Match{}, err
+		}
+		if idx == 0 {
+			match.Length, match.Players = length, matchGame.Record.Players
+		} else if matchGame.Score != score || matchGame.Record.Players != match.Players {
+			return Match{}, &ParseError{nodes[0].line, errors.New("the players or the score do not follow the previous games")}
+		}
+		match.Games = append(match.Games, matchGame)
+		score = nextScore(matchGame.Score, matchGame.Record.Result)
+	}
+	return match, nil
+}
+
+// Function that replays the nodes of a game tree, returning the game and the match length
+func readSGFGame(nodes []sgfNode) (MatchGame, int, error) {
+	root := nodes[0]
+	if gm, ok := root.property("GM"); ok && gm.values[0] != SGF_GAME_BACKGAMMON {
+		return MatchGame{}, 0, &ParseError{gm.line, fmt.Errorf("not a backgammon game: GM[%s]", gm.values[0])}
+	}
+
+	matchGame := MatchGame{}
+	length := 0
+	if mi, ok := root.property("MI"); ok {
+		for _, value := range mi.values {
+			key, number, found := strings.Cut(value, ":")
+			parsed, err := strconv.Atoi(number)
+			if !found || err != nil {
+				continue
+			}
+			switch key {
+			case "length":
+				length = parsed
+			case "ws":
+				matchGame.Score.White = parsed
+			case "bs":
+				matchGame.Score.Black = parsed
+			}
+		}
+	}
+	players := game.Players{}
+	if pw, ok := root.property("PW"); ok {
+		players.White = pw.values[0]
+	}
+	if pb, ok := root.property("PB"); ok {
+		players.Black = pb.values[0]
+	}
+
+	var gb *gameBuilder
+	for _, node := range nodes[1:] {
+		for _, property := range node.properties {
+			if property.ident != "W" && property.ident != "B" {
+				continue
+			}
+			color := board.COLOR_WHITE
+			if property.ident == "B" {
+				color = board.COLOR_BLACK
+			}
+			if gb == nil {
+				gb = newGameBuilder(color)
+			}
+			if err := playSGFAction(gb, color, property.values[0]); err != nil {
+				return MatchGame{}, 0, &ParseError{property.line, fmt.Errorf("%s[%s]: %w", property.ident, property.values[0], err)}
+			}
+		}
+	}
+	if gb == nil {
+		return MatchGame{}, 0, &ParseError{root.line, errors.New("the game has no moves")}
+	}
+
+	if re, ok := root.property("RE"); ok {
+		groups := sgfResultPattern.FindStringSubmatch(re.values[0])
+		if groups == nil {
+			return MatchGame{}, 0, &ParseError{re.line, fmt.Errorf("invalid result %q", re.values[0])}
+		}
+		winner := board.COLOR_WHITE
+		if groups[1] == "B" {
+			winner = board.COLOR_BLACK
+		}
+		points, _ := strconv.Atoi(groups[2])
+		if resigned := groups[3] == SGF_RESIGNATION; resigned == gb.g.IsOver() {
+			return MatchGame{}, 0, &ParseError{re.line, fmt.Errorf("result %q does not match the end of the game", re.values[0])}
+		}
+		if err := gb.win(winner, points); err != nil {
+			return MatchGame{}, 0, &ParseError{re.line, fmt.Errorf("result %q: %w", re.values[0], err)}
+		}
+	}
+	matchGame.Record = gb.record(players)
+	return matchGame, length, nil
+}
+
+func playSGFAction(gb *gameBuilder, color board.Color, value string) error {
+	switch value {
+	case SGF_DOUBLE:
+		return gb.double(color, 2*gb.g.Cube.Value)
+	case SGF_TAKE:
+		return gb.answer(color, true)
+	case SGF_DROP:
+		return gb.answer(color, false)
+	}
+	groups := sgfMovePattern.FindStringSubmatch(value)
+	if groups == nil {
+		return errors.New("invalid move")
+	}
+	d1, _ := strconv.Atoi(groups[1])
+	d2, _ := strconv.Atoi(groups[2])
+	mvRoll := board.MoveRoll{}
+	for idx := 0; idx < len(groups[3]); idx += 2 {
+		mv, err := sgfMove(groups[3][idx], groups[3][idx+1], color)
+		if err != nil {
+			return err
+		}
+		mvRoll = append(mvRoll, mv)
+	}
+	return gb.roll(color, board.DieRoll{Die1: d1, Die2: d2}, mvRoll)
+}
+
+// Function that converts the points of an SGF move played by the player with the given color
+func sgfMove(from byte, to byte, color board.Color) (board.Move, error) {
+	bar := board.PointIndex(board.WHITE_PIECES_BAR_POINT_INDEX)
+	if color == board.COLOR_BLACK {
+		bar = board.BLACK_PIECES_BAR_POINT_INDEX
+	}
+	switch {
+	case from == SGF_OFF || to == SGF_BAR || from > SGF_OFF || to > SGF_OFF:
+		return board.Move{}, fmt.Errorf("invalid move %c%c", from, to)
+	case from == SGF_BAR && to == SGF_OFF:
+		return board.Move{}, fmt.Errorf("invalid move %c%c", from, to)
+	case from == SGF_BAR:
+		return board.Move{From: bar, To: board.PointIndex(to - SGF_FIRST_POINT), Type: board.CHECKER_ON_BAR_MOVE}, nil
+	case to == SGF_OFF:
+		return board.Move{From: board.PointIndex(from - SGF_FIRST_POINT), To: board.TO_INDEX_FOR_BEARING_OFF, Type: board.BEARING_OFF_MOVE}, nil
+	default:
+		return board.Move{From: board.PointIndex(from - SGF_FIRST_POINT), To: board.PointIndex(to - SGF_FIRST_POINT), Type: board.NORMAL_MOVE}, nil
+	}
+}
+
+// Function that returns the SGF point of a point index
+func sgfPoint(idx board.PointIndex) byte {
+	switch idx {
+	case board.TO_INDEX_FOR_BEARING_OFF:
+		return SGF_OFF
+	case board.WHITE_PIECES_BAR_POINT_INDEX, board.BLACK_PIECES_BAR_POINT_INDEX:
+		return SGF_BAR
+	default:
+		return SGF_FIRST_POINT + byte(idx)
+	}
+}
+
+// Function that exports a match as SGF game trees, one per game,
+// the games must start from the initial position
+func WriteSGF(w io.Writer, match Match) error {
+	out := &strings.Builder{}
+	for idx, matchGame := range match.Games {
+		record := matchGame.Record
+		if _, err := replayForExport(record); err != nil {
+			return fmt.Errorf("game %d: %w", idx+1, err)
+		}
+		fmt.Fprintf(out, "(;FF[4]GM[%s]CA[UTF-8]MI[length:%d][game:%d][ws:%d][bs:%d]PW[%s]PB[%s]",
+			SGF_GAME_BACKGAMMON, match.Length, idx, matchGame.Score.White, matchGame.Score.Black,
+			sgfEscape(match.Players.White), sgfEscape(match.Players.Black))
+		if result := record.Result; result != nil {
+			winner := "W"
+			if result.Winner == board.COLOR_BLACK {
+				winner = "B"
+			}
+			suffix := ""
+			if result.Reason == game.END_RESIGNATION {
+				suffix = SGF_RESIGNATION
+			}
+			fmt.Fprintf(out, "RE[%s+%d%s]", winner, result.Points, suffix)
+		}
+		for _, turn := range record.Turns {
+			ident, opponent := "W", "B"
+			if turn.Color == board.COLOR_BLACK {
+				ident, opponent = "B", "W"
+			}
+			for _, action := range turn.CubeActions {
+				if action == game.CUBE_ACTION_DOUBLE {
+					fmt.Fprintf(out, "\n;%s[%s]", ident, SGF_DOUBLE)
+				} else {
+					fmt.Fprintf(out, "\n;%s[%s]", opponent, action)
+				}
+			}
+			if turn.Dice != nil {
+				fmt.Fprintf(out, "\n;%s[%d%d", ident, turn.Dice.Die1, turn.Dice.Die2)
+				for _, mv := range turn.MoveRoll {
+					out.WriteByte(sgfPoint(mv.From))
+					out.WriteByte(sgfPoint(mv.To))
+				}
+				out.WriteString("]")
+			}
+		}
+		out.WriteString(")\n")
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+func sgfEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `]`, `\]`).Replace(value)
+}
@@ -0,0 +1,74 @@
+package matchfile
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSGFRoundTrip(t *testing.T) {
+	// ARRANGE
+	match := readTestMatch(t)
+	match.Players.Black = "bob [the builder]"
+	out := bytes.Buffer{}
+
+	// ACT
+	err := WriteSGF(&out, match)
+	written := out.String()
+	reread, readErr := ReadSGF(&out)
+
+	// ASSERT
+	if err != nil || readErr != nil {
+		t.Fatalf("Unexpected errors %v %v", err, readErr)
+	}
+	for idx := range match.Games {
+		match.Games[idx].Record.Players = match.Players
+	}
+	if !reflect.DeepEqual(reread, match) {
+		t.Errorf("Output %v not equal to expected %v", reread, match)
+	}
+	expected := "(;FF[4]GM[6]CA[UTF-8]MI[length:3][game:1][ws:0][bs:2]PW[alice]PB[bob [the builder\\]]RE[W+1R]\n;B[42qusu]\n;W[65xrrm])\n"
+	if !strings.HasSuffix(written, expected) {
+		t.Errorf("Output %v not equal to expected %v", written, expected)
+	}
+}
+
+type sgfErrorTest struct {
+	content string
+	line    int
+}
+
+func makeSGFErrorTests() []sgfErrorTest {
+	root := "(;FF[4]GM[6]MI[length:1][game:0][ws:0][bs:0]PW[alice]PB[bob]\n"
+	return []sgfErrorTest{
+		// test 1 - an illegal move
+		{root + ";W[31hefe]\n;B[52lqlm])", 3},
+		// test 2 - an invalid move encoding
+		{root + ";W[31hef])", 2},
+		// test 3 - a result that does not match the end of the game
+		{"(;GM[6]RE[W+1]\n;W[31hefe])", 1},
+		// test 4 - variations
+		{root + ";W[31hefe]\n(;B[52lqln]))", 3},
+		// test 5 - an unterminated value
+		{root + ";W[31hefe\n\n", 4},
+		// test 6 - another game
+		{"(;GM[1]\n;W[31hefe])", 1},
+		// test 7 - no games
+		{"\n", 2},
+	}
+}
+
+func TestReadSGFErrors(t *testing.T) {
+	for idx, test := range makeSGFErrorTests() {
+		// ACT
+		_, err := ReadSGF(strings.NewReader(test.content))
+
+		// ASSERT
+		parseErr := &ParseError{}
+		if !errors.As(err, &parseErr) || parseErr.Line != test.line {
+			t.Errorf("Test %d: output %v not equal to expected line %d", idx+1, err, test.line)
+		}
+	}
+}
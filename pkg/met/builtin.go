@@ -0,0 +1,210 @@
+package met
+
+import "math"
+
+// Length of the built-in table
+const BUILTIN_LENGTH = 25
+
+// Fraction of the games won with a gammon in the model of the built-in table
+const BUILTIN_GAMMON_RATE = 0.26
+
+var builtin = newModelTable("Live cube model, 26% gammons", BUILTIN_LENGTH, BUILTIN_GAMMON_RATE, (*model).liveCubeMWC)
+
+// Function that returns the built-in table for matches up to 25 points.
+// It is computed from a model of even games with a fixed gammon rate in
+// which the players turn a fully live cube at their cash points. Its
+// chances are close to the ones of the published tables built from
+// rollouts, e.g. 66.4% for the leader at 2-away 4-away, 57.1% at 3-away
+// 4-away and 68.5% in the Crawford game at 1-away 2-away. The tables of
+// other programs can be loaded with LoadFromFile
+func Builtin() *Table {
+	return builtin
+}
+
+// Model of the match winning chances of games won by either player with
+// probability 0.5, a fraction gammonRate of them with a gammon
+type model struct {
+	t          *Table
+	gammonRate float64
+	// Cubeful match winning chances of the player who needs a points
+	// against b points, as a function of the cubeless winning chances
+	live map[liveKey][]point
+}
+
+type liveKey struct {
+	a, b, cube int
+	owner      owner
+}
+
+// Owner of the cube seen by the player who needs a points
+type owner int
+
+const (
+	OWNER_CENTERED owner = iota
+	OWNER_PLAYER
+	OWNER_OPPONENT
+)
+
+// Point of a polyline: match winning chances y at cubeless winning chances x
+type point struct {
+	x, y float64
+}
+
+// Function that computes a table with the model. After the Crawford game
+// the trailer doubles at once and the leader drops when it is free, i.e.
+// when dropping is better than taking. Before it, the chances of the
+// scores at which both players need at least 2 points are computed by
+// scoreMWC from the chances of the scores closer to the end of the match
+func newModelTable(name string, length int, gammonRate float64, scoreMWC func(m *model, a int, b int) float64) *Table {
+	t := &Table{Name: name, Length: length, post: make([]float64, length), pre: make([][]float64, length)}
+	m := &model{t: t, gammonRate: gammonRate, live: map[liveKey][]point{}}
+
+	t.post[0] = 0.5
+	for n := 2; n <= length; n++ {
+		take := 0.5 * ((1-gammonRate)*m.post(n-2) + gammonRate*m.post(n-4))
+		drop := m.post(n - 1)
+		if drop < take {
+			t.post[n-1] = drop
+		} else {
+			t.post[n-1] = take
+		}
+	}
+
+	for idx := range t.pre {
+		t.pre[idx] = make([]float64, length)
+	}
+	for sum := 2; sum <= 2*length; sum++ {
+		for a := 1; a <= length; a++ {
+			b := sum - a
+			if b < 1 || b > length {
+				continue
+			}
+			switch {
+			case a == 1 && b == 1:
+				t.pre[0][0] = 0.5
+			case a == 1:
+				// Crawford game: the trailer reaches the post Crawford games
+				t.pre[0][b-1] = 0.5 + 0.5*((1-gammonRate)*(1-m.post(b-1))+gammonRate*(1-m.post(b-2)))
+			case b == 1:
+				t.pre[a-1][0] = 0.5 * ((1-gammonRate)*m.post(a-1) + gammonRate*m.post(a-2))
+			default:
+				t.pre[a-1][b-1] = scoreMWC(m, a, b)
+			}
+		}
+	}
+	return t
+}
+
+// Function that returns the chances of the trailer who needs n points
+// after the Crawford game, 1 if the trailer won
+func (m *model) post(n int) float64 {
+	if n <= 0 {
+		return 1
+	}
+	return m.t.post[n-1]
+}
+
+// Function that returns the chances of the player who needs a points
+// against b points before the Crawford game, decided if a or b is not positive
+func (m *model) pre(a int, b int) float64 {
+	switch {
+	case a <= 0:
+		return 1
+	case b <= 0:
+		return 0
+	default:
+		return m.t.pre[a-1][b-1]
+	}
+}
+
+// Function that returns the chances of the player who needs a points
+// against b points at the start of a game, with the cube centered
+func (m *model) liveCubeMWC(a int, b int) float64 {
+	return polyline(m.liveMWC(a, b, 1, OWNER_CENTERED), 0.5)
+}
+
+// Function that returns the chances of the player who needs a points
+// against b points with a cube of the given value and owner, as a function
+// of the cubeless winning chances p of the player. With a fully live cube
+// a player doubles at the cash point, where the opponent is indifferent
+// between taking and dropping, so the chances are linear in p between the
+// cash points of the players and constant past them. Past a cash point the
+// player plays on for the gammon if it is worth more than the cube
+func (m *model) liveMWC(a int, b int, cube int, cubeOwner owner) []point {
+	key := liveKey{a, b, cube, cubeOwner}
+	if points, ok := m.live[key]; ok {
+		return points
+	}
+
+	g := m.gammonRate
+	lose := (1-g)*m.pre(a, b-cube) + g*m.pre(a, b-2*cube)
+	win := (1-g)*m.pre(a-cube, b) + g*m.pre(a-2*cube, b)
+	// A player who would win the match with a single game gains nothing by doubling
+	playerDoubles := cubeOwner != OWNER_OPPONENT && a > cube
+	opponentDoubles := cubeOwner != OWNER_PLAYER && b > cube
+
+	points := []point{{0, lose}}
+	if opponentDoubles {
+		drop := m.pre(a, b-cube)
+		take := m.liveMWC(a, b, 2*cube, OWNER_PLAYER)
+		cashPoint, ok := inverse(take, drop)
+		switch {
+		case !ok:
+			// The player always drops, the opponent cashes at once
+			points = []point{{0, drop}, {1, drop}}
+			m.live[key] = points
+			return points
+		case cashPoint > 0:
+			points = []point{{0, math.Min(lose, drop)}, {cashPoint, drop}}
+		default:
+			points[0].y = math.Min(lose, take[0].y)
+		}
+	}
+	if playerDoubles {
+		drop := m.pre(a-cube, b)
+		take := m.liveMWC(a, b, 2*cube, OWNER_OPPONENT)
+		if cashPoint, ok := inverse(take, drop); ok && cashPoint < 1 {
+			if cashPoint > points[len(points)-1].x {
+				points = append(points, point{cashPoint, drop})
+			}
+			points = append(points, point{1, math.Max(win, drop)})
+		} else {
+			// The opponent takes up to the end, the player doubles at the last moment
+			points = append(points, point{1, math.Max(win, take[len(take)-1].y)})
+		}
+	} else {
+		points = append(points, point{1, win})
+	}
+	m.live[key] = points
+	return points
+}
+
+// Function that returns the value at x of the polyline through the given
+// points, sorted by x
+func polyline(points []point, x float64) float64 {
+	for idx := 1; idx < len(points); idx++ {
+		from, to := points[idx-1], points[idx]
+		if x <= to.x || idx == len(points)-1 {
+			if to.x == from.x {
+				return to.y
+			}
+			return from.y + (x-from.x)*(to.y-from.y)/(to.x-from.x)
+		}
+	}
+	return points[0].y
+}
+
+// Function that returns the smallest x at which the increasing polyline
+// reaches y, false if it never does
+func inverse(points []point, y float64) (float64, bool) {
+	if points[0].y >= y {
+		return points[0].x, true
+	}
+	for idx := 1; idx < len(points); idx++ {
+		from, to := points[idx-1], points[idx]
+		if to.y >= y {
+			return from.x + (y-from.y)*(to.x-from.x)/(to.y-from.y), true
+		}
+	}
+	return 0, false
+}
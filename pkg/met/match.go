@@ -0,0 +1,104 @@
+package met
+
+import (
+	"errors"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/cube"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+var ErrMatchTooLong = errors.New("the match is longer than the match equity table")
+
+// Score of a match being played, it tells AIs the match winning chances
+// of the players with a match equity table. It implements ai.MatchEquity,
+// the games of the match created with NewGame are played for it
+type Match struct {
+	Length int
+	Table  *Table
+	// Points won by each player, indexed by color
+	Points [2]int
+	// The next game is the Crawford game
+	Crawford bool
+	// The Crawford game was played
+	PostCrawford bool
+}
+
+// Function that starts a match of the given length, the built-in
+// table is used if the table is nil
+func NewMatch(length int, table *Table) (*Match, error) {
+	if table == nil {
+		table = Builtin()
+	}
+	if length < 1 || length > table.Length {
+		return nil, ErrMatchTooLong
+	}
+	return &Match{Length: length, Table: table}, nil
+}
+
+// Function that starts a game of the match from the given board, the AI
+// players of the game play for the match winning chances
+func (m *Match) NewGame(b board.Board) *game.Game {
+	g := game.NewGame(b)
+	g.Match = m
+	return g
+}
+
+// Function that returns the points the player with the given color needs to win the match
+func (m *Match) Away(color board.Color) int {
+	return m.Length - m.Points[color]
+}
+
+func (m *Match) IsOver() bool {
+	return m.Away(board.COLOR_WHITE) <= 0 || m.Away(board.COLOR_BLACK) <= 0
+}
+
+// Function that returns the score seen by the player with the given color
+func (m *Match) Score(color board.Color) cube.MatchScore {
+	return cube.MatchScore{
+		Away:         m.Away(color),
+		OpponentAway: m.Away(board.Color(1 - color)),
+		Crawford:     m.Crawford,
+		PostCrawford: m.PostCrawford,
+	}
+}
+
+// Function that returns the match winning chances of the player with the
+// given color before the next game
+func (m *Match) MWC(color board.Color) float64 {
+	away, opponentAway := m.Away(color), m.Away(board.Color(1-color))
+	switch {
+	case away <= 0:
+		return 1
+	case opponentAway <= 0:
+		return 0
+	case m.PostCrawford && (away == 1 || opponentAway == 1):
+		return m.Table.PostCrawfordMWC(away, opponentAway)
+	default:
+		return m.Table.MWC(away, opponentAway)
+	}
+}
+
+// Function that converts the cubeless evaluation of the player with the
+// given color to match winning chances, for the next game played for
+// the given cube value
+func (m *Match) EvaluationMWC(evaluation ai.Evaluation, color board.Color, cubeValue int) float64 {
+	return cube.CubelessMWC(evaluation, cubeValue, m.Score(color), m.Table)
+}
+
+// Function that evaluates the cube decision of the player with the given color
+func (m *Match) CubeDecision(evaluation ai.Evaluation, c game.Cube, color board.Color) (cube.Decision, error) {
+	return cube.MatchDecision(evaluation, c, color, m.Score(color), m.Table)
+}
+
+// Function that adds the points won in a game, the game after the one
+// in which a player reaches 1-away is the Crawford game
+func (m *Match) AddResult(winner board.Color, points int) {
+	m.Points[winner] += points
+	if m.Crawford {
+		m.Crawford, m.PostCrawford = false, true
+	} else if !m.PostCrawford && m.Away(winner) == 1 && m.Away(board.Color(1-winner)) > 1 {
+		m.Crawford = true
+	}
+}
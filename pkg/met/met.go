@@ -0,0 +1,174 @@
+// Package met provides match equity tables: the match winning chances of
+// the players at every score of a match, before and after the Crawford game
+package met
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Tolerance of the sum of the match winning chances of both players in a loaded table
+const SYMMETRY_TOLERANCE = 1e-3
+
+var ErrInvalidTable = errors.New("invalid match equity table")
+
+// Match equity table for matches up to Length points. It implements
+// cube.MatchEquity
+type Table struct {
+	Name   string
+	Length int
+	// pre[i][j] is the match winning chances of a player who needs i+1
+	// points against an opponent who needs j+1 points, before the Crawford
+	// game. If one of the players needs 1 point it is the Crawford game
+	pre [][]float64
+	// post[i] is the match winning chances of the trailer who needs i+1
+	// points after the Crawford game, the leader needs 1 point
+	post []float64
+}
+
+// Function that returns the match winning chances of a player who needs
+// away points against an opponent who needs opponentAway points, both
+// between 1 and the length of the table
+func (t *Table) MWC(away int, opponentAway int) float64 {
+	return t.pre[away-1][opponentAway-1]
+}
+
+// Function that returns the match winning chances after the Crawford game,
+// one of the players needs 1 point
+func (t *Table) PostCrawfordMWC(away int, opponentAway int) float64 {
+	if away == 1 {
+		return 1 - t.post[opponentAway-1]
+	}
+	return t.post[away-1]
+}
+
+// Function that reads a table in the text format written by Save:
+//
+//	# comment
+//	name Example
+//	length 2
+//	pre
+//	0.5    0.6810
+//	0.3190 0.5
+//	post
+//	0.5    0.4870
+//
+// The rows of pre are the points the player needs and the columns the
+// points the opponent needs, post lists the chances of the trailer after
+// the Crawford game. Invalid tables return errors with the line number
+func Load(r io.Reader) (*Table, error) {
+	t := &Table{}
+	section := ""
+	line := 0
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: line %d: %s", ErrInvalidTable, line, fmt.Sprintf(format, args...))
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line += 1
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		switch fields[0] {
+		case "name":
+			t.Name = strings.Join(fields[1:], " ")
+			continue
+		case "length":
+			length, err := strconv.Atoi(strings.Join(fields[1:], ""))
+			if err != nil || length < 1 || t.Length != 0 {
+				return nil, invalid("invalid length %q", strings.Join(fields[1:], " "))
+			}
+			t.Length = length
+			continue
+		case "pre", "post":
+			if t.Length == 0 {
+				return nil, invalid("the length must precede the %s section", fields[0])
+			}
+			section = fields[0]
+			continue
+		}
+
+		row := []float64{}
+		for _, field := range fields {
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil || value < 0 || value > 1 {
+				return nil, invalid("invalid match winning chances %q", field)
+			}
+			row = append(row, value)
+		}
+		switch {
+		case section == "pre" && len(t.pre) < t.Length && len(row) == t.Length:
+			t.pre = append(t.pre, row)
+		case section == "post" && t.post == nil && len(row) == t.Length:
+			t.post = row
+		case section == "":
+			return nil, invalid("unexpected %q outside of a section", fields[0])
+		default:
+			return nil, invalid("expected %d values in the %s section", t.Length, section)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(t.pre) != t.Length || len(t.post) != t.Length {
+		return nil, invalid("expected %d rows of pre and one row of post", t.Length)
+	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func LoadFromFile(path string) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Function that checks the chances of both players sum to 1
+func (t *Table) validate() error {
+	for i := 0; i < t.Length; i++ {
+		for j := 0; j < t.Length; j++ {
+			if math.Abs(t.pre[i][j]+t.pre[j][i]-1) > SYMMETRY_TOLERANCE {
+				return fmt.Errorf("%w: the chances at %d-away %d-away and %d-away %d-away do not sum to 1",
+					ErrInvalidTable, i+1, j+1, j+1, i+1)
+			}
+		}
+	}
+	if math.Abs(t.pre[0][0]-0.5) > SYMMETRY_TOLERANCE || math.Abs(t.post[0]-0.5) > SYMMETRY_TOLERANCE {
+		return fmt.Errorf("%w: the chances at 1-away 1-away must be 0.5", ErrInvalidTable)
+	}
+	return nil
+}
+
+// Function that writes the table in the format read by Load
+func (t *Table) Save(w io.Writer) error {
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "name %s\nlength %d\npre\n", t.Name, t.Length)
+	for _, row := range t.pre {
+		writeRow(out, row)
+	}
+	out.WriteString("post\n")
+	writeRow(out, t.post)
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+func writeRow(out *strings.Builder, row []float64) {
+	values := []string{}
+	for _, value := range row {
+		values = append(values, strconv.FormatFloat(value, 'f', 4, 64))
+	}
+	out.WriteString(strings.Join(values, " ") + "\n")
+}
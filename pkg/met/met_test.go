@@ -0,0 +1,193 @@
+package met
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/cube"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+const TEST_TABLE = `# a 2 point table
+name Example
+length 2
+pre
+0.5    0.6810
+0.3190 0.5
+post
+0.5    0.4870
+`
+
+// Function that computes the chances of the model without the cube before
+// the Crawford game, every game is played for a point
+func cubelessMWC(m *model, a int, b int) float64 {
+	g := m.gammonRate
+	return 0.5*((1-g)*m.pre(a-1, b)+g*m.pre(a-2, b)) + 0.5*((1-g)*m.pre(a, b-1)+g*m.pre(a, b-2))
+}
+
+func TestBuiltin(t *testing.T) {
+	// ARRANGE
+	table := Builtin()
+	cubeless := newModelTable("Cubeless model", BUILTIN_LENGTH, BUILTIN_GAMMON_RATE, cubelessMWC)
+
+	// ACT & ASSERT
+	if err := table.validate(); err != nil || table.Length != BUILTIN_LENGTH {
+		t.Errorf("Output %v %v not equal to expected %v %v", err, table.Length, nil, BUILTIN_LENGTH)
+	}
+	if math.Abs(table.MWC(1, 2)-0.685) > 1e-9 || math.Abs(table.MWC(2, 1)-0.315) > 1e-9 {
+		t.Errorf("Output %v not equal to expected %v", table.MWC(1, 2), 0.685)
+	}
+	// The cube of the trailer brings the chances of the leader down to the
+	// ones of the published tables, about 66% at 2-away 4-away
+	if output := table.MWC(2, 4); math.Abs(output-0.664) > 1e-3 || math.Abs(cubeless.MWC(2, 4)-0.732) > 1e-3 {
+		t.Errorf("Output %v %v not equal to expected %v %v", output, cubeless.MWC(2, 4), 0.664, 0.732)
+	}
+	if math.Abs(table.MWC(BUILTIN_LENGTH, BUILTIN_LENGTH)-0.5) > 1e-9 {
+		t.Errorf("Output %v not equal to expected %v", table.MWC(BUILTIN_LENGTH, BUILTIN_LENGTH), 0.5)
+	}
+	for away := 2; away < BUILTIN_LENGTH; away++ {
+		if table.MWC(away, 5) <= table.MWC(away+1, 5) || table.PostCrawfordMWC(away, 1) < table.PostCrawfordMWC(away+1, 1) {
+			t.Errorf("Output %v not greater than %v", table.MWC(away, 5), table.MWC(away+1, 5))
+		}
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	// ARRANGE
+	table, err := Load(strings.NewReader(TEST_TABLE))
+	if err != nil {
+		t.Fatalf("Output %v not equal to expected %v", err, nil)
+	}
+
+	// ACT
+	out := &bytes.Buffer{}
+	err = table.Save(out)
+	loaded, loadErr := Load(out)
+
+	// ASSERT
+	if err != nil || loadErr != nil || loaded.Name != "Example" || loaded.MWC(1, 2) != 0.681 {
+		t.Errorf("Output %v %v %v not equal to expected %v", err, loadErr, loaded, table)
+	}
+	if loaded.PostCrawfordMWC(2, 1) != 0.487 || loaded.PostCrawfordMWC(1, 2) != 1-0.487 {
+		t.Errorf("Output %v not equal to expected %v", loaded.PostCrawfordMWC(2, 1), 0.487)
+	}
+}
+
+type loadErrorTest struct {
+	input        string
+	expectedLine string
+}
+
+func makeLoadErrorTests() []loadErrorTest {
+	return []loadErrorTest{
+		// test 1 - a section before the length
+		{"name Example\npre\n", "line 2"},
+		// test 2 - chances out of range
+		{strings.Replace(TEST_TABLE, "0.3190", "1.3190", 1), "line 6"},
+		// test 3 - a row of the wrong size
+		{strings.Replace(TEST_TABLE, "0.4870", "0.4870 0.3", 1), "line 8"},
+		// test 4 - the chances of the players do not sum to 1
+		{strings.Replace(TEST_TABLE, "0.3190", "0.4190", 1), "do not sum to 1"},
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	for idx, test := range makeLoadErrorTests() {
+		// ACT
+		_, err := Load(strings.NewReader(test.input))
+
+		// ASSERT
+		if !errors.Is(err, ErrInvalidTable) || !strings.Contains(err.Error(), test.expectedLine) {
+			t.Errorf("Test %d: output %v not equal to expected %v", idx+1, err, test.expectedLine)
+		}
+	}
+}
+
+func TestMatchCrawford(t *testing.T) {
+	// ARRANGE
+	match, err := NewMatch(5, nil)
+	if err != nil {
+		t.Fatalf("Output %v not equal to expected %v", err, nil)
+	}
+
+	// ACT & ASSERT
+	match.AddResult(board.COLOR_WHITE, 4)
+	if !match.Crawford || match.Away(board.COLOR_WHITE) != 1 || match.MWC(board.COLOR_WHITE) != Builtin().MWC(1, 5) {
+		t.Errorf("Output %v not equal to expected %v", match, "the Crawford game")
+	}
+	if _, err := match.CubeDecision(ai.Evaluation{Win: 0.3}, game.NewCube(), board.COLOR_BLACK); err != cube.ErrCrawford {
+		t.Errorf("Output %v not equal to expected %v", err, cube.ErrCrawford)
+	}
+	match.AddResult(board.COLOR_BLACK, 2)
+	if match.Crawford || !match.PostCrawford || match.MWC(board.COLOR_BLACK) != Builtin().PostCrawfordMWC(3, 1) {
+		t.Errorf("Output %v not equal to expected %v", match, "a post Crawford game")
+	}
+	match.AddResult(board.COLOR_WHITE, 1)
+	if !match.IsOver() || match.MWC(board.COLOR_WHITE) != 1 {
+		t.Errorf("Output %v not equal to expected %v", match, "the end of the match")
+	}
+	if _, err := NewMatch(BUILTIN_LENGTH+1, nil); err != ErrMatchTooLong {
+		t.Errorf("Output %v not equal to expected %v", err, ErrMatchTooLong)
+	}
+}
+
+func TestEvaluationMWC(t *testing.T) {
+	// ARRANGE
+	match, _ := NewMatch(3, nil)
+	table := Builtin()
+
+	// ACT
+	single := match.EvaluationMWC(ai.Evaluation{Win: 0.6}, board.COLOR_WHITE, 1)
+	gammons := match.EvaluationMWC(ai.Evaluation{Win: 0.6, WinGammon: 0.2}, board.COLOR_WHITE, 1)
+
+	// ASSERT
+	expected := 0.6*table.MWC(2, 3) + 0.4*table.MWC(3, 2)
+	if math.Abs(single-expected) > 1e-9 {
+		t.Errorf("Output %v not equal to expected %v", single, expected)
+	}
+	expected = 0.4*table.MWC(2, 3) + 0.2*table.MWC(1, 3) + 0.4*table.MWC(3, 2)
+	if math.Abs(gammons-expected) > 1e-9 {
+		t.Errorf("Output %v not equal to expected %v", gammons, expected)
+	}
+}
+
+// AI recording the match and the cube value of the games it plays
+type matchRecorderAI struct {
+	match     *ai.MatchEquity
+	cubeValue *int
+}
+
+func (a matchRecorderAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	return b.GetValidMovesForDieRoll(d)[0]
+}
+
+func (a matchRecorderAI) ChooseMoveContext(ctx context.Context, b board.Board, d board.DieRoll, progress ai.ProgressFunc) (board.MoveRoll, error) {
+	*a.match, *a.cubeValue, _ = ai.MatchFromContext(ctx)
+	return a.ChooseMove(b, d), nil
+}
+
+func TestMatchGame(t *testing.T) {
+	// ARRANGE
+	match, _ := NewMatch(5, nil)
+	g := match.NewGame(board.NewBoard(board.COLOR_WHITE))
+	g.SetDice(board.DieRoll{Die1: 6, Die2: 5})
+	var seen ai.MatchEquity
+	cubeValue := 0
+
+	// ACT
+	_, err := g.PlayAI(context.Background(), game.AIPlayer{Color: board.COLOR_WHITE, AI: matchRecorderAI{&seen, &cubeValue}})
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if seen != ai.MatchEquity(match) || cubeValue != 1 {
+		t.Errorf("Output %v %v not equal to expected %v %v", seen, cubeValue, match, 1)
+	}
+}
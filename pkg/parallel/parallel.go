@@ -0,0 +1,39 @@
+// Package parallel runs independent jobs on a bounded number of goroutines.
+// Every job writes its result at its own index, so the results do not
+// depend on the number of goroutines nor on their scheduling
+package parallel
+
+import "sync"
+
+// Function that calls fn for every index from 0 to n-1 on at most workers
+// goroutines and waits for all the calls to return. With at most one
+// worker, or a single job, the calls are made in order on the calling
+// goroutine. fn must be safe to call concurrently for different indexes
+func For(n int, workers int, fn func(idx int)) {
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for idx := 0; idx < n; idx++ {
+			fn(idx)
+		}
+		return
+	}
+
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				fn(idx)
+			}
+		}()
+	}
+	for idx := 0; idx < n; idx++ {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+}
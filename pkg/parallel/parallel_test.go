@@ -0,0 +1,41 @@
+package parallel
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestFor(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 100} {
+		// ARRANGE
+		squares := make([]int, 50)
+		running, maxRunning := int32(0), int32(0)
+
+		// ACT
+		For(len(squares), workers, func(idx int) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				seen := atomic.LoadInt32(&maxRunning)
+				if current <= seen || atomic.CompareAndSwapInt32(&maxRunning, seen, current) {
+					break
+				}
+			}
+			squares[idx] = idx * idx
+			atomic.AddInt32(&running, -1)
+		})
+
+		// ASSERT
+		for idx, square := range squares {
+			if square != idx*idx {
+				t.Errorf("Output %v not equal to expected %v", square, idx*idx)
+			}
+		}
+		limit := int32(workers)
+		if limit < 1 {
+			limit = 1
+		}
+		if maxRunning > limit {
+			t.Errorf("Output %v not equal to expected at most %v goroutines", maxRunning, limit)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package perft
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+// Difference between the move generator of the board package and the
+// reference generator for a position and a dice roll, positions are
+// written serialized
+type Mismatch struct {
+	Board board.Board
+	Dice  board.DieRoll
+	// Positions only the reference generator reaches
+	Missing []string
+	// Positions only the move generator reaches
+	Extra []string
+	// Positions the move generator returns more than once
+	Duplicates []string
+	// Move rolls not leading to the board they are returned with, or
+	// playing fewer dice than the reference generator
+	Invalid []string
+}
+
+func (m Mismatch) String() string {
+	lines := []string{fmt.Sprintf("%s rolling %d-%d:", m.Board.SerializeBoard(), m.Dice.Die1, m.Dice.Die2)}
+	for _, group := range []struct {
+		name      string
+		positions []string
+	}{{"missing", m.Missing}, {"extra", m.Extra}, {"duplicate", m.Duplicates}, {"invalid", m.Invalid}} {
+		for _, position := range group.positions {
+			lines = append(lines, fmt.Sprintf("  %s %s", group.name, position))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Function that compares the move rolls of the board package with the
+// reference generator for a position and a dice roll, it returns false
+// if they differ
+func Check(b board.Board, d board.DieRoll) (Mismatch, bool) {
+	mismatch := Mismatch{Board: b, Dice: d}
+	reference, diceUsed := referencePositions(b, d)
+
+	seen := map[string]bool{}
+	for _, result := range b.GetValidMoveResultsForDieRoll(d) {
+		position := result.Board.SerializeBoard()
+		if seen[position] {
+			mismatch.Duplicates = append(mismatch.Duplicates, position)
+		}
+		seen[position] = true
+		if _, ok := reference[position]; !ok {
+			mismatch.Extra = append(mismatch.Extra, position)
+		}
+		if result.MoveRoll.MakeMoveRoll(b).SerializeBoard() != position || len(result.MoveRoll) != diceUsed {
+			mismatch.Invalid = append(mismatch.Invalid, result.Notation)
+		}
+	}
+	for position := range reference {
+		if !seen[position] {
+			mismatch.Missing = append(mismatch.Missing, position)
+		}
+	}
+	sort.Strings(mismatch.Missing)
+	ok := len(mismatch.Missing) == 0 && len(mismatch.Extra) == 0 && len(mismatch.Duplicates) == 0 && len(mismatch.Invalid) == 0
+	return mismatch, ok
+}
+
+// Function that checks every position of the perft tree to the given depth
+// with every dice roll, each distinct position once, and returns the mismatches
+func CheckTree(b board.Board, depth int) []Mismatch {
+	mismatches := []Mismatch{}
+	visited := map[string]bool{}
+	var walk func(b board.Board, depth int)
+	walk = func(b board.Board, depth int) {
+		key := fmt.Sprintf("%s %d", b.SerializeBoard(), depth)
+		if depth <= 0 || isOver(b) || visited[key] {
+			return
+		}
+		visited[key] = true
+		for _, roll := range Rolls() {
+			if mismatch, ok := Check(b, roll); !ok {
+				mismatches = append(mismatches, mismatch)
+			}
+			if depth == 1 {
+				continue
+			}
+			positions := ReferencePositions(b, roll)
+			keys := []string{}
+			for key := range positions {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			if len(keys) == 0 {
+				// The player cannot move and passes
+				positions = map[string]board.Board{"": b}
+				keys = []string{""}
+			}
+			for _, position := range keys {
+				next := positions[position].CopyBoard()
+				next.ColorToMove = board.Color(1 - b.ColorToMove)
+				walk(next, depth-1)
+			}
+		}
+	}
+	walk(b, depth)
+	return mismatches
+}
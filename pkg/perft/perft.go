@@ -0,0 +1,117 @@
+// Package perft verifies the move generator the way chess engines do: it
+// counts the leaf positions of the tree of every dice roll and move roll
+// to a given depth, to compare the counts with reference values and time
+// the generator, and cross-checks the generator against a slow generator
+// written straight from the rules
+package perft
+
+import (
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/parallel"
+)
+
+// Leaf counts of Perft at depths 1 and 2 for some positions, agreed on by
+// the generator of the board package and the reference generator
+var REFERENCE_COUNTS = map[string][]uint64{
+	// Initial position
+	"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w": {447, 202782},
+	// White on the bar against a 5 point board
+	"6-4/8-3/13-5/24-2:1-2/2-2/3-2/4-2/5-2/12-5 1 0 w": {158, 115498},
+	// Contact bear-off
+	"1-2/2-2/3-3/4-2/5-2/6-2/8-2:17-2/19-3/20-3/21-2/22-3/23-2 0 0 w": {376, 151152},
+	// Race bear-off
+	"1-3/2-3/3-3/4-3/5-3:20-3/21-3/22-3/23-3/24-3 0 0 w": {206, 42436},
+}
+
+// Function that returns the reference leaf count of a position at a
+// depth, if it is known
+func ReferenceCount(b board.Board, depth int) (uint64, bool) {
+	counts, ok := REFERENCE_COUNTS[b.SerializeBoard()]
+	if !ok || depth < 1 || depth > len(counts) {
+		return 0, false
+	}
+	return counts[depth-1], true
+}
+
+// Function returning the distinct positions the player to move can reach
+// with the dice, the color to move is not switched
+type Generator func(b board.Board, d board.DieRoll) []board.Board
+
+// Generator of the board package
+func BoardGenerator(b board.Board, d board.DieRoll) []board.Board {
+	results := b.GetValidMoveResultsForDieRoll(d)
+	boards := make([]board.Board, len(results))
+	for idx, result := range results {
+		boards[idx] = result.Board
+	}
+	return boards
+}
+
+// Generator written straight from the rules, see ReferencePositions
+func ReferenceGenerator(b board.Board, d board.DieRoll) []board.Board {
+	boards := []board.Board{}
+	for _, position := range ReferencePositions(b, d) {
+		boards = append(boards, position)
+	}
+	return boards
+}
+
+// Function that returns the 21 distinct dice rolls
+func Rolls() []board.DieRoll {
+	rolls := []board.DieRoll{}
+	for d1 := 1; d1 <= 6; d1++ {
+		for d2 := d1; d2 <= 6; d2++ {
+			rolls = append(rolls, board.DieRoll{Die1: d1, Die2: d2})
+		}
+	}
+	return rolls
+}
+
+// Function that counts the leaf positions at the given depth, a ply being
+// one of the 21 dice rolls followed by one of the distinct positions the
+// player to move can reach with it. A player who cannot move passes, which
+// counts as one position, and a game that is over is a leaf at any depth
+func Perft(b board.Board, depth int, generator Generator) uint64 {
+	if depth <= 0 || isOver(b) {
+		return 1
+	}
+	count := uint64(0)
+	for _, roll := range Rolls() {
+		count += perftRoll(b, roll, depth, generator)
+	}
+	return count
+}
+
+// Function that counts the leaf positions at the given depth starting with
+// each of the 21 dice rolls, in the order of Rolls. The rolls are counted
+// on at most workers goroutines, the generator must be safe for
+// concurrent use if it is above 1
+func Divide(b board.Board, depth int, generator Generator, workers int) []uint64 {
+	rolls := Rolls()
+	counts := make([]uint64, len(rolls))
+	if depth <= 0 || isOver(b) {
+		return counts
+	}
+	parallel.For(len(rolls), workers, func(idx int) {
+		counts[idx] = perftRoll(b, rolls[idx], depth, generator)
+	})
+	return counts
+}
+
+func perftRoll(b board.Board, roll board.DieRoll, depth int, generator Generator) uint64 {
+	positions := generator(b, roll)
+	if len(positions) == 0 {
+		positions = []board.Board{b}
+	}
+	count := uint64(0)
+	for _, position := range positions {
+		next := position.CopyBoard()
+		next.ColorToMove = board.Color(1 - b.ColorToMove)
+		count += Perft(next, depth-1, generator)
+	}
+	return count
+}
+
+func isOver(b board.Board) bool {
+	return b.CheckersOff(board.COLOR_WHITE) == board.INIT_NUM_CHECKERS || b.CheckersOff(board.COLOR_BLACK) == board.INIT_NUM_CHECKERS
+}
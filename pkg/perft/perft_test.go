@@ -0,0 +1,89 @@
+package perft
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+type referenceMovesTest struct {
+	board    string
+	die      int
+	expected []board.Move
+}
+
+func TestPerftReferenceCounts(t *testing.T) {
+	for position, counts := range REFERENCE_COUNTS {
+		// ARRANGE
+		b := board.DeserializeBoard(position)
+
+		// ACT
+		output := Perft(b, 1, BoardGenerator)
+		reference := Perft(b, 1, ReferenceGenerator)
+
+		// ASSERT
+		if output != counts[0] || reference != counts[0] {
+			t.Errorf("Output %v %v not equal to expected %v for %v", output, reference, counts[0], position)
+		}
+	}
+}
+
+func TestDivide(t *testing.T) {
+	// ARRANGE
+	b := board.DeserializeBoard("1-3/2-3/3-3/4-3/5-3:20-3/21-3/22-3/23-3/24-3 0 0 w")
+	expected, _ := ReferenceCount(b, 2)
+
+	// ACT
+	counts := Divide(b, 2, BoardGenerator, 4)
+
+	// ASSERT
+	output := uint64(0)
+	for _, count := range counts {
+		output += count
+	}
+	if len(counts) != 21 || output != expected {
+		t.Errorf("Output %v not equal to expected %v", output, expected)
+	}
+}
+
+func TestCheckTree(t *testing.T) {
+	for position := range REFERENCE_COUNTS {
+		// ACT
+		mismatches := CheckTree(board.DeserializeBoard(position), 1)
+
+		// ASSERT
+		for _, mismatch := range mismatches {
+			t.Errorf("Unexpected mismatch %v", mismatch)
+		}
+	}
+}
+
+func TestReferenceMoves(t *testing.T) {
+	for _, test := range makeReferenceMovesTests() {
+		// ACT
+		output := ReferenceMoves(board.DeserializeBoard(test.board), test.die)
+
+		// ASSERT
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v for %v", output, test.expected, test.board)
+		}
+	}
+}
+
+func makeReferenceMovesTests() []referenceMovesTest {
+	return []referenceMovesTest{
+		// A die bigger than the highest point bears off from the highest point only
+		{"2-1/4-2:19-15 0 0 w", 5, []board.Move{{From: 3, To: board.TO_INDEX_FOR_BEARING_OFF, Type: board.BEARING_OFF_MOVE}}},
+		// A die matching a point bears off from it and moves the higher checkers
+		{"2-1/4-2:19-15 0 0 w", 2, []board.Move{
+			{From: 3, To: 1, Type: board.NORMAL_MOVE},
+			{From: 1, To: board.TO_INDEX_FOR_BEARING_OFF, Type: board.BEARING_OFF_MOVE},
+		}},
+		// No bearing off with a checker outside of the home board
+		{"2-1/7-1:19-15 0 0 w", 2, []board.Move{{From: 6, To: 4, Type: board.NORMAL_MOVE}}},
+		// A checker on the bar enters first, on an open point only
+		{"6-14:1-2/2-13 1 0 w", 6, []board.Move{{From: board.WHITE_PIECES_BAR_POINT_INDEX, To: 18, Type: board.CHECKER_ON_BAR_MOVE}}},
+		{"6-14:1-2/19-13 1 0 w", 6, []board.Move{}},
+	}
+}
@@ -0,0 +1,140 @@
+package perft
+
+import (
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+// A position reached by the reference generator with the dice it used
+type referenceLeaf struct {
+	board board.Board
+	dice  []int
+}
+
+// Function that returns the distinct positions the player to move can
+// reach with the dice, keyed by their serialization, straight from the
+// rules: every order of the dice is tried checker by checker, the move
+// rolls using the most dice are kept and, if only one die of a non double
+// can be played, the bigger one is played when possible. It is written to
+// be obviously correct rather than fast, to check the move generator of
+// the board package
+// NOTE: the resulting boards keep the color to move of the initial board
+func ReferencePositions(b board.Board, d board.DieRoll) map[string]board.Board {
+	positions, _ := referencePositions(b, d)
+	return positions
+}
+
+// Function that returns the reference positions with the number of dice
+// played to reach them
+func referencePositions(b board.Board, d board.DieRoll) (map[string]board.Board, int) {
+	orders := [][]int{{d.Die1, d.Die2}, {d.Die2, d.Die1}}
+	if d.Die1 == d.Die2 {
+		orders = [][]int{{d.Die1, d.Die1, d.Die1, d.Die1}}
+	}
+	leaves := []referenceLeaf{}
+	for _, order := range orders {
+		leaves = append(leaves, referenceSequences(b, order, []int{})...)
+	}
+
+	mostDice := 0
+	biggerDie := false
+	for _, leaf := range leaves {
+		if len(leaf.dice) > mostDice {
+			mostDice, biggerDie = len(leaf.dice), false
+		}
+		if len(leaf.dice) == 1 && leaf.dice[0] == maxDie(d) {
+			biggerDie = true
+		}
+	}
+	positions := map[string]board.Board{}
+	if mostDice == 0 {
+		// The player cannot move
+		return positions, 0
+	}
+	for _, leaf := range leaves {
+		if len(leaf.dice) != mostDice {
+			continue
+		}
+		if mostDice == 1 && d.Die1 != d.Die2 && biggerDie && leaf.dice[0] != maxDie(d) {
+			continue
+		}
+		positions[leaf.board.SerializeBoard()] = leaf.board
+	}
+	return positions, mostDice
+}
+
+// Function that plays the dice in order as long as a checker can move,
+// returning the positions reached with the dice played to reach them
+func referenceSequences(b board.Board, dice []int, played []int) []referenceLeaf {
+	if len(dice) == 0 {
+		return []referenceLeaf{{b, played}}
+	}
+	moves := ReferenceMoves(b, dice[0])
+	if len(moves) == 0 {
+		return []referenceLeaf{{b, played}}
+	}
+	leaves := []referenceLeaf{}
+	for _, mv := range moves {
+		next := append(append([]int{}, played...), dice[0])
+		leaves = append(leaves, referenceSequences(mv.MakeMove(b), dice[1:], next)...)
+	}
+	return leaves
+}
+
+// Function that returns the moves of a single checker of the player to
+// move with one die, reasoning with the point numbers of the player:
+// a checker on point n moves to point n - die, point 25 being the bar and
+// point 0 being borne off
+func ReferenceMoves(b board.Board, die int) []board.Move {
+	color := b.ColorToMove
+	bar := board.PointIndexFromNumber(board.NUM_PLAYABLE_POINTS+1, color)
+	if b.Points[bar].CheckerCount > 0 {
+		to := board.NUM_PLAYABLE_POINTS + 1 - die
+		if !isOpen(b, to) {
+			return []board.Move{}
+		}
+		return []board.Move{{From: bar, To: board.PointIndexFromNumber(to, color), Type: board.CHECKER_ON_BAR_MOVE}}
+	}
+
+	highest := 0
+	for number := 1; number <= board.NUM_PLAYABLE_POINTS; number++ {
+		if ownsPoint(b, number) {
+			highest = number
+		}
+	}
+	moves := []board.Move{}
+	for from := board.NUM_PLAYABLE_POINTS; from >= 1; from-- {
+		if !ownsPoint(b, from) {
+			continue
+		}
+		to := from - die
+		switch {
+		case to >= 1 && isOpen(b, to):
+			moves = append(moves, board.Move{From: board.PointIndexFromNumber(from, color), To: board.PointIndexFromNumber(to, color), Type: board.NORMAL_MOVE})
+		case to < 1 && highest <= 6 && (to == 0 || from == highest):
+			// Bearing off needs all the checkers home, a higher die than
+			// the point only bears off from the highest point
+			moves = append(moves, board.Move{From: board.PointIndexFromNumber(from, color), To: board.TO_INDEX_FOR_BEARING_OFF, Type: board.BEARING_OFF_MOVE})
+		}
+	}
+	return moves
+}
+
+// Function that checks whether the player to move has checkers on the point
+func ownsPoint(b board.Board, number int) bool {
+	point := b.Points[board.PointIndexFromNumber(number, b.ColorToMove)]
+	return point.CheckerCount > 0 && point.Checker.Color == b.ColorToMove
+}
+
+// Function that checks whether the player to move can land on the point,
+// i.e. the opponent has at most one checker on it
+func isOpen(b board.Board, number int) bool {
+	point := b.Points[board.PointIndexFromNumber(number, b.ColorToMove)]
+	return point.CheckerCount < 2 || point.Checker.Color == b.ColorToMove
+}
+
+func maxDie(d board.DieRoll) int {
+	if d.Die1 > d.Die2 {
+		return d.Die1
+	}
+	return d.Die2
+}
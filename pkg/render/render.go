@@ -0,0 +1,230 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+const (
+	RESET_COLOR     = "\033[0m"
+	WHITE_COLOR     = "\033[34m"
+	BLACK_COLOR     = "\033[31m"
+	HIGHLIGHT_COLOR = "\033[1;33m"
+)
+
+const WHITE_SYMBOL = "O"
+const BLACK_SYMBOL = "X"
+
+// Number of checkers drawn on a point, bigger stacks show their size instead
+const MAX_STACK_HEIGHT = 5
+
+type Cube struct {
+	Value int
+	// Owner of the cube, ignored if the cube is centered
+	Owner    board.Color
+	Centered bool
+}
+
+type Options struct {
+	// Player whose home board is drawn at the bottom right,
+	// points are numbered from his perspective
+	Perspective board.Color
+	// Draw without ANSI escape codes
+	Plain bool
+	// Dice rolled by the player to move, nil if the dice were not rolled
+	Dice *board.DieRoll
+	// Doubling cube, nil if the game is played without a cube
+	Cube *Cube
+	// Last move roll played, the points it touched are highlighted
+	LastMove board.MoveRoll
+}
+
+// Function that checks whether colors should be used when drawing to f:
+// f must be a terminal and the user must not have disabled colors
+func ColorSupported(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Function that draws a board with stacked checkers, the bar, the borne
+// off checkers, the dice and the cube:
+//
+//	+-13-14-15-16-17-18-+---+-19-20-21-22-23-24-+---+
+//	| X           O     |   | O              X  |   |
+//	...
+//	+-12-11-10-9--8--7--+---+-6--5--4--3--2--1--+---+
+//
+// The top half holds the points 13..24 and the bottom half the points 12..1
+// of the perspective player, the right most column holds the borne off checkers
+func Render(b board.Board, opts Options) string {
+	r := renderer{b, opts, map[board.PointIndex]bool{}}
+	for _, mv := range opts.LastMove {
+		r.highlighted[mv.From] = true
+		r.highlighted[mv.To] = true
+	}
+	return r.render()
+}
+
+type renderer struct {
+	b           board.Board
+	opts        Options
+	highlighted map[board.PointIndex]bool
+}
+
+func (r renderer) render() string {
+	perspective := r.opts.Perspective
+	opponent := board.Color(1 - perspective)
+	topLeft := []int{13, 14, 15, 16, 17, 18}
+	topRight := []int{19, 20, 21, 22, 23, 24}
+	bottomLeft := []int{12, 11, 10, 9, 8, 7}
+	bottomRight := []int{6, 5, 4, 3, 2, 1}
+
+	lines := []string{}
+	lines = append(lines, r.cubeColumn()+r.labels(topLeft, topRight))
+	for row := 0; row < MAX_STACK_HEIGHT; row++ {
+		line := r.cubeColumn() + "|" + r.stackRow(topLeft, row) + "|" +
+			r.barCell(perspective, row) + "|" + r.stackRow(topRight, row) + "|" +
+			r.offCell(opponent, row) + "|"
+		if row == 0 && r.cubeOwnedBy(opponent) {
+			line = r.cubeCell() + line[len(r.cubeColumn()):]
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, r.middleLine())
+	for row := MAX_STACK_HEIGHT - 1; row >= 0; row-- {
+		line := r.cubeColumn() + "|" + r.stackRow(bottomLeft, row) + "|" +
+			r.barCell(opponent, row) + "|" + r.stackRow(bottomRight, row) + "|" +
+			r.offCell(perspective, row) + "|"
+		if row == 0 && r.cubeOwnedBy(perspective) {
+			line = r.cubeCell() + line[len(r.cubeColumn()):]
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, r.cubeColumn()+r.labels(bottomLeft, bottomRight))
+	lines = append(lines, r.summary(board.COLOR_WHITE), r.summary(board.COLOR_BLACK))
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Function that returns the border line holding the point numbers
+func (r renderer) labels(left []int, right []int) string {
+	label := func(numbers []int) string {
+		text := ""
+		for _, number := range numbers {
+			marker := "-"
+			if r.highlighted[board.PointIndexFromNumber(number, r.opts.Perspective)] {
+				marker = "*"
+			}
+			// Checkers are drawn below the first digit of their point number
+			numberText := strings.Replace(fmt.Sprintf("%-2d", number), " ", "-", 1)
+			text += marker + r.colorize(numberText, HIGHLIGHT_COLOR, marker == "*")
+		}
+		return text
+	}
+	return "+" + label(left) + "-+---+" + label(right) + "-+---+"
+}
+
+// Function that returns the cells of a row of checkers, row 0 is the
+// one next to the border holding the point numbers
+func (r renderer) stackRow(numbers []int, row int) string {
+	text := ""
+	for _, number := range numbers {
+		point := r.b.Points[board.PointIndexFromNumber(number, r.opts.Perspective)]
+		text += " " + r.stackCell(point.CheckerCount, point.Checker.Color, row)
+	}
+	return text + " "
+}
+
+// Function that returns the 2 characters drawn for a stack of checkers on a given row
+func (r renderer) stackCell(count int, color board.Color, row int) string {
+	if count <= row {
+		return "  "
+	}
+	if row == MAX_STACK_HEIGHT-1 && count > MAX_STACK_HEIGHT {
+		return r.colorize(fmt.Sprintf("%-2d", count), checkerColor(color), true)
+	}
+	return r.checker(color) + " "
+}
+
+func (r renderer) barCell(color board.Color, row int) string {
+	count := r.b.Points[board.PointIndexFromNumber(board.NUM_PLAYABLE_POINTS+1, color)].CheckerCount
+	return " " + r.stackCell(count, color, row)
+}
+
+func (r renderer) offCell(color board.Color, row int) string {
+	count := r.b.CheckersOff(color)
+	if row == 0 && count > 0 {
+		return r.colorize(fmt.Sprintf("%3d", count), checkerColor(color), true)
+	}
+	return "   "
+}
+
+func (r renderer) middleLine() string {
+	side := strings.Repeat(" ", 19)
+	line := r.cubeColumn()
+	if r.opts.Cube != nil && r.opts.Cube.Centered {
+		line = r.cubeCell()
+	}
+	line += "|" + side + "|BAR|" + side + "|OFF|"
+	if r.opts.Dice != nil {
+		line += fmt.Sprintf("  %s rolled %d-%d", colorName(r.b.ColorToMove), r.opts.Dice.Die1, r.opts.Dice.Die2)
+	}
+	return line
+}
+
+// Function that returns the empty column left of the board where the cube is drawn
+func (r renderer) cubeColumn() string {
+	if r.opts.Cube == nil {
+		return ""
+	}
+	return "     "
+}
+
+func (r renderer) cubeCell() string {
+	return fmt.Sprintf("[%2d] ", r.opts.Cube.Value)
+}
+
+func (r renderer) cubeOwnedBy(color board.Color) bool {
+	return r.opts.Cube != nil && !r.opts.Cube.Centered && r.opts.Cube.Owner == color
+}
+
+func (r renderer) summary(color board.Color) string {
+	return fmt.Sprintf("%s %s: pips %d, off %d", r.checker(color), colorName(color), r.b.PipCount(color), r.b.CheckersOff(color))
+}
+
+func (r renderer) checker(color board.Color) string {
+	symbol := WHITE_SYMBOL
+	if color == board.COLOR_BLACK {
+		symbol = BLACK_SYMBOL
+	}
+	return r.colorize(symbol, checkerColor(color), true)
+}
+
+func (r renderer) colorize(text string, color string, enabled bool) string {
+	if r.opts.Plain || !enabled {
+		return text
+	}
+	return color + text + RESET_COLOR
+}
+
+func checkerColor(color board.Color) string {
+	if color == board.COLOR_WHITE {
+		return WHITE_COLOR
+	}
+	return BLACK_COLOR
+}
+
+func colorName(color board.Color) string {
+	if color == board.COLOR_WHITE {
+		return "White"
+	}
+	return "Black"
+}
@@ -0,0 +1,86 @@
+package render
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+type renderTest struct {
+	name    string
+	board   board.Board
+	options Options
+}
+
+func TestRenderGolden(t *testing.T) {
+	for _, test := range makeRenderTests() {
+		output := Render(test.board, test.options)
+		path := filepath.Join("testdata", test.name+".golden")
+		if *update {
+			if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		expected, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output != string(expected) {
+			t.Errorf("%s: output\n%s\nnot equal to expected\n%s", test.name, output, expected)
+		}
+	}
+}
+
+func TestRenderPlainHasNoEscapeCodes(t *testing.T) {
+	for _, test := range makeRenderTests() {
+		if output := Render(test.board, test.options); strings.Contains(output, "\033") {
+			t.Errorf("%s: plain output contains escape codes", test.name)
+		}
+	}
+}
+
+func TestRenderColor(t *testing.T) {
+	output := Render(board.NewBoard(board.COLOR_WHITE), Options{})
+	if !strings.Contains(output, WHITE_COLOR+WHITE_SYMBOL+RESET_COLOR) || !strings.Contains(output, BLACK_COLOR+BLACK_SYMBOL+RESET_COLOR) {
+		t.Errorf("Colored output does not contain colored checkers:\n%s", output)
+	}
+}
+
+func makeRenderTests() []renderTest {
+	// test 1 - initial position from white's perspective
+	board1 := board.NewBoard(board.COLOR_WHITE)
+
+	// test 2 - initial position from black's perspective with dice and a centered cube
+	board2 := board.NewBoard(board.COLOR_BLACK)
+	dice2 := board.DieRoll{Die1: 6, Die2: 5}
+
+	// test 3 - checkers on the bar, borne off, tall stacks and the last move highlighted
+	board3 := board.DeserializeBoard("1-7/2-3/6-2/15-1:5-1/20-2/23-3/24-6 1 2 w")
+	lastMove3 := board.MoveRoll{
+		{From: 11, To: 4, Type: board.NORMAL_MOVE},
+		{From: 1, To: board.TO_INDEX_FOR_BEARING_OFF, Type: board.BEARING_OFF_MOVE},
+	}
+
+	return []renderTest{
+		{"initial_white", board1, Options{Perspective: board.COLOR_WHITE, Plain: true}},
+		{"initial_black_dice_cube", board2, Options{
+			Perspective: board.COLOR_BLACK,
+			Plain:       true,
+			Dice:        &dice2,
+			Cube:        &Cube{Value: 1, Centered: true},
+		}},
+		{"bar_off_last_move", board3, Options{
+			Perspective: board.COLOR_WHITE,
+			Plain:       true,
+			Cube:        &Cube{Value: 4, Owner: board.COLOR_BLACK},
+			LastMove:    lastMove3,
+		}},
+	}
+}
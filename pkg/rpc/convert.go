@@ -0,0 +1,183 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/rpc/enginepb"
+)
+
+var ErrMissingBoard = errors.New("missing board")
+var ErrMissingDice = errors.New("missing dice")
+
+func ColorToProto(color board.Color) enginepb.Color {
+	if color == board.COLOR_WHITE {
+		return enginepb.Color_COLOR_WHITE
+	}
+	return enginepb.Color_COLOR_BLACK
+}
+
+func ColorFromProto(color enginepb.Color) (board.Color, error) {
+	switch color {
+	case enginepb.Color_COLOR_WHITE:
+		return board.COLOR_WHITE, nil
+	case enginepb.Color_COLOR_BLACK:
+		return board.COLOR_BLACK, nil
+	}
+	return 0, fmt.Errorf("invalid color %v", color)
+}
+
+func BoardToProto(b board.Board) *enginepb.Board {
+	points := make([]*enginepb.Point, len(b.Points))
+	for idx, point := range b.Points {
+		points[idx] = &enginepb.Point{Color: ColorToProto(point.Checker.Color), CheckerCount: int32(point.CheckerCount)}
+	}
+	return &enginepb.Board{Points: points, ColorToMove: ColorToProto(b.ColorToMove)}
+}
+
+// Function that converts a board message to a board, checking that it holds
+// every point, that the bars hold the checkers of the right player and
+// that no player has more than 15 checkers
+func BoardFromProto(pb *enginepb.Board) (board.Board, error) {
+	if pb == nil {
+		return board.Board{}, ErrMissingBoard
+	}
+	if len(pb.Points) != board.NUM_POINTS {
+		return board.Board{}, fmt.Errorf("invalid board: expected %d points, got %d", board.NUM_POINTS, len(pb.Points))
+	}
+	colorToMove, err := ColorFromProto(pb.ColorToMove)
+	if err != nil {
+		return board.Board{}, fmt.Errorf("invalid board: %v to move", err)
+	}
+
+	b := board.NewBoard(colorToMove)
+	numCheckers := map[board.Color]int{}
+	for idx, point := range pb.Points {
+		if point.GetCheckerCount() < 0 {
+			return board.Board{}, fmt.Errorf("invalid board: negative checker count on point %d", idx)
+		}
+		if point.GetCheckerCount() == 0 {
+			b.Points[idx].CheckerCount = 0
+			continue
+		}
+		color, err := ColorFromProto(point.GetColor())
+		if err != nil {
+			return board.Board{}, fmt.Errorf("invalid board: %v on point %d", err, idx)
+		}
+		if (idx == board.WHITE_PIECES_BAR_POINT_INDEX && color != board.COLOR_WHITE) ||
+			(idx == board.BLACK_PIECES_BAR_POINT_INDEX && color != board.COLOR_BLACK) {
+			return board.Board{}, fmt.Errorf("invalid board: checkers of the wrong player on bar %d", idx)
+		}
+		b.Points[idx].CheckerCount = int(point.GetCheckerCount())
+		b.Points[idx].Checker.Color = color
+		numCheckers[color] += int(point.GetCheckerCount())
+	}
+	for color, count := range numCheckers {
+		if count > board.INIT_NUM_CHECKERS {
+			return board.Board{}, fmt.Errorf("invalid board: %v has %d checkers", ColorToProto(color), count)
+		}
+	}
+	return b, nil
+}
+
+func MoveTypeToProto(moveType board.MoveType) enginepb.MoveType {
+	switch moveType {
+	case board.CHECKER_ON_BAR_MOVE:
+		return enginepb.MoveType_MOVE_TYPE_CHECKER_ON_BAR
+	case board.BEARING_OFF_MOVE:
+		return enginepb.MoveType_MOVE_TYPE_BEARING_OFF
+	}
+	return enginepb.MoveType_MOVE_TYPE_NORMAL
+}
+
+func MoveTypeFromProto(moveType enginepb.MoveType) (board.MoveType, error) {
+	switch moveType {
+	case enginepb.MoveType_MOVE_TYPE_NORMAL:
+		return board.NORMAL_MOVE, nil
+	case enginepb.MoveType_MOVE_TYPE_CHECKER_ON_BAR:
+		return board.CHECKER_ON_BAR_MOVE, nil
+	case enginepb.MoveType_MOVE_TYPE_BEARING_OFF:
+		return board.BEARING_OFF_MOVE, nil
+	}
+	return 0, fmt.Errorf("invalid move type %v", moveType)
+}
+
+func MoveToProto(mv board.Move) *enginepb.Move {
+	return &enginepb.Move{From: int32(mv.From), To: int32(mv.To), Type: MoveTypeToProto(mv.Type)}
+}
+
+func MoveFromProto(pb *enginepb.Move) (board.Move, error) {
+	moveType, err := MoveTypeFromProto(pb.GetType())
+	if err != nil {
+		return board.Move{}, err
+	}
+	if pb.GetFrom() < 0 || pb.GetFrom() >= board.NUM_POINTS || pb.GetTo() < int32(board.TO_INDEX_FOR_BEARING_OFF) || pb.GetTo() >= board.NUM_PLAYABLE_POINTS {
+		return board.Move{}, fmt.Errorf("invalid move from %d to %d", pb.GetFrom(), pb.GetTo())
+	}
+	return board.Move{From: board.PointIndex(pb.GetFrom()), To: board.PointIndex(pb.GetTo()), Type: moveType}, nil
+}
+
+func MoveRollToProto(mvRoll board.MoveRoll) *enginepb.MoveRoll {
+	moves := make([]*enginepb.Move, len(mvRoll))
+	for idx, mv := range mvRoll {
+		moves[idx] = MoveToProto(mv)
+	}
+	return &enginepb.MoveRoll{Moves: moves}
+}
+
+// Function that converts a move roll message to a move roll, a missing
+// message is the empty move roll
+func MoveRollFromProto(pb *enginepb.MoveRoll) (board.MoveRoll, error) {
+	mvRoll := board.MoveRoll{}
+	for _, move := range pb.GetMoves() {
+		mv, err := MoveFromProto(move)
+		if err != nil {
+			return nil, err
+		}
+		mvRoll = append(mvRoll, mv)
+	}
+	return mvRoll, nil
+}
+
+func DieRollToProto(d board.DieRoll) *enginepb.DieRoll {
+	return &enginepb.DieRoll{Die1: int32(d.Die1), Die2: int32(d.Die2)}
+}
+
+func DieRollFromProto(pb *enginepb.DieRoll) (board.DieRoll, error) {
+	if pb == nil {
+		return board.DieRoll{}, ErrMissingDice
+	}
+	if pb.Die1 < 1 || pb.Die1 > 6 || pb.Die2 < 1 || pb.Die2 > 6 {
+		return board.DieRoll{}, fmt.Errorf("invalid dice %d-%d", pb.Die1, pb.Die2)
+	}
+	return board.DieRoll{Die1: int(pb.Die1), Die2: int(pb.Die2)}, nil
+}
+
+// Function that converts a move roll result, the board of the message
+// has the opponent to move
+func MoveRollResultToProto(result board.MoveRollResult) *enginepb.MoveRollResult {
+	next := result.Board
+	next.ColorToMove = board.Color(1 - result.Board.ColorToMove)
+	return &enginepb.MoveRollResult{
+		MoveRoll:      MoveRollToProto(result.MoveRoll),
+		Board:         BoardToProto(next),
+		Notation:      result.Notation,
+		Hits:          int32(result.Hits),
+		BorneOff:      int32(result.BorneOff),
+		PointsMade:    int32(result.PointsMade),
+		EntersFromBar: result.EntersFromBar,
+	}
+}
+
+func EvaluationToProto(evaluation ai.Evaluation) *enginepb.Evaluation {
+	return &enginepb.Evaluation{
+		Win:            evaluation.Win,
+		WinGammon:      evaluation.WinGammon,
+		WinBackgammon:  evaluation.WinBackgammon,
+		LoseGammon:     evaluation.LoseGammon,
+		LoseBackgammon: evaluation.LoseBackgammon,
+		Equity:         evaluation.Equity(),
+	}
+}
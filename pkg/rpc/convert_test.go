@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/rpc/enginepb"
+)
+
+func makeBoardConversionTests() []string {
+	return []string{
+		"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w",
+		"6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 b",
+		"1-3/2-2/5-1:20-4/23-6 2 0 b",
+		"3-1:6-4 0 1 w",
+	}
+}
+
+func TestBoardConversionRoundTrip(t *testing.T) {
+	for _, test := range makeBoardConversionTests() {
+		// ARRANGE
+		b, err := board.ParseBoard(test)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+
+		// ACT
+		converted, err := BoardFromProto(BoardToProto(b))
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if converted.SerializeBoard() != test {
+			t.Errorf("Output %v not equal to expected %v", converted.SerializeBoard(), test)
+		}
+	}
+}
+
+func TestMoveRollConversionRoundTrip(t *testing.T) {
+	// ARRANGE
+	mvRoll := board.MoveRoll{
+		{From: board.WHITE_PIECES_BAR_POINT_INDEX, To: 20, Type: board.CHECKER_ON_BAR_MOVE},
+		{From: 12, To: 7, Type: board.NORMAL_MOVE},
+		{From: 3, To: board.TO_INDEX_FOR_BEARING_OFF, Type: board.BEARING_OFF_MOVE},
+	}
+
+	// ACT
+	converted, err := MoveRollFromProto(MoveRollToProto(mvRoll))
+
+	// ASSERT
+	if err != nil || !reflect.DeepEqual(converted, mvRoll) {
+		t.Errorf("Output %v %v not equal to expected %v", converted, err, mvRoll)
+	}
+}
+
+func TestDieRollConversion(t *testing.T) {
+	// ARRANGE
+	d := board.DieRoll{Die1: 6, Die2: 1}
+
+	// ACT
+	converted, err := DieRollFromProto(DieRollToProto(d))
+	_, invalidErr := DieRollFromProto(&enginepb.DieRoll{Die1: 0, Die2: 3})
+	_, missingErr := DieRollFromProto(nil)
+
+	// ASSERT
+	if err != nil || converted != d {
+		t.Errorf("Output %v not equal to expected %v", converted, d)
+	}
+	if invalidErr == nil || missingErr != ErrMissingDice {
+		t.Errorf("Output %v %v not equal to expected errors", invalidErr, missingErr)
+	}
+}
+
+func makeInvalidBoardTests() []*enginepb.Board {
+	valid := func() *enginepb.Board {
+		return BoardToProto(board.NewBoard(board.COLOR_WHITE))
+	}
+
+	tooFewPoints := valid()
+	tooFewPoints.Points = tooFewPoints.Points[:24]
+
+	noColorToMove := valid()
+	noColorToMove.ColorToMove = enginepb.Color_COLOR_UNSPECIFIED
+
+	negativeCount := valid()
+	negativeCount.Points[3].CheckerCount = -1
+
+	tooManyCheckers := valid()
+	tooManyCheckers.Points[5].CheckerCount = 6
+
+	wrongBar := valid()
+	wrongBar.Points[board.WHITE_PIECES_BAR_POINT_INDEX] = &enginepb.Point{Color: enginepb.Color_COLOR_BLACK, CheckerCount: 1}
+
+	noColor := valid()
+	noColor.Points[10] = &enginepb.Point{CheckerCount: 1}
+
+	return []*enginepb.Board{nil, tooFewPoints, noColorToMove, negativeCount, tooManyCheckers, wrongBar, noColor}
+}
+
+func TestBoardFromProtoValidation(t *testing.T) {
+	for idx, test := range makeInvalidBoardTests() {
+		// ACT
+		_, err := BoardFromProto(test)
+
+		// ASSERT
+		if err == nil {
+			t.Errorf("Test %d: expected an error", idx)
+		}
+	}
+}
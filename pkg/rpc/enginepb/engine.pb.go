@@ -0,0 +1,2204 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        (unknown)
+// source: engine.proto
+
+package enginepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Maps onto board.Color
+type Color int32
+
+const (
+	Color_COLOR_UNSPECIFIED Color = 0
+	Color_COLOR_WHITE       Color = 1
+	Color_COLOR_BLACK       Color = 2
+)
+
+// Enum value maps for Color.
+var (
+	Color_name = map[int32]string{
+		0: "COLOR_UNSPECIFIED",
+		1: "COLOR_WHITE",
+		2: "COLOR_BLACK",
+	}
+	Color_value = map[string]int32{
+		"COLOR_UNSPECIFIED": 0,
+		"COLOR_WHITE":       1,
+		"COLOR_BLACK":       2,
+	}
+)
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
+}
+
+func (x Color) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Color) Descriptor() protoreflect.EnumDescriptor {
+	return file_engine_proto_enumTypes[0].Descriptor()
+}
+
+func (Color) Type() protoreflect.EnumType {
+	return &file_engine_proto_enumTypes[0]
+}
+
+func (x Color) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Color.Descriptor instead.
+func (Color) EnumDescriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{0}
+}
+
+// Maps onto board.MoveType
+type MoveType int32
+
+const (
+	MoveType_MOVE_TYPE_UNSPECIFIED    MoveType = 0
+	MoveType_MOVE_TYPE_NORMAL         MoveType = 1
+	MoveType_MOVE_TYPE_CHECKER_ON_BAR MoveType = 2
+	MoveType_MOVE_TYPE_BEARING_OFF    MoveType = 3
+)
+
+// Enum value maps for MoveType.
+var (
+	MoveType_name = map[int32]string{
+		0: "MOVE_TYPE_UNSPECIFIED",
+		1: "MOVE_TYPE_NORMAL",
+		2: "MOVE_TYPE_CHECKER_ON_BAR",
+		3: "MOVE_TYPE_BEARING_OFF",
+	}
+	MoveType_value = map[string]int32{
+		"MOVE_TYPE_UNSPECIFIED":    0,
+		"MOVE_TYPE_NORMAL":         1,
+		"MOVE_TYPE_CHECKER_ON_BAR": 2,
+		"MOVE_TYPE_BEARING_OFF":    3,
+	}
+)
+
+func (x MoveType) Enum() *MoveType {
+	p := new(MoveType)
+	*p = x
+	return p
+}
+
+func (x MoveType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MoveType) Descriptor() protoreflect.EnumDescriptor {
+	return file_engine_proto_enumTypes[1].Descriptor()
+}
+
+func (MoveType) Type() protoreflect.EnumType {
+	return &file_engine_proto_enumTypes[1]
+}
+
+func (x MoveType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MoveType.Descriptor instead.
+func (MoveType) EnumDescriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{1}
+}
+
+// Maps onto board.Point, the color is ignored for empty points
+type Point struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color        Color `protobuf:"varint,1,opt,name=color,proto3,enum=backgammon.engine.v1.Color" json:"color,omitempty"`
+	CheckerCount int32 `protobuf:"varint,2,opt,name=checker_count,json=checkerCount,proto3" json:"checker_count,omitempty"`
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Point) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+func (x *Point) GetCheckerCount() int32 {
+	if x != nil {
+		return x.CheckerCount
+	}
+	return 0
+}
+
+// Maps onto board.Board: the 24 playable points indexed from 0 to 23, white
+// moving from 23 to 0 and black from 0 to 23, followed by the bar of black
+// at index 24 and the bar of white at index 25. Borne off checkers are
+// the checkers missing from the board
+type Board struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Points      []*Point `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	ColorToMove Color    `protobuf:"varint,2,opt,name=color_to_move,json=colorToMove,proto3,enum=backgammon.engine.v1.Color" json:"color_to_move,omitempty"`
+}
+
+func (x *Board) Reset() {
+	*x = Board{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Board) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Board) ProtoMessage() {}
+
+func (x *Board) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Board.ProtoReflect.Descriptor instead.
+func (*Board) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Board) GetPoints() []*Point {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *Board) GetColorToMove() Color {
+	if x != nil {
+		return x.ColorToMove
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+// Maps onto board.Move, the index a checker is borne off to is -1
+type Move struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From int32    `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   int32    `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Type MoveType `protobuf:"varint,3,opt,name=type,proto3,enum=backgammon.engine.v1.MoveType" json:"type,omitempty"`
+}
+
+func (x *Move) Reset() {
+	*x = Move{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Move) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Move) ProtoMessage() {}
+
+func (x *Move) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Move.ProtoReflect.Descriptor instead.
+func (*Move) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Move) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *Move) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *Move) GetType() MoveType {
+	if x != nil {
+		return x.Type
+	}
+	return MoveType_MOVE_TYPE_UNSPECIFIED
+}
+
+// Maps onto board.MoveRoll
+type MoveRoll struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Moves []*Move `protobuf:"bytes,1,rep,name=moves,proto3" json:"moves,omitempty"`
+}
+
+func (x *MoveRoll) Reset() {
+	*x = MoveRoll{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveRoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRoll) ProtoMessage() {}
+
+func (x *MoveRoll) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRoll.ProtoReflect.Descriptor instead.
+func (*MoveRoll) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MoveRoll) GetMoves() []*Move {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+// Maps onto board.DieRoll
+type DieRoll struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Die1 int32 `protobuf:"varint,1,opt,name=die1,proto3" json:"die1,omitempty"`
+	Die2 int32 `protobuf:"varint,2,opt,name=die2,proto3" json:"die2,omitempty"`
+}
+
+func (x *DieRoll) Reset() {
+	*x = DieRoll{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DieRoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DieRoll) ProtoMessage() {}
+
+func (x *DieRoll) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DieRoll.ProtoReflect.Descriptor instead.
+func (*DieRoll) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DieRoll) GetDie1() int32 {
+	if x != nil {
+		return x.Die1
+	}
+	return 0
+}
+
+func (x *DieRoll) GetDie2() int32 {
+	if x != nil {
+		return x.Die2
+	}
+	return 0
+}
+
+// Maps onto board.MoveRollResult
+type MoveRollResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MoveRoll *MoveRoll `protobuf:"bytes,1,opt,name=move_roll,json=moveRoll,proto3" json:"move_roll,omitempty"`
+	// Board after the move roll, with the opponent to move
+	Board         *Board `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`
+	Notation      string `protobuf:"bytes,3,opt,name=notation,proto3" json:"notation,omitempty"`
+	Hits          int32  `protobuf:"varint,4,opt,name=hits,proto3" json:"hits,omitempty"`
+	BorneOff      int32  `protobuf:"varint,5,opt,name=borne_off,json=borneOff,proto3" json:"borne_off,omitempty"`
+	PointsMade    int32  `protobuf:"varint,6,opt,name=points_made,json=pointsMade,proto3" json:"points_made,omitempty"`
+	EntersFromBar bool   `protobuf:"varint,7,opt,name=enters_from_bar,json=entersFromBar,proto3" json:"enters_from_bar,omitempty"`
+}
+
+func (x *MoveRollResult) Reset() {
+	*x = MoveRollResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveRollResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRollResult) ProtoMessage() {}
+
+func (x *MoveRollResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRollResult.ProtoReflect.Descriptor instead.
+func (*MoveRollResult) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MoveRollResult) GetMoveRoll() *MoveRoll {
+	if x != nil {
+		return x.MoveRoll
+	}
+	return nil
+}
+
+func (x *MoveRollResult) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *MoveRollResult) GetNotation() string {
+	if x != nil {
+		return x.Notation
+	}
+	return ""
+}
+
+func (x *MoveRollResult) GetHits() int32 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *MoveRollResult) GetBorneOff() int32 {
+	if x != nil {
+		return x.BorneOff
+	}
+	return 0
+}
+
+func (x *MoveRollResult) GetPointsMade() int32 {
+	if x != nil {
+		return x.PointsMade
+	}
+	return 0
+}
+
+func (x *MoveRollResult) GetEntersFromBar() bool {
+	if x != nil {
+		return x.EntersFromBar
+	}
+	return false
+}
+
+type GetValidMovesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Board *Board   `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`
+	Dice  *DieRoll `protobuf:"bytes,2,opt,name=dice,proto3" json:"dice,omitempty"`
+}
+
+func (x *GetValidMovesRequest) Reset() {
+	*x = GetValidMovesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetValidMovesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValidMovesRequest) ProtoMessage() {}
+
+func (x *GetValidMovesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValidMovesRequest.ProtoReflect.Descriptor instead.
+func (*GetValidMovesRequest) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetValidMovesRequest) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *GetValidMovesRequest) GetDice() *DieRoll {
+	if x != nil {
+		return x.Dice
+	}
+	return nil
+}
+
+type GetValidMovesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*MoveRollResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *GetValidMovesResponse) Reset() {
+	*x = GetValidMovesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetValidMovesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValidMovesResponse) ProtoMessage() {}
+
+func (x *GetValidMovesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValidMovesResponse.ProtoReflect.Descriptor instead.
+func (*GetValidMovesResponse) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetValidMovesResponse) GetResults() []*MoveRollResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ApplyMoveRollRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Board *Board   `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`
+	Dice  *DieRoll `protobuf:"bytes,2,opt,name=dice,proto3" json:"dice,omitempty"`
+	// An empty move roll passes the turn when there are no legal moves
+	MoveRoll *MoveRoll `protobuf:"bytes,3,opt,name=move_roll,json=moveRoll,proto3" json:"move_roll,omitempty"`
+}
+
+func (x *ApplyMoveRollRequest) Reset() {
+	*x = ApplyMoveRollRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyMoveRollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyMoveRollRequest) ProtoMessage() {}
+
+func (x *ApplyMoveRollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyMoveRollRequest.ProtoReflect.Descriptor instead.
+func (*ApplyMoveRollRequest) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ApplyMoveRollRequest) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *ApplyMoveRollRequest) GetDice() *DieRoll {
+	if x != nil {
+		return x.Dice
+	}
+	return nil
+}
+
+func (x *ApplyMoveRollRequest) GetMoveRoll() *MoveRoll {
+	if x != nil {
+		return x.MoveRoll
+	}
+	return nil
+}
+
+type ApplyMoveRollResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Board *Board `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`
+}
+
+func (x *ApplyMoveRollResponse) Reset() {
+	*x = ApplyMoveRollResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyMoveRollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyMoveRollResponse) ProtoMessage() {}
+
+func (x *ApplyMoveRollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyMoveRollResponse.ProtoReflect.Descriptor instead.
+func (*ApplyMoveRollResponse) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ApplyMoveRollResponse) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+type EvaluateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Board *Board `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EvaluateRequest) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+// Maps onto ai.Evaluation, gammon probabilities include backgammons
+type Evaluation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Win            float64 `protobuf:"fixed64,1,opt,name=win,proto3" json:"win,omitempty"`
+	WinGammon      float64 `protobuf:"fixed64,2,opt,name=win_gammon,json=winGammon,proto3" json:"win_gammon,omitempty"`
+	WinBackgammon  float64 `protobuf:"fixed64,3,opt,name=win_backgammon,json=winBackgammon,proto3" json:"win_backgammon,omitempty"`
+	LoseGammon     float64 `protobuf:"fixed64,4,opt,name=lose_gammon,json=loseGammon,proto3" json:"lose_gammon,omitempty"`
+	LoseBackgammon float64 `protobuf:"fixed64,5,opt,name=lose_backgammon,json=loseBackgammon,proto3" json:"lose_backgammon,omitempty"`
+	Equity         float64 `protobuf:"fixed64,6,opt,name=equity,proto3" json:"equity,omitempty"`
+}
+
+func (x *Evaluation) Reset() {
+	*x = Evaluation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Evaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evaluation) ProtoMessage() {}
+
+func (x *Evaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evaluation.ProtoReflect.Descriptor instead.
+func (*Evaluation) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Evaluation) GetWin() float64 {
+	if x != nil {
+		return x.Win
+	}
+	return 0
+}
+
+func (x *Evaluation) GetWinGammon() float64 {
+	if x != nil {
+		return x.WinGammon
+	}
+	return 0
+}
+
+func (x *Evaluation) GetWinBackgammon() float64 {
+	if x != nil {
+		return x.WinBackgammon
+	}
+	return 0
+}
+
+func (x *Evaluation) GetLoseGammon() float64 {
+	if x != nil {
+		return x.LoseGammon
+	}
+	return 0
+}
+
+func (x *Evaluation) GetLoseBackgammon() float64 {
+	if x != nil {
+		return x.LoseBackgammon
+	}
+	return 0
+}
+
+func (x *Evaluation) GetEquity() float64 {
+	if x != nil {
+		return x.Equity
+	}
+	return 0
+}
+
+type ChooseMoveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Board *Board   `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`
+	Dice  *DieRoll `protobuf:"bytes,2,opt,name=dice,proto3" json:"dice,omitempty"`
+	// Name of the AI, see the AIs the server was started with
+	Ai string `protobuf:"bytes,3,opt,name=ai,proto3" json:"ai,omitempty"`
+}
+
+func (x *ChooseMoveRequest) Reset() {
+	*x = ChooseMoveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChooseMoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChooseMoveRequest) ProtoMessage() {}
+
+func (x *ChooseMoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChooseMoveRequest.ProtoReflect.Descriptor instead.
+func (*ChooseMoveRequest) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChooseMoveRequest) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *ChooseMoveRequest) GetDice() *DieRoll {
+	if x != nil {
+		return x.Dice
+	}
+	return nil
+}
+
+func (x *ChooseMoveRequest) GetAi() string {
+	if x != nil {
+		return x.Ai
+	}
+	return ""
+}
+
+type ChooseMoveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MoveRoll *MoveRoll `protobuf:"bytes,1,opt,name=move_roll,json=moveRoll,proto3" json:"move_roll,omitempty"`
+	Notation string    `protobuf:"bytes,2,opt,name=notation,proto3" json:"notation,omitempty"`
+	// Board after the move roll, with the opponent to move
+	Board *Board `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
+}
+
+func (x *ChooseMoveResponse) Reset() {
+	*x = ChooseMoveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChooseMoveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChooseMoveResponse) ProtoMessage() {}
+
+func (x *ChooseMoveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChooseMoveResponse.ProtoReflect.Descriptor instead.
+func (*ChooseMoveResponse) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ChooseMoveResponse) GetMoveRoll() *MoveRoll {
+	if x != nil {
+		return x.MoveRoll
+	}
+	return nil
+}
+
+func (x *ChooseMoveResponse) GetNotation() string {
+	if x != nil {
+		return x.Notation
+	}
+	return ""
+}
+
+func (x *ChooseMoveResponse) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+type PlayGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*PlayGameRequest_Start
+	//	*PlayGameRequest_MoveRoll
+	//	*PlayGameRequest_Resign
+	Request isPlayGameRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *PlayGameRequest) Reset() {
+	*x = PlayGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayGameRequest) ProtoMessage() {}
+
+func (x *PlayGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayGameRequest.ProtoReflect.Descriptor instead.
+func (*PlayGameRequest) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{14}
+}
+
+func (m *PlayGameRequest) GetRequest() isPlayGameRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *PlayGameRequest) GetStart() *StartGame {
+	if x, ok := x.GetRequest().(*PlayGameRequest_Start); ok {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *PlayGameRequest) GetMoveRoll() *MoveRoll {
+	if x, ok := x.GetRequest().(*PlayGameRequest_MoveRoll); ok {
+		return x.MoveRoll
+	}
+	return nil
+}
+
+func (x *PlayGameRequest) GetResign() *Resign {
+	if x, ok := x.GetRequest().(*PlayGameRequest_Resign); ok {
+		return x.Resign
+	}
+	return nil
+}
+
+type isPlayGameRequest_Request interface {
+	isPlayGameRequest_Request()
+}
+
+type PlayGameRequest_Start struct {
+	Start *StartGame `protobuf:"bytes,1,opt,name=start,proto3,oneof"`
+}
+
+type PlayGameRequest_MoveRoll struct {
+	MoveRoll *MoveRoll `protobuf:"bytes,2,opt,name=move_roll,json=moveRoll,proto3,oneof"`
+}
+
+type PlayGameRequest_Resign struct {
+	Resign *Resign `protobuf:"bytes,3,opt,name=resign,proto3,oneof"`
+}
+
+func (*PlayGameRequest_Start) isPlayGameRequest_Request() {}
+
+func (*PlayGameRequest_MoveRoll) isPlayGameRequest_Request() {}
+
+func (*PlayGameRequest_Resign) isPlayGameRequest_Request() {}
+
+type StartGame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the AI played against
+	Ai string `protobuf:"bytes,1,opt,name=ai,proto3" json:"ai,omitempty"`
+	// Color played by the client, white if unspecified
+	Color Color `protobuf:"varint,2,opt,name=color,proto3,enum=backgammon.engine.v1.Color" json:"color,omitempty"`
+	// Seed of the dice, random if 0
+	Seed int64 `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	// Position to start from, the initial position with an opening roll if unset
+	Board *Board `protobuf:"bytes,4,opt,name=board,proto3" json:"board,omitempty"`
+}
+
+func (x *StartGame) Reset() {
+	*x = StartGame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartGame) ProtoMessage() {}
+
+func (x *StartGame) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartGame.ProtoReflect.Descriptor instead.
+func (*StartGame) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StartGame) GetAi() string {
+	if x != nil {
+		return x.Ai
+	}
+	return ""
+}
+
+func (x *StartGame) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+func (x *StartGame) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *StartGame) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+type Resign struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Points given to the AI, 1 if 0
+	Points int32 `protobuf:"varint,1,opt,name=points,proto3" json:"points,omitempty"`
+}
+
+func (x *Resign) Reset() {
+	*x = Resign{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Resign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resign) ProtoMessage() {}
+
+func (x *Resign) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resign.ProtoReflect.Descriptor instead.
+func (*Resign) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Resign) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+type GameEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*GameEvent_TurnStarted
+	//	*GameEvent_MovePlayed
+	//	*GameEvent_MoveRejected
+	//	*GameEvent_GameOver
+	Event isGameEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *GameEvent) Reset() {
+	*x = GameEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameEvent) ProtoMessage() {}
+
+func (x *GameEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameEvent.ProtoReflect.Descriptor instead.
+func (*GameEvent) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{17}
+}
+
+func (m *GameEvent) GetEvent() isGameEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *GameEvent) GetTurnStarted() *TurnStarted {
+	if x, ok := x.GetEvent().(*GameEvent_TurnStarted); ok {
+		return x.TurnStarted
+	}
+	return nil
+}
+
+func (x *GameEvent) GetMovePlayed() *MovePlayed {
+	if x, ok := x.GetEvent().(*GameEvent_MovePlayed); ok {
+		return x.MovePlayed
+	}
+	return nil
+}
+
+func (x *GameEvent) GetMoveRejected() *MoveRejected {
+	if x, ok := x.GetEvent().(*GameEvent_MoveRejected); ok {
+		return x.MoveRejected
+	}
+	return nil
+}
+
+func (x *GameEvent) GetGameOver() *GameOver {
+	if x, ok := x.GetEvent().(*GameEvent_GameOver); ok {
+		return x.GameOver
+	}
+	return nil
+}
+
+type isGameEvent_Event interface {
+	isGameEvent_Event()
+}
+
+type GameEvent_TurnStarted struct {
+	TurnStarted *TurnStarted `protobuf:"bytes,1,opt,name=turn_started,json=turnStarted,proto3,oneof"`
+}
+
+type GameEvent_MovePlayed struct {
+	MovePlayed *MovePlayed `protobuf:"bytes,2,opt,name=move_played,json=movePlayed,proto3,oneof"`
+}
+
+type GameEvent_MoveRejected struct {
+	MoveRejected *MoveRejected `protobuf:"bytes,3,opt,name=move_rejected,json=moveRejected,proto3,oneof"`
+}
+
+type GameEvent_GameOver struct {
+	GameOver *GameOver `protobuf:"bytes,4,opt,name=game_over,json=gameOver,proto3,oneof"`
+}
+
+func (*GameEvent_TurnStarted) isGameEvent_Event() {}
+
+func (*GameEvent_MovePlayed) isGameEvent_Event() {}
+
+func (*GameEvent_MoveRejected) isGameEvent_Event() {}
+
+func (*GameEvent_GameOver) isGameEvent_Event() {}
+
+// The client has rolled and has to play one of the legal move rolls
+type TurnStarted struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Board      *Board            `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`
+	Dice       *DieRoll          `protobuf:"bytes,2,opt,name=dice,proto3" json:"dice,omitempty"`
+	LegalMoves []*MoveRollResult `protobuf:"bytes,3,rep,name=legal_moves,json=legalMoves,proto3" json:"legal_moves,omitempty"`
+}
+
+func (x *TurnStarted) Reset() {
+	*x = TurnStarted{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnStarted) ProtoMessage() {}
+
+func (x *TurnStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnStarted.ProtoReflect.Descriptor instead.
+func (*TurnStarted) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TurnStarted) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *TurnStarted) GetDice() *DieRoll {
+	if x != nil {
+		return x.Dice
+	}
+	return nil
+}
+
+func (x *TurnStarted) GetLegalMoves() []*MoveRollResult {
+	if x != nil {
+		return x.LegalMoves
+	}
+	return nil
+}
+
+// A player played a move roll, an empty move roll passes the turn
+type MovePlayed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color    Color     `protobuf:"varint,1,opt,name=color,proto3,enum=backgammon.engine.v1.Color" json:"color,omitempty"`
+	Dice     *DieRoll  `protobuf:"bytes,2,opt,name=dice,proto3" json:"dice,omitempty"`
+	MoveRoll *MoveRoll `protobuf:"bytes,3,opt,name=move_roll,json=moveRoll,proto3" json:"move_roll,omitempty"`
+	Notation string    `protobuf:"bytes,4,opt,name=notation,proto3" json:"notation,omitempty"`
+	Board    *Board    `protobuf:"bytes,5,opt,name=board,proto3" json:"board,omitempty"`
+}
+
+func (x *MovePlayed) Reset() {
+	*x = MovePlayed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MovePlayed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovePlayed) ProtoMessage() {}
+
+func (x *MovePlayed) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovePlayed.ProtoReflect.Descriptor instead.
+func (*MovePlayed) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MovePlayed) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+func (x *MovePlayed) GetDice() *DieRoll {
+	if x != nil {
+		return x.Dice
+	}
+	return nil
+}
+
+func (x *MovePlayed) GetMoveRoll() *MoveRoll {
+	if x != nil {
+		return x.MoveRoll
+	}
+	return nil
+}
+
+func (x *MovePlayed) GetNotation() string {
+	if x != nil {
+		return x.Notation
+	}
+	return ""
+}
+
+func (x *MovePlayed) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+// The request of the client was invalid, the game goes on
+type MoveRejected struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *MoveRejected) Reset() {
+	*x = MoveRejected{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveRejected) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRejected) ProtoMessage() {}
+
+func (x *MoveRejected) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRejected.ProtoReflect.Descriptor instead.
+func (*MoveRejected) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MoveRejected) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GameOver struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Winner   Color `protobuf:"varint,1,opt,name=winner,proto3,enum=backgammon.engine.v1.Color" json:"winner,omitempty"`
+	Points   int32 `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`
+	Resigned bool  `protobuf:"varint,3,opt,name=resigned,proto3" json:"resigned,omitempty"`
+}
+
+func (x *GameOver) Reset() {
+	*x = GameOver{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameOver) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameOver) ProtoMessage() {}
+
+func (x *GameOver) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameOver.ProtoReflect.Descriptor instead.
+func (*GameOver) Descriptor() ([]byte, []int) {
+	return file_engine_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GameOver) GetWinner() Color {
+	if x != nil {
+		return x.Winner
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+func (x *GameOver) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *GameOver) GetResigned() bool {
+	if x != nil {
+		return x.Resigned
+	}
+	return false
+}
+
+var File_engine_proto protoreflect.FileDescriptor
+
+var file_engine_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14,
+	0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x76, 0x31, 0x22, 0x5f, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x31, 0x0a,
+	0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x62,
+	0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x7d, 0x0a, 0x05, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x33,
+	0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x12, 0x3f, 0x0a, 0x0d, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x5f, 0x74, 0x6f, 0x5f,
+	0x6d, 0x6f, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x63,
+	0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x54, 0x6f,
+	0x4d, 0x6f, 0x76, 0x65, 0x22, 0x5e, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x74, 0x6f,
+	0x12, 0x32, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e,
+	0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x22, 0x3c, 0x0a, 0x08, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c,
+	0x12, 0x30, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x05, 0x6d, 0x6f, 0x76,
+	0x65, 0x73, 0x22, 0x31, 0x0a, 0x07, 0x44, 0x69, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x69, 0x65, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x69, 0x65,
+	0x31, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x69, 0x65, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x64, 0x69, 0x65, 0x32, 0x22, 0x96, 0x02, 0x0a, 0x0e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x6f,
+	0x6c, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x3b, 0x0a, 0x09, 0x6d, 0x6f, 0x76, 0x65,
+	0x5f, 0x72, 0x6f, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x61,
+	0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x08, 0x6d, 0x6f, 0x76,
+	0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x12, 0x31, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x61, 0x72,
+	0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x6f, 0x72, 0x6e,
+	0x65, 0x5f, 0x6f, 0x66, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x62, 0x6f, 0x72,
+	0x6e, 0x65, 0x4f, 0x66, 0x66, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x5f,
+	0x6d, 0x61, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x4d, 0x61, 0x64, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73,
+	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x61, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x61, 0x72, 0x22, 0x7c,
+	0x0a, 0x14, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x6f, 0x76, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x61,
+	0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x31, 0x0a, 0x04, 0x64, 0x69, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x69, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x04, 0x64, 0x69, 0x63, 0x65, 0x22, 0x57, 0x0a, 0x15,
+	0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x6f, 0x76, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f,
+	0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xb9, 0x01, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x4d,
+	0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31,
+	0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x12, 0x31, 0x0a, 0x04, 0x64, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x04,
+	0x64, 0x69, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x09, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x72, 0x6f, 0x6c,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x08, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c,
+	0x6c, 0x22, 0x4a, 0x0a, 0x15, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x6f,
+	0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x05, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x63, 0x6b,
+	0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x22, 0x44, 0x0a,
+	0x0f, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x31, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x22, 0xc6, 0x01, 0x0a, 0x0a, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x77, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x03, 0x77, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x67, 0x61, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x47, 0x61, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x77, 0x69, 0x6e, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x67,
+	0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x77, 0x69, 0x6e,
+	0x42, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f,
+	0x73, 0x65, 0x5f, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0a, 0x6c, 0x6f, 0x73, 0x65, 0x47, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x6c,
+	0x6f, 0x73, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x6c, 0x6f, 0x73, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x67, 0x61,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x71, 0x75, 0x69, 0x74, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x65, 0x71, 0x75, 0x69, 0x74, 0x79, 0x22, 0x89, 0x01, 0x0a,
+	0x11, 0x43, 0x68, 0x6f, 0x6f, 0x73, 0x65, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x31, 0x0a, 0x04, 0x64, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x65, 0x52, 0x6f,
+	0x6c, 0x6c, 0x52, 0x04, 0x64, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x69, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x61, 0x69, 0x22, 0xa0, 0x01, 0x0a, 0x12, 0x43, 0x68, 0x6f,
+	0x6f, 0x73, 0x65, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3b, 0x0a, 0x09, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x72, 0x6f, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x6f,
+	0x6c, 0x6c, 0x52, 0x08, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x12, 0x1a, 0x0a, 0x08,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x22, 0xcc, 0x01, 0x0a, 0x0f,
+	0x50, 0x6c, 0x61, 0x79, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x37, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x48,
+	0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x3d, 0x0a, 0x09, 0x6d, 0x6f, 0x76, 0x65,
+	0x5f, 0x72, 0x6f, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x61,
+	0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x48, 0x00, 0x52, 0x08, 0x6d,
+	0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x12, 0x36, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x69, 0x67,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x69, 0x67, 0x6e, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x42,
+	0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x95, 0x01, 0x0a, 0x09, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x69, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x61, 0x69, 0x12, 0x31, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12,
+	0x31, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x22, 0x20, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x22, 0xab, 0x02, 0x0a, 0x09, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x46, 0x0a, 0x0c, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67,
+	0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x75, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x74,
+	0x75, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x43, 0x0a, 0x0b, 0x6d, 0x6f,
+	0x76, 0x65, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x64, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x12,
+	0x49, 0x0a, 0x0d, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f,
+	0x76, 0x65, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x6f,
+	0x76, 0x65, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x09, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x48, 0x00, 0x52,
+	0x08, 0x67, 0x61, 0x6d, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x22, 0xba, 0x01, 0x0a, 0x0b, 0x54, 0x75, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x12, 0x31, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x31, 0x0a, 0x04, 0x64, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x65, 0x52, 0x6f,
+	0x6c, 0x6c, 0x52, 0x04, 0x64, 0x69, 0x63, 0x65, 0x12, 0x45, 0x0a, 0x0b, 0x6c, 0x65, 0x67, 0x61,
+	0x6c, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x0a, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x4d, 0x6f, 0x76, 0x65, 0x73, 0x22,
+	0xfe, 0x01, 0x0a, 0x0a, 0x4d, 0x6f, 0x76, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x12, 0x31,
+	0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e,
+	0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x12, 0x31, 0x0a, 0x04, 0x64, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x04,
+	0x64, 0x69, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x09, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x72, 0x6f, 0x6c,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x08, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c,
+	0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a,
+	0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62,
+	0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x22, 0x26, 0x0a, 0x0c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x73, 0x0a, 0x08, 0x47, 0x61, 0x6d, 0x65,
+	0x4f, 0x76, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x2a, 0x40, 0x0a,
+	0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a,
+	0x0b, 0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x5f, 0x57, 0x48, 0x49, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0f,
+	0x0a, 0x0b, 0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x5f, 0x42, 0x4c, 0x41, 0x43, 0x4b, 0x10, 0x02, 0x2a,
+	0x74, 0x0a, 0x08, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x4d,
+	0x4f, 0x56, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18,
+	0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x45,
+	0x52, 0x5f, 0x4f, 0x4e, 0x5f, 0x42, 0x41, 0x52, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x4d, 0x4f,
+	0x56, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x45, 0x41, 0x52, 0x49, 0x4e, 0x47, 0x5f,
+	0x4f, 0x46, 0x46, 0x10, 0x03, 0x32, 0xea, 0x03, 0x0a, 0x06, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x12, 0x68, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x6f, 0x76, 0x65,
+	0x73, 0x12, 0x2a, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x4d, 0x6f, 0x76, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e,
+	0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x6f, 0x76,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x68, 0x0a, 0x0d, 0x41, 0x70,
+	0x70, 0x6c, 0x79, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x12, 0x2a, 0x2e, 0x62, 0x61,
+	0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65,
+	0x12, 0x25, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e,
+	0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5f, 0x0a, 0x0a, 0x43, 0x68, 0x6f,
+	0x6f, 0x73, 0x65, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x27, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x68, 0x6f, 0x6f, 0x73, 0x65, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x28, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e,
+	0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x6f, 0x6f, 0x73, 0x65, 0x4d, 0x6f,
+	0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x08, 0x50, 0x6c,
+	0x61, 0x79, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x28, 0x01,
+	0x30, 0x01, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x47, 0x65, 0x6f, 0x72, 0x67, 0x69, 0x61, 0x6e, 0x42, 0x61, 0x64, 0x69, 0x74, 0x61, 0x2f,
+	0x62, 0x61, 0x63, 0x6b, 0x67, 0x61, 0x6d, 0x6d, 0x6f, 0x6e, 0x2d, 0x6d, 0x6f, 0x76, 0x65, 0x2d,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x72, 0x70,
+	0x63, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_engine_proto_rawDescOnce sync.Once
+	file_engine_proto_rawDescData = file_engine_proto_rawDesc
+)
+
+func file_engine_proto_rawDescGZIP() []byte {
+	file_engine_proto_rawDescOnce.Do(func() {
+		file_engine_proto_rawDescData = protoimpl.X.CompressGZIP(file_engine_proto_rawDescData)
+	})
+	return file_engine_proto_rawDescData
+}
+
+var file_engine_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_engine_proto_goTypes = []interface{}{
+	(Color)(0),                    // 0: backgammon.engine.v1.Color
+	(MoveType)(0),                 // 1: backgammon.engine.v1.MoveType
+	(*Point)(nil),                 // 2: backgammon.engine.v1.Point
+	(*Board)(nil),                 // 3: backgammon.engine.v1.Board
+	(*Move)(nil),                  // 4: backgammon.engine.v1.Move
+	(*MoveRoll)(nil),              // 5: backgammon.engine.v1.MoveRoll
+	(*DieRoll)(nil),               // 6: backgammon.engine.v1.DieRoll
+	(*MoveRollResult)(nil),        // 7: backgammon.engine.v1.MoveRollResult
+	(*GetValidMovesRequest)(nil),  // 8: backgammon.engine.v1.GetValidMovesRequest
+	(*GetValidMovesResponse)(nil), // 9: backgammon.engine.v1.GetValidMovesResponse
+	(*ApplyMoveRollRequest)(nil),  // 10: backgammon.engine.v1.ApplyMoveRollRequest
+	(*ApplyMoveRollResponse)(nil), // 11: backgammon.engine.v1.ApplyMoveRollResponse
+	(*EvaluateRequest)(nil),       // 12: backgammon.engine.v1.EvaluateRequest
+	(*Evaluation)(nil),            // 13: backgammon.engine.v1.Evaluation
+	(*ChooseMoveRequest)(nil),     // 14: backgammon.engine.v1.ChooseMoveRequest
+	(*ChooseMoveResponse)(nil),    // 15: backgammon.engine.v1.ChooseMoveResponse
+	(*PlayGameRequest)(nil),       // 16: backgammon.engine.v1.PlayGameRequest
+	(*StartGame)(nil),             // 17: backgammon.engine.v1.StartGame
+	(*Resign)(nil),                // 18: backgammon.engine.v1.Resign
+	(*GameEvent)(nil),             // 19: backgammon.engine.v1.GameEvent
+	(*TurnStarted)(nil),           // 20: backgammon.engine.v1.TurnStarted
+	(*MovePlayed)(nil),            // 21: backgammon.engine.v1.MovePlayed
+	(*MoveRejected)(nil),          // 22: backgammon.engine.v1.MoveRejected
+	(*GameOver)(nil),              // 23: backgammon.engine.v1.GameOver
+}
+var file_engine_proto_depIdxs = []int32{
+	0,  // 0: backgammon.engine.v1.Point.color:type_name -> backgammon.engine.v1.Color
+	2,  // 1: backgammon.engine.v1.Board.points:type_name -> backgammon.engine.v1.Point
+	0,  // 2: backgammon.engine.v1.Board.color_to_move:type_name -> backgammon.engine.v1.Color
+	1,  // 3: backgammon.engine.v1.Move.type:type_name -> backgammon.engine.v1.MoveType
+	4,  // 4: backgammon.engine.v1.MoveRoll.moves:type_name -> backgammon.engine.v1.Move
+	5,  // 5: backgammon.engine.v1.MoveRollResult.move_roll:type_name -> backgammon.engine.v1.MoveRoll
+	3,  // 6: backgammon.engine.v1.MoveRollResult.board:type_name -> backgammon.engine.v1.Board
+	3,  // 7: backgammon.engine.v1.GetValidMovesRequest.board:type_name -> backgammon.engine.v1.Board
+	6,  // 8: backgammon.engine.v1.GetValidMovesRequest.dice:type_name -> backgammon.engine.v1.DieRoll
+	7,  // 9: backgammon.engine.v1.GetValidMovesResponse.results:type_name -> backgammon.engine.v1.MoveRollResult
+	3,  // 10: backgammon.engine.v1.ApplyMoveRollRequest.board:type_name -> backgammon.engine.v1.Board
+	6,  // 11: backgammon.engine.v1.ApplyMoveRollRequest.dice:type_name -> backgammon.engine.v1.DieRoll
+	5,  // 12: backgammon.engine.v1.ApplyMoveRollRequest.move_roll:type_name -> backgammon.engine.v1.MoveRoll
+	3,  // 13: backgammon.engine.v1.ApplyMoveRollResponse.board:type_name -> backgammon.engine.v1.Board
+	3,  // 14: backgammon.engine.v1.EvaluateRequest.board:type_name -> backgammon.engine.v1.Board
+	3,  // 15: backgammon.engine.v1.ChooseMoveRequest.board:type_name -> backgammon.engine.v1.Board
+	6,  // 16: backgammon.engine.v1.ChooseMoveRequest.dice:type_name -> backgammon.engine.v1.DieRoll
+	5,  // 17: backgammon.engine.v1.ChooseMoveResponse.move_roll:type_name -> backgammon.engine.v1.MoveRoll
+	3,  // 18: backgammon.engine.v1.ChooseMoveResponse.board:type_name -> backgammon.engine.v1.Board
+	17, // 19: backgammon.engine.v1.PlayGameRequest.start:type_name -> backgammon.engine.v1.StartGame
+	5,  // 20: backgammon.engine.v1.PlayGameRequest.move_roll:type_name -> backgammon.engine.v1.MoveRoll
+	18, // 21: backgammon.engine.v1.PlayGameRequest.resign:type_name -> backgammon.engine.v1.Resign
+	0,  // 22: backgammon.engine.v1.StartGame.color:type_name -> backgammon.engine.v1.Color
+	3,  // 23: backgammon.engine.v1.StartGame.board:type_name -> backgammon.engine.v1.Board
+	20, // 24: backgammon.engine.v1.GameEvent.turn_started:type_name -> backgammon.engine.v1.TurnStarted
+	21, // 25: backgammon.engine.v1.GameEvent.move_played:type_name -> backgammon.engine.v1.MovePlayed
+	22, // 26: backgammon.engine.v1.GameEvent.move_rejected:type_name -> backgammon.engine.v1.MoveRejected
+	23, // 27: backgammon.engine.v1.GameEvent.game_over:type_name -> backgammon.engine.v1.GameOver
+	3,  // 28: backgammon.engine.v1.TurnStarted.board:type_name -> backgammon.engine.v1.Board
+	6,  // 29: backgammon.engine.v1.TurnStarted.dice:type_name -> backgammon.engine.v1.DieRoll
+	7,  // 30: backgammon.engine.v1.TurnStarted.legal_moves:type_name -> backgammon.engine.v1.MoveRollResult
+	0,  // 31: backgammon.engine.v1.MovePlayed.color:type_name -> backgammon.engine.v1.Color
+	6,  // 32: backgammon.engine.v1.MovePlayed.dice:type_name -> backgammon.engine.v1.DieRoll
+	5,  // 33: backgammon.engine.v1.MovePlayed.move_roll:type_name -> backgammon.engine.v1.MoveRoll
+	3,  // 34: backgammon.engine.v1.MovePlayed.board:type_name -> backgammon.engine.v1.Board
+	0,  // 35: backgammon.engine.v1.GameOver.winner:type_name -> backgammon.engine.v1.Color
+	8,  // 36: backgammon.engine.v1.Engine.GetValidMoves:input_type -> backgammon.engine.v1.GetValidMovesRequest
+	10, // 37: backgammon.engine.v1.Engine.ApplyMoveRoll:input_type -> backgammon.engine.v1.ApplyMoveRollRequest
+	12, // 38: backgammon.engine.v1.Engine.Evaluate:input_type -> backgammon.engine.v1.EvaluateRequest
+	14, // 39: backgammon.engine.v1.Engine.ChooseMove:input_type -> backgammon.engine.v1.ChooseMoveRequest
+	16, // 40: backgammon.engine.v1.Engine.PlayGame:input_type -> backgammon.engine.v1.PlayGameRequest
+	9,  // 41: backgammon.engine.v1.Engine.GetValidMoves:output_type -> backgammon.engine.v1.GetValidMovesResponse
+	11, // 42: backgammon.engine.v1.Engine.ApplyMoveRoll:output_type -> backgammon.engine.v1.ApplyMoveRollResponse
+	13, // 43: backgammon.engine.v1.Engine.Evaluate:output_type -> backgammon.engine.v1.Evaluation
+	15, // 44: backgammon.engine.v1.Engine.ChooseMove:output_type -> backgammon.engine.v1.ChooseMoveResponse
+	19, // 45: backgammon.engine.v1.Engine.PlayGame:output_type -> backgammon.engine.v1.GameEvent
+	41, // [41:46] is the sub-list for method output_type
+	36, // [36:41] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
+}
+
+func init() { file_engine_proto_init() }
+func file_engine_proto_init() {
+	if File_engine_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_engine_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Point); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Board); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Move); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveRoll); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DieRoll); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveRollResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetValidMovesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetValidMovesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyMoveRollRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyMoveRollResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Evaluation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChooseMoveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChooseMoveResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartGame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resign); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TurnStarted); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MovePlayed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveRejected); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameOver); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_engine_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*PlayGameRequest_Start)(nil),
+		(*PlayGameRequest_MoveRoll)(nil),
+		(*PlayGameRequest_Resign)(nil),
+	}
+	file_engine_proto_msgTypes[17].OneofWrappers = []interface{}{
+		(*GameEvent_TurnStarted)(nil),
+		(*GameEvent_MovePlayed)(nil),
+		(*GameEvent_MoveRejected)(nil),
+		(*GameEvent_GameOver)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_engine_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_engine_proto_goTypes,
+		DependencyIndexes: file_engine_proto_depIdxs,
+		EnumInfos:         file_engine_proto_enumTypes,
+		MessageInfos:      file_engine_proto_msgTypes,
+	}.Build()
+	File_engine_proto = out.File
+	file_engine_proto_rawDesc = nil
+	file_engine_proto_goTypes = nil
+	file_engine_proto_depIdxs = nil
+}
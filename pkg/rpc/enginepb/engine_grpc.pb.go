@@ -0,0 +1,302 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: engine.proto
+
+package enginepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Engine_GetValidMoves_FullMethodName = "/backgammon.engine.v1.Engine/GetValidMoves"
+	Engine_ApplyMoveRoll_FullMethodName = "/backgammon.engine.v1.Engine/ApplyMoveRoll"
+	Engine_Evaluate_FullMethodName      = "/backgammon.engine.v1.Engine/Evaluate"
+	Engine_ChooseMove_FullMethodName    = "/backgammon.engine.v1.Engine/ChooseMove"
+	Engine_PlayGame_FullMethodName      = "/backgammon.engine.v1.Engine/PlayGame"
+)
+
+// EngineClient is the client API for Engine service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EngineClient interface {
+	// Legal move rolls of the player to move for a roll of the dice
+	GetValidMoves(ctx context.Context, in *GetValidMovesRequest, opts ...grpc.CallOption) (*GetValidMovesResponse, error)
+	// Plays a legal move roll, the resulting board has the opponent to move
+	ApplyMoveRoll(ctx context.Context, in *ApplyMoveRollRequest, opts ...grpc.CallOption) (*ApplyMoveRollResponse, error)
+	// Evaluates a board from the perspective of the player to move
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*Evaluation, error)
+	// Move roll chosen by an AI for the player to move
+	ChooseMove(ctx context.Context, in *ChooseMoveRequest, opts ...grpc.CallOption) (*ChooseMoveResponse, error)
+	// Plays a game against an AI. The first request must start the game,
+	// the server rolls the dice and plays the turns of the AI
+	PlayGame(ctx context.Context, opts ...grpc.CallOption) (Engine_PlayGameClient, error)
+}
+
+type engineClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEngineClient(cc grpc.ClientConnInterface) EngineClient {
+	return &engineClient{cc}
+}
+
+func (c *engineClient) GetValidMoves(ctx context.Context, in *GetValidMovesRequest, opts ...grpc.CallOption) (*GetValidMovesResponse, error) {
+	out := new(GetValidMovesResponse)
+	err := c.cc.Invoke(ctx, Engine_GetValidMoves_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) ApplyMoveRoll(ctx context.Context, in *ApplyMoveRollRequest, opts ...grpc.CallOption) (*ApplyMoveRollResponse, error) {
+	out := new(ApplyMoveRollResponse)
+	err := c.cc.Invoke(ctx, Engine_ApplyMoveRoll_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*Evaluation, error) {
+	out := new(Evaluation)
+	err := c.cc.Invoke(ctx, Engine_Evaluate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) ChooseMove(ctx context.Context, in *ChooseMoveRequest, opts ...grpc.CallOption) (*ChooseMoveResponse, error) {
+	out := new(ChooseMoveResponse)
+	err := c.cc.Invoke(ctx, Engine_ChooseMove_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) PlayGame(ctx context.Context, opts ...grpc.CallOption) (Engine_PlayGameClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Engine_ServiceDesc.Streams[0], Engine_PlayGame_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &enginePlayGameClient{stream}
+	return x, nil
+}
+
+type Engine_PlayGameClient interface {
+	Send(*PlayGameRequest) error
+	Recv() (*GameEvent, error)
+	grpc.ClientStream
+}
+
+type enginePlayGameClient struct {
+	grpc.ClientStream
+}
+
+func (x *enginePlayGameClient) Send(m *PlayGameRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *enginePlayGameClient) Recv() (*GameEvent, error) {
+	m := new(GameEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EngineServer is the server API for Engine service.
+// All implementations must embed UnimplementedEngineServer
+// for forward compatibility
+type EngineServer interface {
+	// Legal move rolls of the player to move for a roll of the dice
+	GetValidMoves(context.Context, *GetValidMovesRequest) (*GetValidMovesResponse, error)
+	// Plays a legal move roll, the resulting board has the opponent to move
+	ApplyMoveRoll(context.Context, *ApplyMoveRollRequest) (*ApplyMoveRollResponse, error)
+	// Evaluates a board from the perspective of the player to move
+	Evaluate(context.Context, *EvaluateRequest) (*Evaluation, error)
+	// Move roll chosen by an AI for the player to move
+	ChooseMove(context.Context, *ChooseMoveRequest) (*ChooseMoveResponse, error)
+	// Plays a game against an AI. The first request must start the game,
+	// the server rolls the dice and plays the turns of the AI
+	PlayGame(Engine_PlayGameServer) error
+	mustEmbedUnimplementedEngineServer()
+}
+
+// UnimplementedEngineServer must be embedded to have forward compatible implementations.
+type UnimplementedEngineServer struct {
+}
+
+func (UnimplementedEngineServer) GetValidMoves(context.Context, *GetValidMovesRequest) (*GetValidMovesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidMoves not implemented")
+}
+func (UnimplementedEngineServer) ApplyMoveRoll(context.Context, *ApplyMoveRollRequest) (*ApplyMoveRollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyMoveRoll not implemented")
+}
+func (UnimplementedEngineServer) Evaluate(context.Context, *EvaluateRequest) (*Evaluation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (UnimplementedEngineServer) ChooseMove(context.Context, *ChooseMoveRequest) (*ChooseMoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChooseMove not implemented")
+}
+func (UnimplementedEngineServer) PlayGame(Engine_PlayGameServer) error {
+	return status.Errorf(codes.Unimplemented, "method PlayGame not implemented")
+}
+func (UnimplementedEngineServer) mustEmbedUnimplementedEngineServer() {}
+
+// UnsafeEngineServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EngineServer will
+// result in compilation errors.
+type UnsafeEngineServer interface {
+	mustEmbedUnimplementedEngineServer()
+}
+
+func RegisterEngineServer(s grpc.ServiceRegistrar, srv EngineServer) {
+	s.RegisterService(&Engine_ServiceDesc, srv)
+}
+
+func _Engine_GetValidMoves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValidMovesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).GetValidMoves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engine_GetValidMoves_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).GetValidMoves(ctx, req.(*GetValidMovesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_ApplyMoveRoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyMoveRollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).ApplyMoveRoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engine_ApplyMoveRoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).ApplyMoveRoll(ctx, req.(*ApplyMoveRollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engine_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_ChooseMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChooseMoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).ChooseMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engine_ChooseMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).ChooseMove(ctx, req.(*ChooseMoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_PlayGame_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EngineServer).PlayGame(&enginePlayGameServer{stream})
+}
+
+type Engine_PlayGameServer interface {
+	Send(*GameEvent) error
+	Recv() (*PlayGameRequest, error)
+	grpc.ServerStream
+}
+
+type enginePlayGameServer struct {
+	grpc.ServerStream
+}
+
+func (x *enginePlayGameServer) Send(m *GameEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *enginePlayGameServer) Recv() (*PlayGameRequest, error) {
+	m := new(PlayGameRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Engine_ServiceDesc is the grpc.ServiceDesc for Engine service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Engine_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "backgammon.engine.v1.Engine",
+	HandlerType: (*EngineServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetValidMoves",
+			Handler:    _Engine_GetValidMoves_Handler,
+		},
+		{
+			MethodName: "ApplyMoveRoll",
+			Handler:    _Engine_ApplyMoveRoll_Handler,
+		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _Engine_Evaluate_Handler,
+		},
+		{
+			MethodName: "ChooseMove",
+			Handler:    _Engine_ChooseMove_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PlayGame",
+			Handler:       _Engine_PlayGame_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "engine.proto",
+}
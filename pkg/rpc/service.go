@@ -0,0 +1,299 @@
+package rpc
+
+//go:generate protoc -I enginepb --go_out=enginepb --go_opt=paths=source_relative --go-grpc_out=enginepb --go-grpc_opt=paths=source_relative engine.proto
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/rpc/enginepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Options struct {
+	// AIs that choose moves and games are played against, by name
+	AIs map[string]ai.AI
+	// Evaluator used by the Evaluate RPC
+	Evaluator ai.Evaluator
+	// Function creating the dice of a new game, the seed is the one
+	// requested by the client or 0 if none was requested
+	NewDice func(seed int64) game.Dice
+}
+
+// Implementation of the Engine gRPC service, register it with
+// enginepb.RegisterEngineServer
+type Service struct {
+	enginepb.UnimplementedEngineServer
+	options Options
+}
+
+func NewService(options Options) *Service {
+	if options.Evaluator == nil {
+		options.Evaluator = ai.HeuristicEvaluator{}
+	}
+	if options.NewDice == nil {
+		options.NewDice = func(seed int64) game.Dice {
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+			return game.NewRandomDice(seed)
+		}
+	}
+	return &Service{options: options}
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// Function that converts the errors returned by a game to gRPC errors
+func gameError(err error) error {
+	if err == game.ErrGameOver {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return invalidArgument(err)
+}
+
+func (s *Service) GetValidMoves(ctx context.Context, request *enginepb.GetValidMovesRequest) (*enginepb.GetValidMovesResponse, error) {
+	b, err := BoardFromProto(request.Board)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	dice, err := DieRollFromProto(request.Dice)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	response := &enginepb.GetValidMovesResponse{}
+	for _, result := range b.GetValidMoveResultsForDieRoll(dice) {
+		response.Results = append(response.Results, MoveRollResultToProto(result))
+	}
+	return response, nil
+}
+
+func (s *Service) ApplyMoveRoll(ctx context.Context, request *enginepb.ApplyMoveRollRequest) (*enginepb.ApplyMoveRollResponse, error) {
+	b, err := BoardFromProto(request.Board)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	dice, err := DieRollFromProto(request.Dice)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	mvRoll, err := MoveRollFromProto(request.MoveRoll)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	next, err := play(b, dice, mvRoll)
+	if err != nil {
+		return nil, gameError(err)
+	}
+	return &enginepb.ApplyMoveRollResponse{Board: BoardToProto(next)}, nil
+}
+
+func (s *Service) Evaluate(ctx context.Context, request *enginepb.EvaluateRequest) (*enginepb.Evaluation, error) {
+	b, err := BoardFromProto(request.Board)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return EvaluationToProto(s.options.Evaluator.Evaluate(b)), nil
+}
+
+func (s *Service) ChooseMove(ctx context.Context, request *enginepb.ChooseMoveRequest) (*enginepb.ChooseMoveResponse, error) {
+	chooser, err := s.ai(request.Ai)
+	if err != nil {
+		return nil, err
+	}
+	b, err := BoardFromProto(request.Board)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	dice, err := DieRollFromProto(request.Dice)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	// The AI stops thinking if the client cancels the call
+	mvRoll, err := ai.Choose(ctx, chooser, b, dice, nil)
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	next, err := play(b, dice, mvRoll)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "AI %s chose an illegal move roll: %v", request.Ai, err)
+	}
+	return &enginepb.ChooseMoveResponse{MoveRoll: MoveRollToProto(mvRoll), Notation: mvRoll.Notation(b), Board: BoardToProto(next)}, nil
+}
+
+func (s *Service) PlayGame(stream enginepb.Engine_PlayGameServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := request.GetStart()
+	if start == nil {
+		return status.Error(codes.InvalidArgument, "the first request must start the game")
+	}
+	opponent, err := s.ai(start.Ai)
+	if err != nil {
+		return err
+	}
+	clientColor := board.COLOR_WHITE
+	if start.Color != enginepb.Color_COLOR_UNSPECIFIED {
+		if clientColor, err = ColorFromProto(start.Color); err != nil {
+			return invalidArgument(err)
+		}
+	}
+
+	dice := s.options.NewDice(start.Seed)
+	var g *game.Game
+	if start.Board != nil {
+		b, err := BoardFromProto(start.Board)
+		if err != nil {
+			return invalidArgument(err)
+		}
+		g = game.NewGame(b)
+	} else {
+		color, openingRoll := game.OpeningRoll(dice)
+		g = game.NewGame(board.NewBoard(color))
+		g.SetDice(openingRoll)
+	}
+
+	p := &gamePlay{stream, g, dice, clientColor, opponent}
+	for {
+		if err := p.advance(); err != nil || g.IsOver() {
+			return err
+		}
+		request, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch r := request.Request.(type) {
+		case *enginepb.PlayGameRequest_MoveRoll:
+			mvRoll, err := MoveRollFromProto(r.MoveRoll)
+			if err == nil {
+				err = p.play(mvRoll)
+			}
+			if err != nil {
+				err = p.send(&enginepb.GameEvent{Event: &enginepb.GameEvent_MoveRejected{MoveRejected: &enginepb.MoveRejected{Reason: err.Error()}}})
+			}
+		case *enginepb.PlayGameRequest_Resign:
+			points := int(r.Resign.Points)
+			if points == 0 {
+				points = 1
+			}
+			if err = g.Resign(clientColor, points); err != nil {
+				err = p.send(&enginepb.GameEvent{Event: &enginepb.GameEvent_MoveRejected{MoveRejected: &enginepb.MoveRejected{Reason: err.Error()}}})
+			}
+		default:
+			err = p.send(&enginepb.GameEvent{Event: &enginepb.GameEvent_MoveRejected{MoveRejected: &enginepb.MoveRejected{Reason: "the game has already started"}}})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// A game streamed to a client playing against an AI
+type gamePlay struct {
+	stream      enginepb.Engine_PlayGameServer
+	game        *game.Game
+	dice        game.Dice
+	clientColor board.Color
+	opponent    ai.AI
+}
+
+func (p *gamePlay) send(event *enginepb.GameEvent) error {
+	return p.stream.Send(event)
+}
+
+// Function that plays a move roll for the player to move and sends it to the client
+func (p *gamePlay) play(mvRoll board.MoveRoll) error {
+	before := p.game.Board
+	dice := p.game.Dice
+	if err := p.game.Play(mvRoll); err != nil {
+		return err
+	}
+	return p.send(&enginepb.GameEvent{Event: &enginepb.GameEvent_MovePlayed{MovePlayed: &enginepb.MovePlayed{
+		Color:    ColorToProto(before.ColorToMove),
+		Dice:     DieRollToProto(dice),
+		MoveRoll: MoveRollToProto(mvRoll),
+		Notation: mvRoll.Notation(before),
+		Board:    BoardToProto(p.game.Board),
+	}}})
+}
+
+// Function that lets the AI play its turns and rolls the dice for the client
+// until the game is over, which is sent to the client, or the client has
+// a move to make, which starts his turn
+func (p *gamePlay) advance() error {
+	g := p.game
+	for !g.IsOver() {
+		if !g.Rolled {
+			g.Roll(p.dice)
+		}
+		results, _ := g.ValidMoves()
+		if g.Board.ColorToMove == p.clientColor && len(results) > 0 {
+			turn := &enginepb.TurnStarted{Board: BoardToProto(g.Board), Dice: DieRollToProto(g.Dice)}
+			for _, result := range results {
+				turn.LegalMoves = append(turn.LegalMoves, MoveRollResultToProto(result))
+			}
+			return p.send(&enginepb.GameEvent{Event: &enginepb.GameEvent_TurnStarted{TurnStarted: turn}})
+		}
+
+		mvRoll := board.MoveRoll{}
+		if g.Board.ColorToMove != p.clientColor {
+			var err error
+			if mvRoll, err = ai.Choose(p.stream.Context(), p.opponent, g.Board, g.Dice, nil); err != nil {
+				return status.FromContextError(err).Err()
+			}
+		}
+		if err := p.play(mvRoll); err == game.ErrIllegalMove {
+			// An AI playing an illegal move roll forfeits the game
+			g.Resign(g.Board.ColorToMove, 1)
+		} else if err != nil {
+			return err
+		}
+	}
+	return p.send(&enginepb.GameEvent{Event: &enginepb.GameEvent_GameOver{GameOver: &enginepb.GameOver{
+		Winner:   ColorToProto(g.Result.Winner),
+		Points:   int32(g.Result.Points),
+		Resigned: g.Result.Resigned,
+	}}})
+}
+
+func (s *Service) ai(name string) (ai.AI, error) {
+	chooser, ok := s.options.AIs[name]
+	if !ok {
+		names := []string{}
+		for name := range s.options.AIs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, status.Errorf(codes.NotFound, "unknown AI %s, available AIs: %s", name, strings.Join(names, ", "))
+	}
+	return chooser, nil
+}
+
+// Function that plays a move roll on a board, checking it is legal for the dice
+func play(b board.Board, dice board.DieRoll, mvRoll board.MoveRoll) (board.Board, error) {
+	g := game.NewGame(b)
+	if err := g.SetDice(dice); err != nil {
+		return board.Board{}, err
+	}
+	if err := g.Play(mvRoll); err != nil {
+		return board.Board{}, err
+	}
+	return g.Board, nil
+}
@@ -0,0 +1,250 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/rpc/enginepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const INITIAL_WHITE = "6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w"
+
+type fixedDice struct {
+	rolls []board.DieRoll
+}
+
+func (d *fixedDice) Roll() board.DieRoll {
+	roll := d.rolls[0]
+	d.rolls = append(d.rolls[1:], roll)
+	return roll
+}
+
+// Function that serves the service in process and returns a client connected to it,
+// the dice of every game cycle through the given rolls
+func newTestClient(t *testing.T, rolls ...board.DieRoll) enginepb.EngineClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	enginepb.RegisterEngineServer(server, NewService(Options{
+		AIs: map[string]ai.AI{"heuristic": ai.EvaluatorAI{Evaluator: ai.HeuristicEvaluator{}}},
+		NewDice: func(seed int64) game.Dice {
+			return &fixedDice{rolls}
+		},
+	}))
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return enginepb.NewEngineClient(conn)
+}
+
+func initialBoard(t *testing.T) *enginepb.Board {
+	b, err := board.ParseBoard(INITIAL_WHITE)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return BoardToProto(b)
+}
+
+func TestGetValidMoves(t *testing.T) {
+	// ARRANGE
+	client := newTestClient(t)
+	dice := board.DieRoll{Die1: 6, Die2: 5}
+
+	// ACT
+	response, err := client.GetValidMoves(context.Background(), &enginepb.GetValidMovesRequest{Board: initialBoard(t), Dice: DieRollToProto(dice)})
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := board.NewBoard(board.COLOR_WHITE).GetValidMovesForDieRoll(dice)
+	if len(response.Results) != len(expected) {
+		t.Fatalf("Output %v not equal to expected %v", len(response.Results), len(expected))
+	}
+	for idx, result := range response.Results {
+		mvRoll, _ := MoveRollFromProto(result.MoveRoll)
+		next, _ := BoardFromProto(result.Board)
+		if next.ColorToMove != board.COLOR_BLACK || len(mvRoll) != len(expected[idx]) || result.Notation == "" {
+			t.Errorf("Unexpected result %v", result)
+		}
+	}
+}
+
+func TestApplyMoveRoll(t *testing.T) {
+	// ARRANGE
+	client := newTestClient(t)
+	request := &enginepb.ApplyMoveRollRequest{
+		Board:    initialBoard(t),
+		Dice:     &enginepb.DieRoll{Die1: 6, Die2: 5},
+		MoveRoll: MoveRollToProto(board.MoveRoll{{From: 23, To: 17, Type: board.NORMAL_MOVE}, {From: 17, To: 12, Type: board.NORMAL_MOVE}}),
+	}
+
+	// ACT
+	response, err := client.ApplyMoveRoll(context.Background(), request)
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	next, _ := BoardFromProto(response.Board)
+	expected := "6-5/8-3/13-6/24-1:1-2/12-5/17-3/19-5 0 0 b"
+	if next.SerializeBoard() != expected {
+		t.Errorf("Output %v not equal to expected %v", next.SerializeBoard(), expected)
+	}
+
+	// ACT
+	request.MoveRoll = MoveRollToProto(board.MoveRoll{{From: 23, To: 13, Type: board.NORMAL_MOVE}})
+	_, err = client.ApplyMoveRoll(context.Background(), request)
+
+	// ASSERT
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Output %v not equal to expected %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	// ARRANGE
+	client := newTestClient(t)
+
+	// ACT
+	response, err := client.Evaluate(context.Background(), &enginepb.EvaluateRequest{Board: initialBoard(t)})
+	_, missingErr := client.Evaluate(context.Background(), &enginepb.EvaluateRequest{})
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := ai.HeuristicEvaluator{}.Evaluate(board.NewBoard(board.COLOR_WHITE))
+	if response.Win != expected.Win || response.Equity != expected.Equity() {
+		t.Errorf("Output %v not equal to expected %v", response, expected)
+	}
+	if status.Code(missingErr) != codes.InvalidArgument {
+		t.Errorf("Output %v not equal to expected %v", status.Code(missingErr), codes.InvalidArgument)
+	}
+}
+
+func TestChooseMove(t *testing.T) {
+	// ARRANGE
+	client := newTestClient(t)
+	dice := board.DieRoll{Die1: 3, Die2: 1}
+
+	// ACT
+	response, err := client.ChooseMove(context.Background(), &enginepb.ChooseMoveRequest{Board: initialBoard(t), Dice: DieRollToProto(dice), Ai: "heuristic"})
+	_, unknownErr := client.ChooseMove(context.Background(), &enginepb.ChooseMoveRequest{Board: initialBoard(t), Dice: DieRollToProto(dice), Ai: "unknown"})
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	b := board.NewBoard(board.COLOR_WHITE)
+	expected := ai.EvaluatorAI{Evaluator: ai.HeuristicEvaluator{}}.ChooseMove(b, dice)
+	if response.Notation != expected.Notation(b) {
+		t.Errorf("Output %v not equal to expected %v", response.Notation, expected.Notation(b))
+	}
+	if status.Code(unknownErr) != codes.NotFound {
+		t.Errorf("Output %v not equal to expected %v", status.Code(unknownErr), codes.NotFound)
+	}
+}
+
+func TestPlayGame(t *testing.T) {
+	// ARRANGE
+	client := newTestClient(t, board.DieRoll{Die1: 6, Die2: 5}, board.DieRoll{Die1: 3, Die2: 1})
+	stream, err := client.PlayGame(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	// ACT
+	stream.Send(&enginepb.PlayGameRequest{Request: &enginepb.PlayGameRequest_Start{Start: &enginepb.StartGame{Ai: "heuristic"}}})
+	event, err := stream.Recv()
+
+	// ASSERT
+	// White wins the opening roll 6-5
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	turn := event.GetTurnStarted()
+	if turn == nil || turn.Dice.Die1 != 6 || turn.Dice.Die2 != 5 || len(turn.LegalMoves) == 0 {
+		t.Fatalf("Unexpected event %v", event)
+	}
+
+	// ACT
+	illegal := MoveRollToProto(board.MoveRoll{{From: 23, To: 13, Type: board.NORMAL_MOVE}})
+	stream.Send(&enginepb.PlayGameRequest{Request: &enginepb.PlayGameRequest_MoveRoll{MoveRoll: illegal}})
+	rejected, _ := stream.Recv()
+	restarted, _ := stream.Recv()
+
+	// ASSERT
+	if rejected.GetMoveRejected() == nil || restarted.GetTurnStarted() == nil {
+		t.Fatalf("Unexpected events %v %v", rejected, restarted)
+	}
+
+	// ACT
+	stream.Send(&enginepb.PlayGameRequest{Request: &enginepb.PlayGameRequest_MoveRoll{MoveRoll: turn.LegalMoves[0].MoveRoll}})
+	played, _ := stream.Recv()
+	replied, _ := stream.Recv()
+	nextTurn, _ := stream.Recv()
+
+	// ASSERT
+	if played.GetMovePlayed().GetColor() != enginepb.Color_COLOR_WHITE || played.GetMovePlayed().GetNotation() != turn.LegalMoves[0].Notation {
+		t.Errorf("Unexpected event %v", played)
+	}
+	if replied.GetMovePlayed().GetColor() != enginepb.Color_COLOR_BLACK || replied.GetMovePlayed().GetDice().GetDie1() != 3 {
+		t.Errorf("Unexpected event %v", replied)
+	}
+	if nextTurn.GetTurnStarted() == nil {
+		t.Fatalf("Unexpected event %v", nextTurn)
+	}
+
+	// ACT
+	stream.Send(&enginepb.PlayGameRequest{Request: &enginepb.PlayGameRequest_Resign{Resign: &enginepb.Resign{Points: 2}}})
+	over, _ := stream.Recv()
+	_, endErr := stream.Recv()
+
+	// ASSERT
+	gameOver := over.GetGameOver()
+	if gameOver == nil || gameOver.Winner != enginepb.Color_COLOR_BLACK || gameOver.Points != 2 || !gameOver.Resigned {
+		t.Errorf("Unexpected event %v", over)
+	}
+	if endErr == nil {
+		t.Errorf("Expected the stream to end")
+	}
+}
+
+func TestPlayGameMustStart(t *testing.T) {
+	// ARRANGE
+	client := newTestClient(t, board.DieRoll{Die1: 6, Die2: 5})
+	stream, err := client.PlayGame(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	// ACT
+	stream.Send(&enginepb.PlayGameRequest{Request: &enginepb.PlayGameRequest_Resign{Resign: &enginepb.Resign{}}})
+	_, err = stream.Recv()
+
+	// ASSERT
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Output %v not equal to expected %v", status.Code(err), codes.InvalidArgument)
+	}
+}
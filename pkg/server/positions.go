@@ -0,0 +1,221 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+type movesRequest struct {
+	Board string `json:"board"`
+	Dice  []int  `json:"dice"`
+}
+
+type moveJSON struct {
+	Notation string `json:"notation"`
+	// Board after the move roll, with the opponent to move
+	Board         string `json:"board"`
+	Hits          int    `json:"hits"`
+	BorneOff      int    `json:"borneOff"`
+	PointsMade    int    `json:"pointsMade"`
+	EntersFromBar bool   `json:"entersFromBar"`
+}
+
+type movesResponse struct {
+	Moves []moveJSON `json:"moves"`
+}
+
+type applyRequest struct {
+	Board string `json:"board"`
+	Dice  []int  `json:"dice"`
+	Move  string `json:"move"`
+}
+
+type boardResponse struct {
+	Board string `json:"board"`
+}
+
+type evaluateRequest struct {
+	Board string `json:"board"`
+}
+
+type evaluationJSON struct {
+	Win            float64 `json:"win"`
+	WinGammon      float64 `json:"winGammon"`
+	WinBackgammon  float64 `json:"winBackgammon"`
+	LoseGammon     float64 `json:"loseGammon"`
+	LoseBackgammon float64 `json:"loseBackgammon"`
+	Equity         float64 `json:"equity"`
+}
+
+type hintRequest struct {
+	Board string `json:"board"`
+	Dice  []int  `json:"dice"`
+	// Number of candidates, all of them if 0
+	N     int `json:"n"`
+	Depth int `json:"depth"`
+}
+
+type candidateJSON struct {
+	Notation   string         `json:"notation"`
+	MoveRoll   board.MoveRoll `json:"moveRoll"`
+	Evaluation evaluationJSON `json:"evaluation"`
+	// Equity difference from the best candidate
+	Difference float64 `json:"difference"`
+}
+
+type hintResponse struct {
+	Candidates []candidateJSON `json:"candidates"`
+}
+
+func newMoveJSON(result board.MoveRollResult) moveJSON {
+	next := result.Board
+	next.ColorToMove = board.Color(1 - result.Board.ColorToMove)
+	return moveJSON{
+		Notation:      result.Notation,
+		Board:         next.SerializeBoard(),
+		Hits:          result.Hits,
+		BorneOff:      result.BorneOff,
+		PointsMade:    result.PointsMade,
+		EntersFromBar: result.EntersFromBar,
+	}
+}
+
+func newEvaluationJSON(evaluation ai.Evaluation) evaluationJSON {
+	return evaluationJSON{
+		Win:            evaluation.Win,
+		WinGammon:      evaluation.WinGammon,
+		WinBackgammon:  evaluation.WinBackgammon,
+		LoseGammon:     evaluation.LoseGammon,
+		LoseBackgammon: evaluation.LoseBackgammon,
+		Equity:         evaluation.Equity(),
+	}
+}
+
+func (s *Server) handleMoves(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	response, err := s.moves(w, r)
+	respond(w, response, err)
+}
+
+func (s *Server) moves(w http.ResponseWriter, r *http.Request) (movesResponse, error) {
+	request := movesRequest{}
+	if err := decodeBody(w, r, &request); err != nil {
+		return movesResponse{}, err
+	}
+	b, err := parseBoard(request.Board)
+	if err != nil {
+		return movesResponse{}, err
+	}
+	dice, err := parseDice(request.Dice)
+	if err != nil {
+		return movesResponse{}, err
+	}
+
+	response := movesResponse{[]moveJSON{}}
+	for _, result := range b.GetValidMoveResultsForDieRoll(dice) {
+		response.Moves = append(response.Moves, newMoveJSON(result))
+	}
+	return response, nil
+}
+
+func (s *Server) handleApply(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	response, err := s.apply(w, r)
+	respond(w, response, err)
+}
+
+func (s *Server) apply(w http.ResponseWriter, r *http.Request) (boardResponse, error) {
+	request := applyRequest{}
+	if err := decodeBody(w, r, &request); err != nil {
+		return boardResponse{}, err
+	}
+	b, err := parseBoard(request.Board)
+	if err != nil {
+		return boardResponse{}, err
+	}
+	dice, err := parseDice(request.Dice)
+	if err != nil {
+		return boardResponse{}, err
+	}
+	mvRoll, err := parseMoveRoll(request.Move, b.ColorToMove)
+	if err != nil {
+		return boardResponse{}, err
+	}
+
+	// The game checks the move roll is legal for the dice
+	g := game.NewGame(b)
+	if err := g.SetDice(dice); err != nil {
+		return boardResponse{}, gameError(err)
+	}
+	if err := g.Play(mvRoll); err != nil {
+		return boardResponse{}, gameError(err)
+	}
+	return boardResponse{g.Board.SerializeBoard()}, nil
+}
+
+func (s *Server) handleEvaluate(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	response, err := s.evaluate(w, r)
+	respond(w, response, err)
+}
+
+func (s *Server) evaluate(w http.ResponseWriter, r *http.Request) (evaluationJSON, error) {
+	request := evaluateRequest{}
+	if err := decodeBody(w, r, &request); err != nil {
+		return evaluationJSON{}, err
+	}
+	b, err := parseBoard(request.Board)
+	if err != nil {
+		return evaluationJSON{}, err
+	}
+	return newEvaluationJSON(s.options.Evaluator.Evaluate(b)), nil
+}
+
+func (s *Server) handleHint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	response, err := s.hint(w, r)
+	respond(w, response, err)
+}
+
+func (s *Server) hint(w http.ResponseWriter, r *http.Request) (hintResponse, error) {
+	request := hintRequest{}
+	if err := decodeBody(w, r, &request); err != nil {
+		return hintResponse{}, err
+	}
+	b, err := parseBoard(request.Board)
+	if err != nil {
+		return hintResponse{}, err
+	}
+	dice, err := parseDice(request.Dice)
+	if err != nil {
+		return hintResponse{}, err
+	}
+	if request.N < 0 || request.Depth < 0 || request.Depth > MAX_HINT_DEPTH {
+		return hintResponse{}, &apiError{http.StatusBadRequest, CODE_BAD_REQUEST,
+			fmt.Sprintf("n must not be negative and depth must be between 0 and %d", MAX_HINT_DEPTH)}
+	}
+
+	response := hintResponse{[]candidateJSON{}}
+	options := ai.HintOptions{Evaluator: s.options.Evaluator, Depth: request.Depth}
+	for _, candidate := range ai.Hint(b, dice, request.N, options) {
+		response.Candidates = append(response.Candidates, candidateJSON{
+			Notation:   candidate.Notation,
+			MoveRoll:   candidate.MoveRoll,
+			Evaluation: newEvaluationJSON(candidate.Evaluation),
+			Difference: candidate.Difference,
+		})
+	}
+	return response, nil
+}
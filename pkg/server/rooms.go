@@ -0,0 +1,606 @@
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	CODE_ROOM_FULL      = "room_full"
+	CODE_INVALID_TOKEN  = "invalid_token"
+	CODE_READ_ONLY      = "read_only"
+	CODE_NOT_STARTED    = "not_started"
+	CODE_NOT_YOUR_TURN  = "not_your_turn"
+	CODE_TOO_MANY_ROOMS = "too_many_rooms"
+)
+
+const (
+	ROLE_PLAYER    = "player"
+	ROLE_SPECTATOR = "spectator"
+)
+
+// Ways a room game can end besides a player bearing off all his checkers
+const (
+	END_RESIGNATION = "resignation"
+	END_TIMEOUT     = "timeout"
+	END_DISCONNECT  = "disconnect"
+)
+
+// Number of messages queued for a client before it is considered too slow and dropped
+const CLIENT_SEND_BUFFER = 64
+
+// Time allowed to write a message to a client
+const WRITE_TIMEOUT = 10 * time.Second
+
+// Value of the idle time of a room while clients are connected to it
+const ROOM_IN_USE = math.MinInt64
+
+// Message sent by a client of a room
+type clientMessage struct {
+	// "move" or "resign"
+	Type   string `json:"type"`
+	Move   string `json:"move,omitempty"`
+	Points int    `json:"points,omitempty"`
+}
+
+// Message sent to a client after it joined a room. Players reconnect
+// to their seat with the token
+type joinedMessage struct {
+	Type  string `json:"type"`
+	Room  string `json:"room"`
+	Role  string `json:"role"`
+	Color string `json:"color,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+type seatJSON struct {
+	Joined    bool `json:"joined"`
+	Connected bool `json:"connected"`
+}
+
+// Message holding the whole state of a room, sent to every client
+// whenever a turn starts or the game ends
+type stateMessage struct {
+	Type       string              `json:"type"`
+	Room       string              `json:"room"`
+	Started    bool                `json:"started"`
+	Players    map[string]seatJSON `json:"players"`
+	Board      string              `json:"board,omitempty"`
+	Turn       string              `json:"turn,omitempty"`
+	Dice       []int               `json:"dice,omitempty"`
+	LegalMoves []string            `json:"legalMoves"`
+	History    []turnJSON          `json:"history"`
+	Result     *resultJSON         `json:"result"`
+	EndReason  string              `json:"endReason,omitempty"`
+}
+
+// Message sent to every client when a move roll was played, an empty move passes the turn
+type moveMessage struct {
+	Type  string `json:"type"`
+	Color string `json:"color"`
+	Dice  []int  `json:"dice"`
+	Move  string `json:"move"`
+	Board string `json:"board"`
+}
+
+// Message sent to every client when a player disconnects or reconnects
+type playerMessage struct {
+	Type      string `json:"type"`
+	Color     string `json:"color"`
+	Connected bool   `json:"connected"`
+}
+
+type errorMessage struct {
+	Type  string    `json:"type"`
+	Error errorBody `json:"error"`
+}
+
+func newErrorMessage(code string, message string) errorMessage {
+	return errorMessage{"error", errorBody{code, message}}
+}
+
+// WebSocket connection of a player or a spectator. Messages are queued and
+// written by a single goroutine, so the room never blocks on a slow client
+type client struct {
+	conn   *websocket.Conn
+	mu     sync.Mutex
+	closed bool
+	send   chan interface{}
+}
+
+func newClient(conn *websocket.Conn) *client {
+	c := &client{conn: conn, send: make(chan interface{}, CLIENT_SEND_BUFFER)}
+	go c.writeLoop()
+	return c
+}
+
+func (c *client) writeLoop() {
+	for message := range c.send {
+		c.conn.SetWriteDeadline(time.Now().Add(WRITE_TIMEOUT))
+		if err := c.conn.WriteJSON(message); err != nil {
+			break
+		}
+	}
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	c.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(WRITE_TIMEOUT))
+	c.conn.Close()
+}
+
+// Function that queues a message, a client whose queue is full is closed
+func (c *client) write(message interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	select {
+	case c.send <- message:
+	default:
+		c.closed = true
+		close(c.send)
+	}
+}
+
+// Function that closes the connection once the queued messages are written
+func (c *client) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.send)
+	}
+}
+
+type seat struct {
+	token string
+	// Connection of the player, nil while he is disconnected
+	client         *client
+	reconnectTimer *time.Timer
+}
+
+// A game between two remote players, watched by any number of spectators.
+// The game starts once both seats are taken, the server rolls the dice
+// and validates every move roll
+type room struct {
+	mu         sync.Mutex
+	id         string
+	options    Options
+	remove     func()
+	closed     bool
+	seats      [2]*seat
+	spectators map[*client]bool
+	game       *game.Game
+	dice       game.Dice
+	history    []turnJSON
+	endReason  string
+	// Incremented at every turn, so a move timer only ends the turn it was started for
+	turn      int
+	moveTimer *time.Timer
+	now       func() time.Time
+	// Time in Unix nanoseconds since which no client is connected,
+	// ROOM_IN_USE while clients are connected
+	idleSince atomic.Int64
+}
+
+func newRoom(id string, options Options, now func() time.Time, remove func()) *room {
+	rm := &room{
+		id:         id,
+		options:    options,
+		remove:     remove,
+		seats:      [2]*seat{{}, {}},
+		spectators: map[*client]bool{},
+		now:        now,
+	}
+	rm.idleSince.Store(now().UnixNano())
+	return rm
+}
+
+var upgrader = websocket.Upgrader{}
+
+// Handles GET /v1/rooms/{id}?role=player|spectator&token=..., upgrading the
+// connection to a WebSocket. Rooms are created when the first client joins
+func (s *Server) handleRoom(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/v1/rooms/")
+	if id == "" || strings.Contains(id, "/") {
+		writeError(w, http.StatusNotFound, CODE_NOT_FOUND, "unknown endpoint "+r.URL.Path)
+		return
+	}
+	role := r.URL.Query().Get("role")
+	if role == "" {
+		role = ROLE_PLAYER
+	}
+	if role != ROLE_PLAYER && role != ROLE_SPECTATOR {
+		writeError(w, http.StatusBadRequest, CODE_BAD_REQUEST, "role must be player or spectator")
+		return
+	}
+
+	rm, apiErr := s.room(id)
+	if apiErr != nil {
+		writeError(w, apiErr.status, apiErr.code, apiErr.message)
+		return
+	}
+
+	// The upgrader replies with an HTTP error itself
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	conn.SetReadLimit(MAX_BODY_SIZE)
+	c := newClient(conn)
+	if err := rm.join(c, role, r.URL.Query().Get("token")); err != nil {
+		c.write(newErrorMessage(err.code, err.message))
+		c.close()
+		rm.leave(c)
+		return
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		message := clientMessage{}
+		if err := json.Unmarshal(data, &message); err != nil {
+			c.write(newErrorMessage(CODE_BAD_REQUEST, "invalid message: "+err.Error()))
+			continue
+		}
+		rm.handle(c, message)
+	}
+	rm.leave(c)
+	c.close()
+}
+
+// Function that returns the room with the given id, creating it if there
+// is room for one more
+func (s *Server) room(id string) (*room, *apiError) {
+	s.mu.Lock()
+	expired := s.expireRooms()
+	rm, ok := s.rooms[id]
+	if !ok && len(s.rooms) < s.options.MaxRooms {
+		rm = newRoom(id, s.options, s.now, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.rooms[id] == rm {
+				delete(s.rooms, id)
+			}
+		})
+		s.rooms[id] = rm
+	}
+	s.mu.Unlock()
+
+	// The rooms are closed without s.mu, as they take it to remove themselves
+	for _, old := range expired {
+		old.close()
+	}
+	if rm == nil {
+		return nil, &apiError{http.StatusServiceUnavailable, CODE_TOO_MANY_ROOMS, "too many rooms, retry later"}
+	}
+	return rm, nil
+}
+
+// Function that deletes the rooms no client was connected to for longer
+// than the idle timeout and returns them. The caller holds s.mu
+func (s *Server) expireRooms() []*room {
+	now := s.now()
+	expired := []*room{}
+	for id, rm := range s.rooms {
+		if rm.idle(now, s.options.RoomIdleTimeout) {
+			delete(s.rooms, id)
+			expired = append(expired, rm)
+		}
+	}
+	return expired
+}
+
+func (rm *room) idle(now time.Time, timeout time.Duration) bool {
+	idleSince := rm.idleSince.Load()
+	return idleSince != ROOM_IN_USE && now.Sub(time.Unix(0, idleSince)) > timeout
+}
+
+// Function that closes a deleted room, the clients joining it later get an error
+func (rm *room) close() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.closed = true
+	rm.stopTimers()
+}
+
+func (rm *room) join(c *client, role string, token string) *apiError {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if rm.closed {
+		return &apiError{http.StatusNotFound, CODE_NOT_FOUND, "room " + rm.id + " is closed"}
+	}
+	// The room is in use until the client leaves, at once if it fails to join
+	rm.idleSince.Store(ROOM_IN_USE)
+
+	if role == ROLE_SPECTATOR {
+		rm.spectators[c] = true
+		c.write(joinedMessage{Type: "joined", Room: rm.id, Role: ROLE_SPECTATOR})
+		c.write(rm.state())
+		return nil
+	}
+
+	if token != "" {
+		for color, s := range rm.seats {
+			if s.token != token {
+				continue
+			}
+			if s.client != nil {
+				// The newest connection of a player replaces the old one
+				s.client.close()
+			}
+			if s.reconnectTimer != nil {
+				s.reconnectTimer.Stop()
+				s.reconnectTimer = nil
+			}
+			s.client = c
+			c.write(joinedMessage{"joined", rm.id, ROLE_PLAYER, colorName(board.Color(color)), token})
+			rm.broadcast(playerMessage{"player", colorName(board.Color(color)), true})
+			c.write(rm.state())
+			return nil
+		}
+		return &apiError{http.StatusForbidden, CODE_INVALID_TOKEN, "invalid token"}
+	}
+
+	for color, s := range rm.seats {
+		if s.token != "" {
+			continue
+		}
+		s.token = newSessionID()
+		s.client = c
+		c.write(joinedMessage{"joined", rm.id, ROLE_PLAYER, colorName(board.Color(color)), s.token})
+		if rm.seats[0].token != "" && rm.seats[1].token != "" {
+			rm.start()
+		} else {
+			rm.broadcast(rm.state())
+		}
+		return nil
+	}
+	return &apiError{http.StatusConflict, CODE_ROOM_FULL, "room " + rm.id + " already has two players"}
+}
+
+// Function that handles a client leaving the room, a disconnected
+// player forfeits unless he reconnects in time
+func (rm *room) leave(c *client) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	delete(rm.spectators, c)
+	for color, s := range rm.seats {
+		color, s := color, s
+		if s.client != c {
+			continue
+		}
+		s.client = nil
+		if rm.game == nil {
+			// A player leaving before the game started frees his seat
+			s.token = ""
+			rm.broadcast(rm.state())
+			continue
+		}
+		rm.broadcast(playerMessage{"player", colorName(board.Color(color)), false})
+		if !rm.game.IsOver() && rm.options.ReconnectTimeout > 0 {
+			s.reconnectTimer = time.AfterFunc(rm.options.ReconnectTimeout, func() {
+				rm.mu.Lock()
+				defer rm.mu.Unlock()
+				if s.client == nil && !rm.game.IsOver() {
+					rm.end(board.Color(color), END_DISCONNECT)
+				}
+			})
+		}
+	}
+	if rm.seats[0].client == nil && rm.seats[1].client == nil && len(rm.spectators) == 0 {
+		rm.idleSince.Store(rm.now().UnixNano())
+	}
+	rm.removeIfDone()
+}
+
+func (rm *room) handle(c *client, message clientMessage) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	color, ok := rm.colorOf(c)
+	if !ok {
+		c.write(newErrorMessage(CODE_READ_ONLY, "spectators can not play"))
+		return
+	}
+	if rm.game == nil {
+		c.write(newErrorMessage(CODE_NOT_STARTED, "the game has not started yet"))
+		return
+	}
+	if rm.game.IsOver() {
+		c.write(newErrorMessage(CODE_GAME_OVER, game.ErrGameOver.Error()))
+		return
+	}
+
+	switch message.Type {
+	case "move":
+		if color != rm.game.Board.ColorToMove {
+			c.write(newErrorMessage(CODE_NOT_YOUR_TURN, "it is not your turn"))
+			return
+		}
+		mvRoll, err := parseMoveRoll(message.Move, color)
+		if err == nil {
+			err = gameError(rm.play(mvRoll))
+		}
+		if err != nil {
+			apiErr := err.(*apiError)
+			c.write(newErrorMessage(apiErr.code, apiErr.message))
+			return
+		}
+		rm.startTurn()
+	case "resign":
+		points := message.Points
+		if points == 0 {
+			points = 1
+		}
+		if err := rm.game.Resign(color, points); err != nil {
+			apiErr := gameError(err).(*apiError)
+			c.write(newErrorMessage(apiErr.code, apiErr.message))
+			return
+		}
+		rm.end(color, END_RESIGNATION)
+	default:
+		c.write(newErrorMessage(CODE_BAD_REQUEST, "unknown message type "+message.Type))
+	}
+}
+
+func (rm *room) colorOf(c *client) (board.Color, bool) {
+	for color, s := range rm.seats {
+		if s.client == c {
+			return board.Color(color), true
+		}
+	}
+	return 0, false
+}
+
+// Function that starts the game with the opening roll
+func (rm *room) start() {
+	rm.dice = rm.options.NewDice(0)
+	color, openingRoll := game.OpeningRoll(rm.dice)
+	rm.game = game.NewGame(board.NewBoard(color))
+	rm.game.SetDice(openingRoll)
+	rm.startTurn()
+}
+
+// Function that plays a move roll for the player to move and notifies the clients
+func (rm *room) play(mvRoll board.MoveRoll) error {
+	before := rm.game.Board
+	dice := []int{rm.game.Dice.Die1, rm.game.Dice.Die2}
+	if err := rm.game.Play(mvRoll); err != nil {
+		return err
+	}
+	notation := ""
+	if len(mvRoll) > 0 {
+		notation = mvRoll.Notation(before)
+	}
+	turn := turnJSON{colorName(before.ColorToMove), dice, notation}
+	rm.history = append(rm.history, turn)
+	rm.broadcast(moveMessage{"move", turn.Color, turn.Dice, turn.Move, rm.game.Board.SerializeBoard()})
+	return nil
+}
+
+// Function that rolls the dice for the player to move, passing his turn if
+// he can not move, and waits for his move roll
+func (rm *room) startTurn() {
+	rm.turn += 1
+	if rm.moveTimer != nil {
+		rm.moveTimer.Stop()
+	}
+	for !rm.game.IsOver() {
+		if !rm.game.Rolled {
+			rm.game.Roll(rm.dice)
+		}
+		if results, _ := rm.game.ValidMoves(); len(results) > 0 {
+			break
+		}
+		rm.play(board.MoveRoll{})
+	}
+	rm.broadcast(rm.state())
+	if rm.game.IsOver() {
+		rm.stopTimers()
+		return
+	}
+
+	if rm.options.MoveTimeout > 0 {
+		turn, color := rm.turn, rm.game.Board.ColorToMove
+		rm.moveTimer = time.AfterFunc(rm.options.MoveTimeout, func() {
+			rm.mu.Lock()
+			defer rm.mu.Unlock()
+			if rm.turn == turn && !rm.game.IsOver() {
+				rm.end(color, END_TIMEOUT)
+			}
+		})
+	}
+}
+
+// Function that ends the game with the loss of a single game by the given player,
+// unless the game is already over
+func (rm *room) end(loser board.Color, reason string) {
+	if !rm.game.IsOver() {
+		rm.game.Resign(loser, 1)
+	}
+	rm.endReason = reason
+	rm.stopTimers()
+	rm.broadcast(rm.state())
+	rm.removeIfDone()
+}
+
+func (rm *room) stopTimers() {
+	if rm.moveTimer != nil {
+		rm.moveTimer.Stop()
+	}
+	for _, s := range rm.seats {
+		if s.reconnectTimer != nil {
+			s.reconnectTimer.Stop()
+			s.reconnectTimer = nil
+		}
+	}
+}
+
+// Function that forgets the room once its game is over and every client left
+func (rm *room) removeIfDone() {
+	if rm.seats[0].client != nil || rm.seats[1].client != nil || len(rm.spectators) > 0 {
+		return
+	}
+	if rm.game != nil && rm.game.IsOver() || rm.seats[0].token == "" {
+		rm.closed = true
+		rm.remove()
+	}
+}
+
+func (rm *room) broadcast(message interface{}) {
+	for _, s := range rm.seats {
+		if s.client != nil {
+			s.client.write(message)
+		}
+	}
+	for c := range rm.spectators {
+		c.write(message)
+	}
+}
+
+func (rm *room) state() stateMessage {
+	state := stateMessage{
+		Type:       "state",
+		Room:       rm.id,
+		Started:    rm.game != nil,
+		Players:    map[string]seatJSON{},
+		LegalMoves: []string{},
+		History:    append([]turnJSON{}, rm.history...),
+		EndReason:  rm.endReason,
+	}
+	for color, s := range rm.seats {
+		state.Players[colorName(board.Color(color))] = seatJSON{s.token != "", s.client != nil}
+	}
+	if rm.game == nil {
+		return state
+	}
+
+	g := rm.game
+	state.Board = g.Board.SerializeBoard()
+	state.Turn = colorName(g.Board.ColorToMove)
+	if g.IsOver() {
+		state.Result = &resultJSON{colorName(g.Result.Winner), g.Result.Points, g.Result.Resigned}
+		return state
+	}
+	if g.Rolled {
+		state.Dice = []int{g.Dice.Die1, g.Dice.Die2}
+		results, _ := g.ValidMoves()
+		for _, result := range results {
+			state.LegalMoves = append(state.LegalMoves, result.Notation)
+		}
+	}
+	return state
+}
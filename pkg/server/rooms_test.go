@@ -0,0 +1,344 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+	"github.com/gorilla/websocket"
+)
+
+// Union of the fields of the messages sent to the clients of a room
+type roomMessage struct {
+	Type       string              `json:"type"`
+	Role       string              `json:"role"`
+	Color      string              `json:"color"`
+	Token      string              `json:"token"`
+	Started    bool                `json:"started"`
+	Players    map[string]seatJSON `json:"players"`
+	Board      string              `json:"board"`
+	Turn       string              `json:"turn"`
+	Dice       []int               `json:"dice"`
+	LegalMoves []string            `json:"legalMoves"`
+	History    []turnJSON          `json:"history"`
+	Result     *resultJSON         `json:"result"`
+	EndReason  string              `json:"endReason"`
+	Move       string              `json:"move"`
+	Connected  bool                `json:"connected"`
+	Error      errorBody           `json:"error"`
+}
+
+func newRoomTestServer(moveTimeout time.Duration, reconnectTimeout time.Duration) *httptest.Server {
+	return httptest.NewServer(New(Options{
+		AIs: map[string]ai.AI{},
+		NewDice: func(seed int64) game.Dice {
+			return &fixedDice{[]board.DieRoll{{Die1: 6, Die2: 5}, {Die1: 3, Die2: 1}}}
+		},
+		MoveTimeout:      moveTimeout,
+		ReconnectTimeout: reconnectTimeout,
+	}))
+}
+
+func dialRoom(t *testing.T, srv *httptest.Server, query string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/v1/rooms/test?" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return conn
+}
+
+// Function that reads messages until one of the given type is received
+func readMessage(t *testing.T, conn *websocket.Conn, messageType string) roomMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		message := roomMessage{}
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("Unexpected error %v while waiting for %s", err, messageType)
+		}
+		if message.Type == messageType {
+			return message
+		}
+	}
+}
+
+// Function that joins two players, white is the first to join
+func joinPlayers(t *testing.T, srv *httptest.Server) (*websocket.Conn, roomMessage, *websocket.Conn, roomMessage) {
+	white := dialRoom(t, srv, "role=player")
+	whiteJoined := readMessage(t, white, "joined")
+	black := dialRoom(t, srv, "")
+	blackJoined := readMessage(t, black, "joined")
+	return white, whiteJoined, black, blackJoined
+}
+
+func TestRoomGame(t *testing.T) {
+	// ARRANGE
+	srv := newRoomTestServer(0, 0)
+	defer srv.Close()
+	white, whiteJoined, black, blackJoined := joinPlayers(t, srv)
+	defer white.Close()
+	defer black.Close()
+	spectator := dialRoom(t, srv, "role=spectator")
+	defer spectator.Close()
+
+	// ASSERT
+	if whiteJoined.Color != "white" || blackJoined.Color != "black" || whiteJoined.Token == "" || whiteJoined.Token == blackJoined.Token {
+		t.Fatalf("Unexpected joined messages %+v %+v", whiteJoined, blackJoined)
+	}
+	if joined := readMessage(t, spectator, "joined"); joined.Role != ROLE_SPECTATOR || joined.Token != "" {
+		t.Errorf("Unexpected joined message %+v", joined)
+	}
+	state := readMessage(t, spectator, "state")
+	if !state.Started || state.Turn != "white" || state.Board != INITIAL_WHITE || len(state.LegalMoves) == 0 {
+		t.Fatalf("Unexpected state %+v", state)
+	}
+
+	// ACT
+	spectator.WriteJSON(clientMessage{Type: "move", Move: "24/13"})
+	black.WriteJSON(clientMessage{Type: "move", Move: "24/13"})
+	white.WriteJSON(clientMessage{Type: "move", Move: "24/14"})
+
+	// ASSERT
+	if message := readMessage(t, spectator, "error"); message.Error.Code != CODE_READ_ONLY {
+		t.Errorf("Output %v not equal to expected %v", message.Error.Code, CODE_READ_ONLY)
+	}
+	if message := readMessage(t, black, "error"); message.Error.Code != CODE_NOT_YOUR_TURN {
+		t.Errorf("Output %v not equal to expected %v", message.Error.Code, CODE_NOT_YOUR_TURN)
+	}
+	if message := readMessage(t, white, "error"); message.Error.Code != CODE_ILLEGAL_MOVE {
+		t.Errorf("Output %v not equal to expected %v", message.Error.Code, CODE_ILLEGAL_MOVE)
+	}
+
+	// ACT
+	white.WriteJSON(clientMessage{Type: "move", Move: "24/13"})
+
+	// ASSERT
+	for _, conn := range []*websocket.Conn{white, black, spectator} {
+		move := readMessage(t, conn, "move")
+		if move.Color != "white" || move.Move != "24/13" || move.Board != "6-5/8-3/13-6/24-1:1-2/12-5/17-3/19-5 0 0 b" {
+			t.Errorf("Unexpected move %+v", move)
+		}
+		state := readMessage(t, conn, "state")
+		if state.Turn != "black" || state.Dice[0] != 3 || state.Dice[1] != 1 {
+			t.Errorf("Unexpected state %+v", state)
+		}
+	}
+
+	// ACT
+	black.WriteJSON(clientMessage{Type: "resign", Points: 2})
+
+	// ASSERT
+	state = readMessage(t, spectator, "state")
+	expectedResult := resultJSON{"white", 2, true}
+	if state.Result == nil || *state.Result != expectedResult || state.EndReason != END_RESIGNATION {
+		t.Errorf("Output %v %v not equal to expected %v %v", state.Result, state.EndReason, expectedResult, END_RESIGNATION)
+	}
+}
+
+func TestRoomFull(t *testing.T) {
+	// ARRANGE
+	srv := newRoomTestServer(0, 0)
+	defer srv.Close()
+	white, _, black, _ := joinPlayers(t, srv)
+	defer white.Close()
+	defer black.Close()
+
+	// ACT
+	third := dialRoom(t, srv, "role=player")
+	defer third.Close()
+	impostor := dialRoom(t, srv, "token=unknown")
+	defer impostor.Close()
+
+	// ASSERT
+	if message := readMessage(t, third, "error"); message.Error.Code != CODE_ROOM_FULL {
+		t.Errorf("Output %v not equal to expected %v", message.Error.Code, CODE_ROOM_FULL)
+	}
+	if message := readMessage(t, impostor, "error"); message.Error.Code != CODE_INVALID_TOKEN {
+		t.Errorf("Output %v not equal to expected %v", message.Error.Code, CODE_INVALID_TOKEN)
+	}
+}
+
+func TestRoomReconnect(t *testing.T) {
+	// ARRANGE
+	srv := newRoomTestServer(0, time.Minute)
+	defer srv.Close()
+	white, whiteJoined, black, _ := joinPlayers(t, srv)
+	defer black.Close()
+	readMessage(t, black, "state")
+
+	// ACT
+	white.Close()
+	disconnected := readMessage(t, black, "player")
+	white = dialRoom(t, srv, "token="+whiteJoined.Token)
+	defer white.Close()
+
+	// ASSERT
+	if disconnected.Color != "white" || disconnected.Connected {
+		t.Errorf("Unexpected player message %+v", disconnected)
+	}
+	if joined := readMessage(t, white, "joined"); joined.Color != "white" {
+		t.Errorf("Output %v not equal to expected %v", joined.Color, "white")
+	}
+	if reconnected := readMessage(t, black, "player"); reconnected.Color != "white" || !reconnected.Connected {
+		t.Errorf("Unexpected player message %+v", reconnected)
+	}
+	state := readMessage(t, white, "state")
+	if state.Turn != "white" || state.Board != INITIAL_WHITE || !state.Players["white"].Connected {
+		t.Errorf("Unexpected state %+v", state)
+	}
+
+	// ACT
+	white.WriteJSON(clientMessage{Type: "move", Move: "24/13"})
+
+	// ASSERT
+	if move := readMessage(t, black, "move"); move.Move != "24/13" {
+		t.Errorf("Output %v not equal to expected %v", move.Move, "24/13")
+	}
+}
+
+func TestRoomTimeouts(t *testing.T) {
+	// ARRANGE
+	srv := newRoomTestServer(50*time.Millisecond, 0)
+	defer srv.Close()
+	white, _, black, _ := joinPlayers(t, srv)
+	defer white.Close()
+	defer black.Close()
+
+	// ACT
+	// White wins the opening roll and never moves
+	var state roomMessage
+	for state.Result == nil {
+		state = readMessage(t, black, "state")
+	}
+
+	// ASSERT
+	expectedResult := resultJSON{"black", 1, true}
+	if *state.Result != expectedResult || state.EndReason != END_TIMEOUT {
+		t.Errorf("Output %v %v not equal to expected %v %v", *state.Result, state.EndReason, expectedResult, END_TIMEOUT)
+	}
+
+	// ARRANGE
+	disconnectSrv := newRoomTestServer(0, 50*time.Millisecond)
+	defer disconnectSrv.Close()
+	white, _, black, _ = joinPlayers(t, disconnectSrv)
+	defer black.Close()
+	readMessage(t, black, "state")
+
+	// ACT
+	white.Close()
+	state = roomMessage{}
+	for state.Result == nil {
+		state = readMessage(t, black, "state")
+	}
+
+	// ASSERT
+	if *state.Result != expectedResult || state.EndReason != END_DISCONNECT {
+		t.Errorf("Output %v %v not equal to expected %v %v", *state.Result, state.EndReason, expectedResult, END_DISCONNECT)
+	}
+}
+
+func TestRoomBadRequests(t *testing.T) {
+	// ARRANGE
+	srv := newRoomTestServer(0, 0)
+	defer srv.Close()
+	response := errorResponse{}
+
+	// ACT
+	status := doRequest(t, http.MethodGet, srv.URL+"/v1/rooms/test?role=referee", ``, &response)
+
+	// ASSERT
+	if status != http.StatusBadRequest || response.Error.Code != CODE_BAD_REQUEST {
+		t.Errorf("Output %v %v not equal to expected %v %v", status, response.Error.Code, http.StatusBadRequest, CODE_BAD_REQUEST)
+	}
+
+	// ARRANGE
+	white := dialRoom(t, srv, "")
+	defer white.Close()
+	readMessage(t, white, "joined")
+
+	// ACT
+	white.WriteJSON(clientMessage{Type: "move", Move: "24/13"})
+	white.WriteMessage(websocket.TextMessage, []byte("{"))
+
+	// ASSERT
+	if message := readMessage(t, white, "error"); message.Error.Code != CODE_NOT_STARTED {
+		t.Errorf("Output %v not equal to expected %v", message.Error.Code, CODE_NOT_STARTED)
+	}
+	if message := readMessage(t, white, "error"); message.Error.Code != CODE_BAD_REQUEST {
+		t.Errorf("Output %v not equal to expected %v", message.Error.Code, CODE_BAD_REQUEST)
+	}
+}
+
+func TestRoomMessageTooBig(t *testing.T) {
+	// ARRANGE
+	srv := newRoomTestServer(0, 0)
+	defer srv.Close()
+	white := dialRoom(t, srv, "")
+	defer white.Close()
+	readMessage(t, white, "joined")
+
+	// ACT
+	white.WriteMessage(websocket.TextMessage, []byte(strings.Repeat(" ", MAX_BODY_SIZE+1)))
+	var err error
+	white.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for err == nil {
+		_, _, err = white.ReadMessage()
+	}
+
+	// ASSERT
+	if !websocket.IsCloseError(err, websocket.CloseMessageTooBig) {
+		t.Errorf("Output %v not equal to expected %v", err, websocket.CloseMessageTooBig)
+	}
+}
+
+func TestRoomLimits(t *testing.T) {
+	// ARRANGE
+	now := time.Unix(0, 0)
+	handler := New(Options{
+		NewDice: func(seed int64) game.Dice {
+			return &fixedDice{[]board.DieRoll{{Die1: 6, Die2: 5}}}
+		},
+		MaxRooms:        1,
+		RoomIdleTimeout: time.Minute,
+	})
+	handler.now = func() time.Time { return now }
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	// Both players leave in the middle of the game, the room is kept for them
+	white, _, black, _ := joinPlayers(t, srv)
+	white.Close()
+	black.Close()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		handler.mu.Lock()
+		idle := handler.rooms["test"].idleSince.Load() != ROOM_IN_USE
+		handler.mu.Unlock()
+		if idle {
+			break
+		}
+	}
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/v1/rooms/other"
+
+	// ACT
+	_, full, fullErr := websocket.DefaultDialer.Dial(url, nil)
+	now = now.Add(2 * time.Minute)
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+
+	// ASSERT
+	if fullErr == nil || full == nil || full.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Output %v not equal to expected %v", fullErr, http.StatusServiceUnavailable)
+	}
+	// The idle room was deleted
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	defer conn.Close()
+	if message := readMessage(t, conn, "joined"); message.Color != "white" {
+		t.Errorf("Output %v not equal to expected %v", message.Color, "white")
+	}
+}
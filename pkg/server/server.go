@@ -0,0 +1,291 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+const (
+	CODE_BAD_REQUEST        = "bad_request"
+	CODE_INVALID_BOARD      = "invalid_board"
+	CODE_INVALID_DICE       = "invalid_dice"
+	CODE_INVALID_MOVE       = "invalid_move"
+	CODE_ILLEGAL_MOVE       = "illegal_move"
+	CODE_UNKNOWN_AI         = "unknown_ai"
+	CODE_NOT_FOUND          = "not_found"
+	CODE_METHOD_NOT_ALLOWED = "method_not_allowed"
+	CODE_GAME_OVER          = "game_over"
+	CODE_TOO_MANY_SESSIONS  = "too_many_sessions"
+	CODE_CANCELLED          = "cancelled"
+	CODE_TIMEOUT            = "timeout"
+)
+
+// Status of the requests the client closed before getting the response,
+// the status nginx logs for them
+const STATUS_CLIENT_CLOSED_REQUEST = 499
+
+// Maximum number of rolls the hint endpoint looks ahead, deeper searches
+// take minutes
+const MAX_HINT_DEPTH = 1
+
+// Maximum size of a request body, requests only hold a few strings
+const MAX_BODY_SIZE = 1 << 16
+
+// Default limits of the game sessions, so clients creating sessions
+// without deleting them cannot grow the server without bound
+const (
+	DEFAULT_MAX_SESSIONS         = 1000
+	DEFAULT_SESSION_IDLE_TIMEOUT = 30 * time.Minute
+)
+
+// Default limits of the multiplayer rooms, so rooms left by their players
+// in the middle of a game do not grow the server without bound
+const (
+	DEFAULT_MAX_ROOMS         = 1000
+	DEFAULT_ROOM_IDLE_TIMEOUT = 30 * time.Minute
+)
+
+type Options struct {
+	// AIs game sessions can be played against, by name
+	AIs map[string]ai.AI
+	// Evaluator used by the evaluate and the hint endpoints
+	Evaluator ai.Evaluator
+	// Function creating the dice of a new session, the seed is the one
+	// requested by the client or 0 if none was requested
+	NewDice func(seed int64) game.Dice
+	// Time a player of a room has to play his move roll, no limit if 0
+	MoveTimeout time.Duration
+	// Time a disconnected player of a room has to reconnect before
+	// losing the game, no limit if 0
+	ReconnectTimeout time.Duration
+	// Maximum number of game sessions, DEFAULT_MAX_SESSIONS if 0
+	MaxSessions int
+	// Time after which a game session nobody used is deleted,
+	// DEFAULT_SESSION_IDLE_TIMEOUT if 0
+	SessionIdleTimeout time.Duration
+	// Maximum number of rooms, DEFAULT_MAX_ROOMS if 0
+	MaxRooms int
+	// Time after which a room without any connected client is deleted,
+	// DEFAULT_ROOM_IDLE_TIMEOUT if 0
+	RoomIdleTimeout time.Duration
+}
+
+// HTTP handler serving the JSON API:
+//
+//	POST   /v1/moves                legal move rolls for a board and dice
+//	POST   /v1/apply                plays a move roll on a board
+//	POST   /v1/evaluate             evaluates a board
+//	POST   /v1/hint                 best move rolls for a board and dice
+//	POST   /v1/sessions             creates a game session against an AI
+//	GET    /v1/sessions/{id}        returns a game session
+//	DELETE /v1/sessions/{id}        deletes a game session
+//	POST   /v1/sessions/{id}/move   plays a move roll in a game session
+//	POST   /v1/sessions/{id}/resign resigns a game session
+//	GET    /v1/rooms/{id}           joins a multiplayer room over a WebSocket
+//
+// At most Options.MaxSessions game sessions exist at a time, a game
+// session nobody used for Options.SessionIdleTimeout is deleted. Likewise
+// for the rooms with Options.MaxRooms, a room is deleted once no client
+// was connected to it for Options.RoomIdleTimeout
+type Server struct {
+	options  Options
+	mux      *http.ServeMux
+	mu       sync.Mutex
+	sessions map[string]*session
+	rooms    map[string]*room
+	// Current time, replaced by the tests
+	now func() time.Time
+}
+
+func New(options Options) *Server {
+	if options.Evaluator == nil {
+		options.Evaluator = ai.HeuristicEvaluator{}
+	}
+	if options.NewDice == nil {
+		options.NewDice = func(seed int64) game.Dice {
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+			return game.NewRandomDice(seed)
+		}
+	}
+	if options.MaxSessions == 0 {
+		options.MaxSessions = DEFAULT_MAX_SESSIONS
+	}
+	if options.SessionIdleTimeout == 0 {
+		options.SessionIdleTimeout = DEFAULT_SESSION_IDLE_TIMEOUT
+	}
+	if options.MaxRooms == 0 {
+		options.MaxRooms = DEFAULT_MAX_ROOMS
+	}
+	if options.RoomIdleTimeout == 0 {
+		options.RoomIdleTimeout = DEFAULT_ROOM_IDLE_TIMEOUT
+	}
+	s := &Server{options: options, mux: http.NewServeMux(), sessions: map[string]*session{}, rooms: map[string]*room{}, now: time.Now}
+	s.mux.HandleFunc("/v1/moves", s.handleMoves)
+	s.mux.HandleFunc("/v1/apply", s.handleApply)
+	s.mux.HandleFunc("/v1/evaluate", s.handleEvaluate)
+	s.mux.HandleFunc("/v1/hint", s.handleHint)
+	s.mux.HandleFunc("/v1/sessions", s.handleSessions)
+	s.mux.HandleFunc("/v1/sessions/", s.handleSession)
+	s.mux.HandleFunc("/v1/rooms/", s.handleRoom)
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, CODE_NOT_FOUND, "unknown endpoint "+r.URL.Path)
+	})
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, errorResponse{errorBody{code, message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Function that writes either the response body or the error
+func respond(w http.ResponseWriter, body interface{}, err error) {
+	if err != nil {
+		apiErr := &apiError{}
+		if !errors.As(err, &apiErr) {
+			apiErr = internalError(err)
+		}
+		writeError(w, apiErr.status, apiErr.code, apiErr.message)
+		return
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// Function that converts an error that is not an API error: the request
+// was cancelled, or the AI ran out of time, or the server failed
+func internalError(err error) *apiError {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return &apiError{STATUS_CLIENT_CLOSED_REQUEST, CODE_CANCELLED, "the request was cancelled"}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &apiError{http.StatusServiceUnavailable, CODE_TIMEOUT, "the AI did not move in time, retry later"}
+	}
+	return &apiError{http.StatusInternalServerError, "internal", err.Error()}
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, body interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MAX_BODY_SIZE))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(body); err != nil {
+		return &apiError{http.StatusBadRequest, CODE_BAD_REQUEST, "invalid request body: " + err.Error()}
+	}
+	return nil
+}
+
+func requireMethod(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, method := range methods {
+		if r.Method == method {
+			return true
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, CODE_METHOD_NOT_ALLOWED, "method "+r.Method+" is not allowed")
+	return false
+}
+
+func parseBoard(boardStr string) (board.Board, error) {
+	b, err := board.ParseBoard(boardStr)
+	if err != nil {
+		return board.Board{}, &apiError{http.StatusBadRequest, CODE_INVALID_BOARD, err.Error()}
+	}
+	return b, nil
+}
+
+func parseDice(dice []int) (board.DieRoll, error) {
+	if len(dice) != 2 || dice[0] < 1 || dice[0] > 6 || dice[1] < 1 || dice[1] > 6 {
+		return board.DieRoll{}, &apiError{http.StatusBadRequest, CODE_INVALID_DICE, "dice must be two values between 1 and 6"}
+	}
+	return board.DieRoll{Die1: dice[0], Die2: dice[1]}, nil
+}
+
+func parseMoveRoll(notation string, color board.Color) (board.MoveRoll, error) {
+	// An empty move roll passes the turn when there are no legal moves
+	if strings.TrimSpace(notation) == "" {
+		return board.MoveRoll{}, nil
+	}
+	mvRoll, err := board.ParseMoveRollNotation(notation, color)
+	if err != nil {
+		return nil, &apiError{http.StatusBadRequest, CODE_INVALID_MOVE, err.Error()}
+	}
+	return mvRoll, nil
+}
+
+// Function that converts the errors returned by a game to API errors
+func gameError(err error) error {
+	switch err {
+	case nil:
+		return nil
+	case game.ErrIllegalMove:
+		return &apiError{http.StatusUnprocessableEntity, CODE_ILLEGAL_MOVE, err.Error()}
+	case game.ErrGameOver:
+		return &apiError{http.StatusConflict, CODE_GAME_OVER, err.Error()}
+	default:
+		return &apiError{http.StatusBadRequest, CODE_BAD_REQUEST, err.Error()}
+	}
+}
+
+func newSessionID() string {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(bytes)
+}
+
+func colorName(color board.Color) string {
+	if color == board.COLOR_WHITE {
+		return "white"
+	}
+	return "black"
+}
+
+func parseColor(name string) (board.Color, bool) {
+	switch name {
+	case "white":
+		return board.COLOR_WHITE, true
+	case "black":
+		return board.COLOR_BLACK, true
+	}
+	return 0, false
+}
@@ -0,0 +1,414 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+const INITIAL_WHITE = "6-5/8-3/13-5/24-2:1-2/12-5/17-3/19-5 0 0 w"
+
+type fixedDice struct {
+	rolls []board.DieRoll
+}
+
+func (d *fixedDice) Roll() board.DieRoll {
+	roll := d.rolls[0]
+	d.rolls = append(d.rolls[1:], roll)
+	return roll
+}
+
+func newTestServer(rolls ...board.DieRoll) *httptest.Server {
+	return httptest.NewServer(New(Options{
+		AIs:       map[string]ai.AI{"heuristic": ai.EvaluatorAI{Evaluator: ai.HeuristicEvaluator{}}},
+		Evaluator: ai.HeuristicEvaluator{},
+		NewDice: func(seed int64) game.Dice {
+			return &fixedDice{rolls}
+		},
+	}))
+}
+
+func doRequest(t *testing.T, method string, url string, body string, response interface{}) int {
+	request, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	defer resp.Body.Close()
+	if response != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+type errorTest struct {
+	method         string
+	path           string
+	body           string
+	expectedStatus int
+	expectedCode   string
+}
+
+func makeErrorTests() []errorTest {
+	return []errorTest{
+		{http.MethodPost, "/v1/moves", `{"board": "nonsense", "dice": [6, 5]}`, http.StatusBadRequest, CODE_INVALID_BOARD},
+		{http.MethodPost, "/v1/moves", `{"board": "` + INITIAL_WHITE + `", "dice": [7, 5]}`, http.StatusBadRequest, CODE_INVALID_DICE},
+		{http.MethodPost, "/v1/moves", `{"board": "` + INITIAL_WHITE + `"}`, http.StatusBadRequest, CODE_INVALID_DICE},
+		{http.MethodPost, "/v1/moves", `{"board": `, http.StatusBadRequest, CODE_BAD_REQUEST},
+		{http.MethodPost, "/v1/moves", `{"position": "` + INITIAL_WHITE + `"}`, http.StatusBadRequest, CODE_BAD_REQUEST},
+		{http.MethodGet, "/v1/moves", ``, http.StatusMethodNotAllowed, CODE_METHOD_NOT_ALLOWED},
+		{http.MethodPost, "/v1/apply", `{"board": "` + INITIAL_WHITE + `", "dice": [6, 5], "move": "24/14"}`, http.StatusUnprocessableEntity, CODE_ILLEGAL_MOVE},
+		{http.MethodPost, "/v1/apply", `{"board": "` + INITIAL_WHITE + `", "dice": [6, 5], "move": "24/x"}`, http.StatusBadRequest, CODE_INVALID_MOVE},
+		{http.MethodPost, "/v1/apply", `{"board": "` + INITIAL_WHITE + `", "dice": [6, 5], "move": ""}`, http.StatusUnprocessableEntity, CODE_ILLEGAL_MOVE},
+		{http.MethodPost, "/v1/hint", `{"board": "` + INITIAL_WHITE + `", "dice": [6, 5], "depth": 3}`, http.StatusBadRequest, CODE_BAD_REQUEST},
+		{http.MethodPost, "/v1/sessions", `{"ai": "unknown"}`, http.StatusBadRequest, CODE_UNKNOWN_AI},
+		{http.MethodPost, "/v1/sessions", `{"ai": "heuristic", "color": "green"}`, http.StatusBadRequest, CODE_BAD_REQUEST},
+		{http.MethodGet, "/v1/sessions/unknown", ``, http.StatusNotFound, CODE_NOT_FOUND},
+		{http.MethodGet, "/v2/moves", ``, http.StatusNotFound, CODE_NOT_FOUND},
+	}
+}
+
+func TestErrors(t *testing.T) {
+	srv := newTestServer(board.DieRoll{Die1: 6, Die2: 5})
+	defer srv.Close()
+
+	for _, test := range makeErrorTests() {
+		// ARRANGE
+		response := errorResponse{}
+
+		// ACT
+		status := doRequest(t, test.method, srv.URL+test.path, test.body, &response)
+
+		// ASSERT
+		if status != test.expectedStatus {
+			t.Errorf("%s %s: Output %v not equal to expected %v", test.method, test.path, status, test.expectedStatus)
+		}
+		if response.Error.Code != test.expectedCode || response.Error.Message == "" {
+			t.Errorf("%s %s: Output %v not equal to expected %v", test.method, test.path, response.Error, test.expectedCode)
+		}
+	}
+}
+
+func TestMoves(t *testing.T) {
+	// ARRANGE
+	srv := newTestServer()
+	defer srv.Close()
+	response := movesResponse{}
+
+	// ACT
+	status := doRequest(t, http.MethodPost, srv.URL+"/v1/moves", `{"board": "`+INITIAL_WHITE+`", "dice": [6, 5]}`, &response)
+
+	// ASSERT
+	if status != http.StatusOK {
+		t.Fatalf("Output %v not equal to expected %v", status, http.StatusOK)
+	}
+	expected := len(board.NewBoard(board.COLOR_WHITE).GetValidMovesForDieRoll(board.DieRoll{Die1: 6, Die2: 5}))
+	if len(response.Moves) != expected {
+		t.Errorf("Output %v not equal to expected %v", len(response.Moves), expected)
+	}
+	found := false
+	for _, mv := range response.Moves {
+		if mv.Notation == "24/18 18/13" {
+			found = true
+			if mv.Board != "6-5/8-3/13-6/24-1:1-2/12-5/17-3/19-5 0 0 b" {
+				t.Errorf("Output %v not equal to expected %v", mv.Board, "6-5/8-3/13-6/24-1:1-2/12-5/17-3/19-5 0 0 b")
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Move 24/18 18/13 not found in %v", response.Moves)
+	}
+}
+
+func TestApply(t *testing.T) {
+	// ARRANGE
+	srv := newTestServer()
+	defer srv.Close()
+	response := boardResponse{}
+
+	// ACT
+	status := doRequest(t, http.MethodPost, srv.URL+"/v1/apply", `{"board": "`+INITIAL_WHITE+`", "dice": [6, 5], "move": "24/18/13"}`, &response)
+
+	// ASSERT
+	expected := "6-5/8-3/13-6/24-1:1-2/12-5/17-3/19-5 0 0 b"
+	if status != http.StatusOK || response.Board != expected {
+		t.Errorf("Output %v %v not equal to expected %v %v", status, response.Board, http.StatusOK, expected)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	// ARRANGE
+	srv := newTestServer()
+	defer srv.Close()
+	response := evaluationJSON{}
+
+	// ACT
+	status := doRequest(t, http.MethodPost, srv.URL+"/v1/evaluate", `{"board": "`+INITIAL_WHITE+`"}`, &response)
+
+	// ASSERT
+	b, _ := board.ParseBoard(INITIAL_WHITE)
+	expected := newEvaluationJSON(ai.HeuristicEvaluator{}.Evaluate(b))
+	if status != http.StatusOK || response != expected {
+		t.Errorf("Output %v not equal to expected %v", response, expected)
+	}
+}
+
+func TestHint(t *testing.T) {
+	// ARRANGE
+	srv := newTestServer()
+	defer srv.Close()
+	response := hintResponse{}
+
+	// ACT
+	status := doRequest(t, http.MethodPost, srv.URL+"/v1/hint", `{"board": "`+INITIAL_WHITE+`", "dice": [3, 1], "n": 2}`, &response)
+
+	// ASSERT
+	b, _ := board.ParseBoard(INITIAL_WHITE)
+	expected := ai.Hint(b, board.DieRoll{Die1: 3, Die2: 1}, 2, ai.HintOptions{})
+	if status != http.StatusOK || len(response.Candidates) != 2 {
+		t.Fatalf("Output %v %v not equal to expected %v", status, response, expected)
+	}
+	for idx, candidate := range response.Candidates {
+		if candidate.Notation != expected[idx].Notation || candidate.Difference != expected[idx].Difference || candidate.Evaluation.Equity != expected[idx].Equity {
+			t.Errorf("Output %v not equal to expected %v", candidate, expected[idx])
+		}
+	}
+}
+
+func TestSession(t *testing.T) {
+	// ARRANGE
+	srv := newTestServer(board.DieRoll{Die1: 6, Die2: 5}, board.DieRoll{Die1: 3, Die2: 1})
+	defer srv.Close()
+	created := sessionJSON{}
+
+	// ACT
+	status := doRequest(t, http.MethodPost, srv.URL+"/v1/sessions", `{"ai": "heuristic", "color": "white"}`, &created)
+
+	// ASSERT
+	// White wins the opening roll 6-5 and moves first
+	if status != http.StatusOK {
+		t.Fatalf("Output %v not equal to expected %v", status, http.StatusOK)
+	}
+	if created.Turn != "white" || created.Board != INITIAL_WHITE || len(created.LegalMoves) == 0 || created.Result != nil {
+		t.Fatalf("Unexpected session %+v", created)
+	}
+	if created.Dice[0] != 6 || created.Dice[1] != 5 {
+		t.Errorf("Output %v not equal to expected %v", created.Dice, []int{6, 5})
+	}
+
+	// ACT
+	played := sessionJSON{}
+	status = doRequest(t, http.MethodPost, srv.URL+"/v1/sessions/"+created.ID+"/move", `{"move": "24/13"}`, &played)
+
+	// ASSERT
+	// The AI replied with its roll, the user has rolled again
+	if status != http.StatusOK {
+		t.Fatalf("Output %v not equal to expected %v", status, http.StatusOK)
+	}
+	if played.Turn != "white" || len(played.History) != 2 {
+		t.Fatalf("Unexpected session %+v", played)
+	}
+	if played.History[0].Color != "white" || played.History[0].Move != "24/13" {
+		t.Errorf("Output %v not equal to expected %v", played.History[0], "24/13")
+	}
+	if played.History[1].Color != "black" || played.History[1].Dice[0] != 3 {
+		t.Errorf("Unexpected AI turn %v", played.History[1])
+	}
+
+	// ACT
+	fetched := sessionJSON{}
+	doRequest(t, http.MethodGet, srv.URL+"/v1/sessions/"+created.ID, ``, &fetched)
+
+	// ASSERT
+	if fetched.Board != played.Board {
+		t.Errorf("Output %v not equal to expected %v", fetched.Board, played.Board)
+	}
+
+	// ACT
+	illegal := errorResponse{}
+	status = doRequest(t, http.MethodPost, srv.URL+"/v1/sessions/"+created.ID+"/move", `{"move": "24/1"}`, &illegal)
+
+	// ASSERT
+	if status != http.StatusUnprocessableEntity || illegal.Error.Code != CODE_ILLEGAL_MOVE {
+		t.Errorf("Output %v not equal to expected %v", illegal.Error, CODE_ILLEGAL_MOVE)
+	}
+
+	// ACT
+	resigned := sessionJSON{}
+	doRequest(t, http.MethodPost, srv.URL+"/v1/sessions/"+created.ID+"/resign", ``, &resigned)
+
+	// ASSERT
+	expectedResult := resultJSON{"black", 1, true}
+	if resigned.Result == nil || *resigned.Result != expectedResult {
+		t.Errorf("Output %v not equal to expected %v", resigned.Result, expectedResult)
+	}
+
+	// ACT
+	status = doRequest(t, http.MethodDelete, srv.URL+"/v1/sessions/"+created.ID, ``, nil)
+	gone := errorResponse{}
+	goneStatus := doRequest(t, http.MethodGet, srv.URL+"/v1/sessions/"+created.ID, ``, &gone)
+
+	// ASSERT
+	if status != http.StatusNoContent || goneStatus != http.StatusNotFound {
+		t.Errorf("Output %v %v not equal to expected %v %v", status, goneStatus, http.StatusNoContent, http.StatusNotFound)
+	}
+}
+
+func TestSessionAIStarts(t *testing.T) {
+	// ARRANGE
+	srv := newTestServer(board.DieRoll{Die1: 2, Die2: 4}, board.DieRoll{Die1: 6, Die2: 6})
+	defer srv.Close()
+	created := sessionJSON{}
+
+	// ACT
+	doRequest(t, http.MethodPost, srv.URL+"/v1/sessions", `{"ai": "heuristic"}`, &created)
+
+	// ASSERT
+	// Black wins the opening roll and the AI plays it before the session is returned
+	if len(created.History) != 1 || created.History[0].Color != "black" || created.Turn != "white" {
+		t.Fatalf("Unexpected session %+v", created)
+	}
+	if created.Dice[0] != 6 || created.Dice[1] != 6 {
+		t.Errorf("Output %v not equal to expected %v", created.Dice, []int{6, 6})
+	}
+}
+
+func TestSessionLimits(t *testing.T) {
+	// ARRANGE
+	now := time.Unix(0, 0)
+	moves := int32(0)
+	handler := New(Options{
+		AIs:                map[string]ai.AI{"heuristic": ai.EvaluatorAI{Evaluator: ai.HeuristicEvaluator{}}, "counting": countingAI{&moves}},
+		MaxSessions:        2,
+		SessionIdleTimeout: time.Minute,
+		NewDice: func(seed int64) game.Dice {
+			return &fixedDice{[]board.DieRoll{{Die1: 6, Die2: 5}}}
+		},
+	})
+	handler.now = func() time.Time { return now }
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	first, second := sessionJSON{}, sessionJSON{}
+	doRequest(t, http.MethodPost, srv.URL+"/v1/sessions", `{"ai": "heuristic"}`, &first)
+	now = now.Add(30 * time.Second)
+	doRequest(t, http.MethodPost, srv.URL+"/v1/sessions", `{"ai": "heuristic"}`, &second)
+
+	// ACT
+	full := errorResponse{}
+	// White wins the opening roll, the AI would move first
+	fullStatus := doRequest(t, http.MethodPost, srv.URL+"/v1/sessions", `{"ai": "counting", "color": "black"}`, &full)
+	now = now.Add(45 * time.Second)
+	expired := errorResponse{}
+	expiredStatus := doRequest(t, http.MethodGet, srv.URL+"/v1/sessions/"+first.ID, ``, &expired)
+	kept := sessionJSON{}
+	keptStatus := doRequest(t, http.MethodGet, srv.URL+"/v1/sessions/"+second.ID, ``, &kept)
+	createdStatus := doRequest(t, http.MethodPost, srv.URL+"/v1/sessions", `{"ai": "heuristic"}`, &sessionJSON{})
+
+	// ASSERT
+	if fullStatus != http.StatusServiceUnavailable || full.Error.Code != CODE_TOO_MANY_SESSIONS || atomic.LoadInt32(&moves) != 0 {
+		t.Errorf("Output %v %v %v not equal to expected %v %v %v", fullStatus, full.Error.Code, moves, http.StatusServiceUnavailable, CODE_TOO_MANY_SESSIONS, 0)
+	}
+	// Only the first session was idle for more than a minute
+	if expiredStatus != http.StatusNotFound || keptStatus != http.StatusOK || kept.ID != second.ID {
+		t.Errorf("Output %v %v not equal to expected %v %v", expiredStatus, keptStatus, http.StatusNotFound, http.StatusOK)
+	}
+	if createdStatus != http.StatusOK {
+		t.Errorf("Output %v not equal to expected %v", createdStatus, http.StatusOK)
+	}
+}
+
+// AI playing the first valid move roll, without a context
+type firstMoveAI struct{}
+
+func (firstMoveAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	return b.GetValidMovesForDieRoll(d)[0]
+}
+
+// AI playing the first valid move roll and counting its moves
+type countingAI struct {
+	moves *int32
+}
+
+func (a countingAI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	atomic.AddInt32(a.moves, 1)
+	return firstMoveAI{}.ChooseMove(b, d)
+}
+
+type internalErrorTest struct {
+	err            error
+	expectedStatus int
+	expectedCode   string
+}
+
+func makeInternalErrorTests() []internalErrorTest {
+	return []internalErrorTest{
+		// test 1 - the client closed the request
+		{context.Canceled, STATUS_CLIENT_CLOSED_REQUEST, CODE_CANCELLED},
+		// test 2 - the AI ran out of time
+		{fmt.Errorf("search: %w", context.DeadlineExceeded), http.StatusServiceUnavailable, CODE_TIMEOUT},
+		// test 3 - any other error
+		{errors.New("failure"), http.StatusInternalServerError, "internal"},
+	}
+}
+
+func TestInternalError(t *testing.T) {
+	for idx, test := range makeInternalErrorTests() {
+		// ACT
+		output := internalError(test.err)
+
+		// ASSERT
+		if output.status != test.expectedStatus || output.code != test.expectedCode {
+			t.Errorf("Test %d: output %v %v not equal to expected %v %v", idx+1, output.status, output.code, test.expectedStatus, test.expectedCode)
+		}
+	}
+}
+
+func makeSessionCancelledTests() []ai.AI {
+	return []ai.AI{
+		// test 1 - an AI that cannot stop thinking
+		firstMoveAI{},
+		// test 2 - a search that stops when the context is done
+		ai.EvaluatorAI{Evaluator: ai.ExpectimaxEvaluator{Evaluator: ai.HeuristicEvaluator{}, Depth: 1}},
+	}
+}
+
+func TestSessionCancelled(t *testing.T) {
+	for idx, sessionAI := range makeSessionCancelledTests() {
+		// ARRANGE
+		g := game.NewGame(board.NewBoard(board.COLOR_WHITE))
+		g.SetDice(board.DieRoll{Die1: 6, Die2: 5})
+		sess := &session{ai: sessionAI, humanColor: board.COLOR_BLACK, game: g, dice: &fixedDice{[]board.DieRoll{{Die1: 3, Die2: 1}}}}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// ACT
+		cancelledErr := sess.advance(ctx)
+		cancelledTurn := g.Board.ColorToMove
+		err := sess.advance(context.Background())
+
+		// ASSERT
+		// The turn of the AI is left to the next request
+		if cancelledErr != context.Canceled || cancelledTurn != board.COLOR_WHITE {
+			t.Errorf("Test %d: output %v %v not equal to expected %v %v", idx+1, cancelledErr, cancelledTurn, context.Canceled, board.COLOR_WHITE)
+		}
+		if err != nil || g.Board.ColorToMove != board.COLOR_BLACK || len(sess.history) != 1 {
+			t.Errorf("Test %d: output %v %v not equal to expected %v %v", idx+1, err, g.Board.ColorToMove, nil, board.COLOR_BLACK)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+)
+
+// A game between a client and an AI. The dice are rolled by the server,
+// the AI plays as soon as it is its turn, so a session is always either
+// over or waiting for a move of the client, unless a request was cancelled
+// while the AI was thinking
+type session struct {
+	mu         sync.Mutex
+	id         string
+	aiName     string
+	ai         ai.AI
+	humanColor board.Color
+	game       *game.Game
+	dice       game.Dice
+	history    []turnJSON
+	// Time the session was last used, in Unix nanoseconds
+	lastUsed atomic.Int64
+}
+
+type createSessionRequest struct {
+	AI string `json:"ai"`
+	// Color played by the client, white if empty
+	Color string `json:"color"`
+	// Seed of the dice, random if 0
+	Seed int64 `json:"seed"`
+	// Position to start from, the initial position with an opening roll if empty
+	Board string `json:"board"`
+}
+
+type sessionMoveRequest struct {
+	Move string `json:"move"`
+}
+
+type resignRequest struct {
+	Points int `json:"points"`
+}
+
+type turnJSON struct {
+	Color string `json:"color"`
+	Dice  []int  `json:"dice"`
+	Move  string `json:"move"`
+}
+
+type resultJSON struct {
+	Winner   string `json:"winner"`
+	Points   int    `json:"points"`
+	Resigned bool   `json:"resigned"`
+}
+
+type sessionJSON struct {
+	ID         string      `json:"id"`
+	AI         string      `json:"ai"`
+	Color      string      `json:"color"`
+	Board      string      `json:"board"`
+	Turn       string      `json:"turn"`
+	Dice       []int       `json:"dice,omitempty"`
+	LegalMoves []moveJSON  `json:"legalMoves"`
+	History    []turnJSON  `json:"history"`
+	Result     *resultJSON `json:"result"`
+}
+
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	response, err := s.createSession(w, r)
+	respond(w, response, err)
+}
+
+func (s *Server) createSession(w http.ResponseWriter, r *http.Request) (sessionJSON, error) {
+	request := createSessionRequest{}
+	if err := decodeBody(w, r, &request); err != nil {
+		return sessionJSON{}, err
+	}
+	opponent, ok := s.options.AIs[request.AI]
+	if !ok {
+		return sessionJSON{}, &apiError{http.StatusBadRequest, CODE_UNKNOWN_AI, "unknown AI " + request.AI + ", available AIs: " + strings.Join(s.aiNames(), ", ")}
+	}
+	humanColor := board.COLOR_WHITE
+	if request.Color != "" {
+		if humanColor, ok = parseColor(request.Color); !ok {
+			return sessionJSON{}, &apiError{http.StatusBadRequest, CODE_BAD_REQUEST, "color must be white or black"}
+		}
+	}
+
+	dice := s.options.NewDice(request.Seed)
+	var g *game.Game
+	if request.Board != "" {
+		b, err := parseBoard(request.Board)
+		if err != nil {
+			return sessionJSON{}, err
+		}
+		g = game.NewGame(b)
+	} else {
+		color, openingRoll := game.OpeningRoll(dice)
+		g = game.NewGame(board.NewBoard(color))
+		g.SetDice(openingRoll)
+	}
+
+	// The AI does not think for a session that can not be stored
+	if err := s.checkSessionLimit(); err != nil {
+		return sessionJSON{}, err
+	}
+	sess := &session{id: newSessionID(), aiName: request.AI, ai: opponent, humanColor: humanColor, game: g, dice: dice}
+	if err := sess.advance(r.Context()); err != nil {
+		return sessionJSON{}, err
+	}
+	sess.touch(s.now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Sessions may have been created while the AI was thinking
+	if err := s.sessionLimitError(); err != nil {
+		return sessionJSON{}, err
+	}
+	s.sessions[sess.id] = sess
+	return sess.toJSON(), nil
+}
+
+func (s *Server) checkSessionLimit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessionLimitError()
+}
+
+// Function that deletes the idle game sessions and returns an error if
+// there is no room for one more session. The caller holds s.mu
+func (s *Server) sessionLimitError() error {
+	s.expireSessions()
+	if len(s.sessions) >= s.options.MaxSessions {
+		return &apiError{http.StatusServiceUnavailable, CODE_TOO_MANY_SESSIONS, "too many game sessions, delete one or retry later"}
+	}
+	return nil
+}
+
+// Function that deletes the game sessions nobody used for longer than
+// the idle timeout. The caller holds s.mu
+func (s *Server) expireSessions() {
+	now := s.now()
+	for id, sess := range s.sessions {
+		if sess.idle(now, s.options.SessionIdleTimeout) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+func (sess *session) touch(now time.Time) {
+	sess.lastUsed.Store(now.UnixNano())
+}
+
+func (sess *session) idle(now time.Time, timeout time.Duration) bool {
+	return now.Sub(time.Unix(0, sess.lastUsed.Load())) > timeout
+}
+
+// Handles the endpoints under /v1/sessions/{id}
+func (s *Server) handleSession(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/sessions/"), "/")
+	now := s.now()
+	s.mu.Lock()
+	sess, ok := s.sessions[parts[0]]
+	if ok && sess.idle(now, s.options.SessionIdleTimeout) {
+		delete(s.sessions, sess.id)
+		ok = false
+	}
+	s.mu.Unlock()
+	if !ok || len(parts) > 2 {
+		writeError(w, http.StatusNotFound, CODE_NOT_FOUND, "unknown session "+parts[0])
+		return
+	}
+
+	sess.touch(now)
+
+	action := ""
+	if len(parts) == 2 {
+		action = parts[1]
+	}
+	switch action {
+	case "":
+		if !requireMethod(w, r, http.MethodGet, http.MethodDelete) {
+			return
+		}
+		if r.Method == http.MethodDelete {
+			s.mu.Lock()
+			delete(s.sessions, sess.id)
+			s.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		sess.mu.Lock()
+		defer sess.mu.Unlock()
+		// Finishes the turn of the AI if a request was cancelled during it
+		err := sess.advance(r.Context())
+		respond(w, sess.toJSON(), err)
+	case "move":
+		if !requireMethod(w, r, http.MethodPost) {
+			return
+		}
+		response, err := sess.move(w, r)
+		respond(w, response, err)
+	case "resign":
+		if !requireMethod(w, r, http.MethodPost) {
+			return
+		}
+		response, err := sess.resign(w, r)
+		respond(w, response, err)
+	default:
+		writeError(w, http.StatusNotFound, CODE_NOT_FOUND, "unknown endpoint "+r.URL.Path)
+	}
+}
+
+func (sess *session) move(w http.ResponseWriter, r *http.Request) (sessionJSON, error) {
+	request := sessionMoveRequest{}
+	if err := decodeBody(w, r, &request); err != nil {
+		return sessionJSON{}, err
+	}
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if err := sess.advance(r.Context()); err != nil {
+		return sessionJSON{}, err
+	}
+	mvRoll, err := parseMoveRoll(request.Move, sess.game.Board.ColorToMove)
+	if err != nil {
+		return sessionJSON{}, err
+	}
+	if err := sess.play(mvRoll); err != nil {
+		return sessionJSON{}, gameError(err)
+	}
+	if err := sess.advance(r.Context()); err != nil {
+		return sessionJSON{}, err
+	}
+	return sess.toJSON(), nil
+}
+
+func (sess *session) resign(w http.ResponseWriter, r *http.Request) (sessionJSON, error) {
+	request := resignRequest{Points: 1}
+	if r.ContentLength != 0 {
+		if err := decodeBody(w, r, &request); err != nil {
+			return sessionJSON{}, err
+		}
+	}
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if err := sess.game.Resign(sess.humanColor, request.Points); err != nil {
+		return sessionJSON{}, gameError(err)
+	}
+	return sess.toJSON(), nil
+}
+
+// Function that plays a move roll for the player to move and records it
+func (sess *session) play(mvRoll board.MoveRoll) error {
+	before := sess.game.Board
+	dice := sess.game.Dice
+	if err := sess.game.Play(mvRoll); err != nil {
+		return err
+	}
+	notation := ""
+	if len(mvRoll) > 0 {
+		notation = mvRoll.Notation(before)
+	}
+	sess.history = append(sess.history, turnJSON{colorName(before.ColorToMove), []int{dice.Die1, dice.Die2}, notation})
+	return nil
+}
+
+// Function that lets the AI play its turns and rolls the dice for the
+// client, until the game is over or the client has a move to make. The AI
+// stops thinking if the request is cancelled, its turn is left to the next
+// request and the error of the context is returned
+func (sess *session) advance(ctx context.Context) error {
+	for !sess.game.IsOver() {
+		if !sess.game.Rolled {
+			sess.game.Roll(sess.dice)
+		}
+		color := sess.game.Board.ColorToMove
+		if color != sess.humanColor {
+			mvRoll, err := ai.Choose(ctx, sess.ai, sess.game.Board, sess.game.Dice, nil)
+			if err == nil {
+				// The best move roll of a search cut short is not played either
+				err = ctx.Err()
+			}
+			if err != nil {
+				return err
+			}
+			if err := sess.play(mvRoll); err != nil {
+				// An AI playing an illegal move roll forfeits the game
+				sess.game.Resign(color, 1)
+			}
+			continue
+		}
+		if results, _ := sess.game.ValidMoves(); len(results) > 0 {
+			return nil
+		}
+		sess.play(board.MoveRoll{})
+	}
+	return nil
+}
+
+func (sess *session) toJSON() sessionJSON {
+	g := sess.game
+	response := sessionJSON{
+		ID:         sess.id,
+		AI:         sess.aiName,
+		Color:      colorName(sess.humanColor),
+		Board:      g.Board.SerializeBoard(),
+		Turn:       colorName(g.Board.ColorToMove),
+		LegalMoves: []moveJSON{},
+		History:    append([]turnJSON{}, sess.history...),
+	}
+	if g.IsOver() {
+		response.Result = &resultJSON{colorName(g.Result.Winner), g.Result.Points, g.Result.Resigned}
+		return response
+	}
+	if g.Rolled {
+		response.Dice = []int{g.Dice.Die1, g.Dice.Die2}
+		results, _ := g.ValidMoves()
+		for _, result := range results {
+			response.LegalMoves = append(response.LegalMoves, newMoveJSON(result))
+		}
+	}
+	return response
+}
+
+func (s *Server) aiNames() []string {
+	names := []string{}
+	for name := range s.options.AIs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,98 @@
+package skill
+
+import (
+	"math"
+	"runtime"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/analysis"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/game"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/parallel"
+)
+
+// Error rate of a level measured over a number of games
+type Measurement struct {
+	// Millipoints lost per unforced decision
+	ErrorRate float64
+	// Standard error of the error rate, two levels whose error rates are
+	// less than about three standard errors apart can not be told apart
+	StandardError float64
+	Decisions     int
+}
+
+// Equity lost by both players of a game
+type gameLoss struct {
+	decisions  int
+	equityLoss float64
+}
+
+// Function that measures the error rate of a level: the level plays the
+// given number of games against itself with the given evaluator and the
+// games are analyzed with the same evaluator, so the errors come from the
+// noise and the mistakes only. The game i rolls the dice seeded with
+// seed + i and the games are played on all the CPUs, with a nonzero seed
+// the measurement only depends on the level, the evaluator, the games and
+// the seed
+func Measure(level Level, evaluator ai.Evaluator, games int, seed int64) (Measurement, error) {
+	player := Wrap(ai.EvaluatorAI{Evaluator: evaluator}, level, seed)
+	analyzer := analysis.Analyzer{Evaluator: evaluator}
+
+	losses := make([]gameLoss, games)
+	errs := make([]error, games)
+	parallel.For(games, runtime.NumCPU(), func(idx int) {
+		losses[idx], errs[idx] = measureGame(player, analyzer, game.NewRandomDice(seed+int64(idx)))
+	})
+
+	decisions, equityLoss := 0, 0.0
+	for idx, loss := range losses {
+		if errs[idx] != nil {
+			return Measurement{}, errs[idx]
+		}
+		decisions += loss.decisions
+		equityLoss += loss.equityLoss
+	}
+	if decisions == 0 {
+		return Measurement{}, nil
+	}
+
+	// Standard error of a ratio of sums, the games being independent
+	rate := equityLoss / float64(decisions)
+	variance := 0.0
+	for _, loss := range losses {
+		deviation := loss.equityLoss - rate*float64(loss.decisions)
+		variance += deviation * deviation
+	}
+	return Measurement{
+		ErrorRate:     1000 * rate,
+		StandardError: 1000 * math.Sqrt(variance) / float64(decisions),
+		Decisions:     decisions,
+	}, nil
+}
+
+// Function that plays a game of the player against itself and returns the
+// equity both sides lost
+func measureGame(player *AI, analyzer analysis.Analyzer, dice game.Dice) (gameLoss, error) {
+	color, openingRoll := game.OpeningRoll(dice)
+	g := game.NewGame(board.NewBoard(color))
+	g.SetDice(openingRoll)
+	for !g.IsOver() {
+		if !g.Rolled {
+			g.Roll(dice)
+		}
+		if err := g.Play(player.ChooseMove(g.Board, g.Dice)); err != nil {
+			return gameLoss{}, err
+		}
+	}
+
+	report, err := analyzer.Analyze(g.Record(game.Players{White: player.Level.Name, Black: player.Level.Name}))
+	if err != nil {
+		return gameLoss{}, err
+	}
+	loss := gameLoss{}
+	for _, stats := range []analysis.PlayerStats{report.White, report.Black} {
+		loss.decisions += stats.Decisions
+		loss.equityLoss += stats.EquityLoss
+	}
+	return loss, nil
+}
@@ -0,0 +1,170 @@
+// Package skill makes weaker AI players: it wraps evaluator based AIs so
+// they add noise to their equities, sometimes play one of the next best
+// move rolls and miss cube actions, with named levels calibrated against
+// the equity loss measured by the game analyzer
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/cube"
+)
+
+var ErrUnknownLevel = errors.New("unknown skill level")
+
+type Level struct {
+	Name string
+	// Standard deviation of the gaussian noise added to the equity of every move roll
+	Noise float64
+	// Probability of playing one of the next best move rolls instead of
+	// the best one after the noise
+	MistakeRate float64
+	// The mistakes are picked among the 2nd to the MistakeDepth-th best move rolls
+	MistakeDepth int
+	// Probability of doing the wrong cube action
+	CubeMissRate float64
+	// Error rate of the level measured by Measure with the heuristic
+	// evaluator, in millipoints per unforced decision
+	ErrorRate float64
+}
+
+// Levels from the weakest to the strongest
+// NOTE: the error rates were measured with Measure over 600 games per level
+// with seed 1, about 30000 decisions each, their standard errors are below
+// 1 millipoint. They have to be measured again when the levels change,
+// e.g. with backgammon-arena -calibrate -games 600 -seed 1
+var LEVELS = []Level{
+	{Name: "beginner", Noise: 0.14, MistakeRate: 0.2, MistakeDepth: 5, CubeMissRate: 0.3, ErrorRate: 59.7},
+	{Name: "casual", Noise: 0.1, MistakeRate: 0.1, MistakeDepth: 4, CubeMissRate: 0.2, ErrorRate: 32.7},
+	{Name: "intermediate", Noise: 0.08, MistakeRate: 0.07, MistakeDepth: 3, CubeMissRate: 0.12, ErrorRate: 22.2},
+	{Name: "advanced", Noise: 0.065, MistakeRate: 0.04, MistakeDepth: 3, CubeMissRate: 0.06, ErrorRate: 16.1},
+	{Name: "expert", Noise: 0.045, MistakeRate: 0.02, MistakeDepth: 2, CubeMissRate: 0.03, ErrorRate: 8.4},
+	{Name: "world-class", Noise: 0.03, MistakeRate: 0.01, MistakeDepth: 2, CubeMissRate: 0.01, ErrorRate: 4.5},
+}
+
+// Function that returns the level with the given name
+func LevelByName(name string) (Level, error) {
+	for _, level := range LEVELS {
+		if level.Name == name {
+			return level, nil
+		}
+	}
+	names := []string{}
+	for _, level := range LEVELS {
+		names = append(names, level.Name)
+	}
+	return Level{}, fmt.Errorf("%w %q, levels: %s", ErrUnknownLevel, name, strings.Join(names, ", "))
+}
+
+// AI playing at a skill level with the evaluator of an evaluator based AI.
+// With a nonzero seed, its random choices are drawn from a generator seeded
+// with the seed, the position and the dice, so they do not depend on the
+// games played before or at the same time: games replayed with the same
+// dice are the same, e.g. in the arena. With seed 0 they are drawn from a
+// generator seeded from the clock and shared by the games, e.g. for
+// interactive play. It is safe for concurrent use
+type AI struct {
+	Evaluator ai.Evaluator
+	Level     Level
+	Seed      int64
+
+	mutex sync.Mutex
+	clock *rand.Rand
+}
+
+// Function that wraps an evaluator based AI, the noise is drawn with the
+// given seed, 0 for a seed from the clock
+func Wrap(base ai.EvaluatorAI, level Level, seed int64) *AI {
+	player := &AI{Evaluator: base.Evaluator, Level: level, Seed: seed}
+	if seed == 0 {
+		player.clock = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return player
+}
+
+// Function that returns the random generator of a decision in a position,
+// the decisions in the same position draw from different generators
+func (a *AI) rng(b board.Board, decision string) *rand.Rand {
+	if a.clock == nil {
+		return ai.PositionRand(a.Seed, b, decision)
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return rand.New(rand.NewSource(a.clock.Int63()))
+}
+
+func (a *AI) ChooseMove(b board.Board, d board.DieRoll) board.MoveRoll {
+	candidates := ai.Hint(b, d, 0, ai.HintOptions{Evaluator: a.Evaluator})
+	if len(candidates) == 0 {
+		return board.MoveRoll{}
+	}
+
+	rng := a.rng(b, fmt.Sprintf("move %d-%d", d.Die1, d.Die2))
+	noisy := make([]float64, len(candidates))
+	for idx, candidate := range candidates {
+		noisy[idx] = candidate.Equity + rng.NormFloat64()*a.Level.Noise
+	}
+	ranked := make([]int, len(candidates))
+	for idx := range ranked {
+		ranked[idx] = idx
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return noisy[ranked[i]] > noisy[ranked[j]] })
+
+	choice := 0
+	worst := a.Level.MistakeDepth
+	if worst > len(ranked) {
+		worst = len(ranked)
+	}
+	if worst > 1 && rng.Float64() < a.Level.MistakeRate {
+		choice = 1 + rng.Intn(worst-1)
+	}
+	return candidates[ranked[choice]].MoveRoll
+}
+
+// Function that decides whether to double given the cube decision of the
+// player to move, the proper action is missed with the cube miss rate.
+// It implements cube.Player
+func (a *AI) ShouldDouble(b board.Board, decision cube.Decision) bool {
+	return decision.Double() != a.miss(b, "double")
+}
+
+// Function that decides whether to take a double given the cube decision
+// of the doubler, the proper response is missed with the cube miss rate
+func (a *AI) ShouldTake(b board.Board, decision cube.Decision) bool {
+	return decision.Take != a.miss(b, "take")
+}
+
+func (a *AI) miss(b board.Board, decision string) bool {
+	return a.rng(b, decision).Float64() < a.Level.CubeMissRate
+}
+
+func init() {
+	ai.Register(ai.Factory{
+		Name:  "skill",
+		Usage: "plays at a skill level: beginner, casual, intermediate, advanced, expert or world-class",
+		Options: []ai.Option{
+			{Name: "level", Type: ai.OPTION_STRING, Default: "intermediate", Usage: "skill level"},
+			{Name: "evaluator", Type: ai.OPTION_EVALUATOR, Default: "heuristic", Usage: "evaluator of the positions"},
+			{Name: "noise", Type: ai.OPTION_FLOAT, Default: "-1", Usage: "standard deviation of the equity noise, negative for the noise of the level"},
+			{Name: "seed", Type: ai.OPTION_INT, Default: "0", Usage: "seed of the noise, 0 for a seed from the clock, otherwise the choices only depend on the seed, the position and the dice"},
+		},
+		New: func(options ai.Options) (ai.AI, error) {
+			level, err := LevelByName(options.String("level"))
+			if err != nil {
+				return nil, err
+			}
+			if noise := options.Float("noise"); noise >= 0 {
+				level.Noise = noise
+			}
+			return Wrap(ai.EvaluatorAI{Evaluator: options.Evaluator("evaluator")}, level, int64(options.Int("seed"))), nil
+		},
+	})
+}
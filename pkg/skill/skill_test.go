@@ -0,0 +1,106 @@
+package skill
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/ai"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/board"
+	"github.com/GeorgianBadita/backgammon-move-generator/pkg/cube"
+)
+
+var heuristic = ai.EvaluatorAI{Evaluator: ai.HeuristicEvaluator{}}
+
+// Function that returns the position a move roll leads to
+func position(b board.Board, mvRoll board.MoveRoll) string {
+	return mvRoll.MakeMoveRoll(b).SerializeBoard()
+}
+
+func TestLevels(t *testing.T) {
+	for idx, level := range LEVELS {
+		// ACT
+		output, err := LevelByName(level.Name)
+
+		// ASSERT
+		if err != nil || output != level {
+			t.Errorf("Output %v %v not equal to expected %v", output, err, level)
+		}
+		if idx > 0 && level.ErrorRate >= LEVELS[idx-1].ErrorRate {
+			t.Errorf("Level %s should make less errors than %s", level.Name, LEVELS[idx-1].Name)
+		}
+	}
+	if _, err := LevelByName("grandmaster"); !errors.Is(err, ErrUnknownLevel) {
+		t.Errorf("Output %v not equal to expected %v", err, ErrUnknownLevel)
+	}
+}
+
+func TestChooseMove(t *testing.T) {
+	// ARRANGE
+	b := board.NewBoard(board.COLOR_WHITE)
+	dice := board.DieRoll{Die1: 6, Die2: 4}
+	candidates := ai.Hint(b, dice, 2, ai.HintOptions{})
+	perfect := Wrap(heuristic, Level{Name: "perfect"}, 1)
+	wrong := Wrap(heuristic, Level{Name: "wrong", MistakeRate: 1, MistakeDepth: 2}, 1)
+	clocked := Wrap(heuristic, Level{Name: "wrong", MistakeRate: 1, MistakeDepth: 2}, 0)
+
+	for idx := 0; idx < 5; idx++ {
+		// ACT
+		best := perfect.ChooseMove(b, dice)
+		second := wrong.ChooseMove(b, dice)
+		clockedSecond := clocked.ChooseMove(b, dice)
+
+		// ASSERT
+		if position(b, best) != position(b, candidates[0].MoveRoll) {
+			t.Errorf("Output %v not equal to expected %v", best.Notation(b), candidates[0].Notation)
+		}
+		if position(b, second) != position(b, candidates[1].MoveRoll) || position(b, clockedSecond) != position(b, candidates[1].MoveRoll) {
+			t.Errorf("Output %v %v not equal to expected %v", second.Notation(b), clockedSecond.Notation(b), candidates[1].Notation)
+		}
+	}
+}
+
+func TestCubeActions(t *testing.T) {
+	// ARRANGE
+	decision := cube.Decision{Action: cube.ACTION_DOUBLE_TAKE, Take: true}
+	perfect := Wrap(heuristic, Level{Name: "perfect"}, 1)
+	wrong := Wrap(heuristic, Level{Name: "wrong", CubeMissRate: 1}, 1)
+
+	// ACT & ASSERT
+	b := board.NewBoard(board.COLOR_WHITE)
+	if !perfect.ShouldDouble(b, decision) || !perfect.ShouldTake(b, decision) {
+		t.Errorf("Output %v not equal to expected %v", false, true)
+	}
+	if wrong.ShouldDouble(b, decision) || wrong.ShouldTake(b, decision) {
+		t.Errorf("Output %v not equal to expected %v", true, false)
+	}
+}
+
+func TestRegisteredSkill(t *testing.T) {
+	// ACT
+	output, err := ai.New("skill:level=expert,seed=3,noise=0")
+	interactive, interactiveErr := ai.New("skill")
+	_, unknownErr := ai.New("skill:level=grandmaster")
+
+	// ASSERT
+	skilled, ok := output.(*AI)
+	if err != nil || !ok || skilled.Level.Name != "expert" || skilled.Level.Noise != 0 || skilled.clock != nil {
+		t.Errorf("Output %v %v not equal to expected %v", output, err, "a seeded expert without noise")
+	}
+	// The default seed draws from the clock
+	if clocked, ok := interactive.(*AI); interactiveErr != nil || !ok || clocked.Seed != 0 || clocked.clock == nil {
+		t.Errorf("Output %v %v not equal to expected %v", interactive, interactiveErr, "an AI seeded from the clock")
+	}
+	if !errors.Is(unknownErr, ErrUnknownLevel) {
+		t.Errorf("Output %v not equal to expected %v", unknownErr, ErrUnknownLevel)
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	// ACT
+	measurement, err := Measure(Level{Name: "perfect"}, ai.HeuristicEvaluator{}, 1, 1)
+
+	// ASSERT
+	if err != nil || measurement.ErrorRate != 0 || measurement.StandardError != 0 || measurement.Decisions == 0 {
+		t.Errorf("Output %v %v not equal to expected %v", measurement, err, 0)
+	}
+}